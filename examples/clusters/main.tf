@@ -0,0 +1,153 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_cluster" "example" {
+  name               = "foo"
+  description        = "Terraform provider acceptance testing cluster"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "foo"
+  cluster_group_name = "default"
+
+  labels = {
+    env       = "test"
+    createdby = "Terraform"
+  }
+
+  tkg_aws {
+    region             = "us-west-2"
+    version            = "1.20.5-1-amazon2"
+    credential_name    = "aws-credential"
+    availability_zones = ["us-west-2a", "us-west-2b", "us-west-2c"]
+    high_availability  = true
+    instance_type      = "m5.large"
+    ssh_key            = "default"
+
+    vpc_cidrblock       = "10.0.0.0/16"
+    pods_cidrblocks     = ["192.168.0.0/16"]
+    services_cidrblocks = ["10.96.0.0/12"]
+
+    node_pool {
+      name              = "default-node-pool"
+      worker_node_count = 3
+      instance_type     = "m5.large"
+      availability_zone = "us-west-2a"
+    }
+  }
+}
+
+resource "tmc_cluster" "vsphere" {
+  name               = "bar"
+  management_cluster = "vsphere-tkg"
+  provisioner_name   = "default"
+
+  tkg_vsphere {
+    version                = "v1.20.5+vmware.2-tkg.1"
+    datacenter             = "/dc0"
+    datastore              = "/dc0/datastore/local-0"
+    folder                 = "/dc0/vm"
+    resource_pool          = "/dc0/host/cluster0/Resources"
+    workspace_network      = "/dc0/network/VM Network"
+    control_plane_endpoint = "10.0.0.10"
+    ssh_key                = "ssh-rsa AAAA..."
+
+    control_plane {
+      cpu        = 2
+      memory_mib = 8192
+      disk_gib   = 40
+    }
+
+    node_pool {
+      name              = "default-nodepool"
+      worker_node_count = 3
+      cpu               = 2
+      memory_mib        = 8192
+      disk_gib          = 40
+    }
+  }
+}
+
+data "tmc_tkgs_options" "supervisor" {
+  management_cluster_name = "supervisor"
+  provisioner_name        = "dev-namespace"
+}
+
+resource "tmc_cluster" "tkgs" {
+  name               = "baz"
+  management_cluster = "supervisor"
+  provisioner_name   = "dev-namespace"
+
+  tkg_service_vsphere {
+    version         = data.tmc_tkgs_options.supervisor.tkr_versions[0]
+    storage_classes = data.tmc_tkgs_options.supervisor.storage_classes
+
+    control_plane {
+      class         = "best-effort-small"
+      storage_class = data.tmc_tkgs_options.supervisor.storage_classes[0]
+      replicas      = 3
+    }
+
+    node_pool {
+      name              = "default-nodepool"
+      worker_node_count = 3
+      class             = "best-effort-medium"
+      storage_class     = data.tmc_tkgs_options.supervisor.storage_classes[0]
+
+      volume {
+        name       = "containerd"
+        mount_path = "/var/lib/containerd"
+        capacity   = 50
+      }
+    }
+  }
+}
+
+resource "tmc_cluster_nodepool" "gpu" {
+  name               = "gpu-nodepool"
+  management_cluster = tmc_cluster.vsphere.management_cluster
+  provisioner_name   = tmc_cluster.vsphere.provisioner_name
+  cluster_name       = tmc_cluster.vsphere.name
+  worker_node_count  = 2
+
+  node_labels = {
+    "accelerator" = "gpu"
+  }
+
+  taint {
+    key    = "dedicated"
+    value  = "gpu"
+    effect = "NoSchedule"
+  }
+
+  autoscaling {
+    min_count = 1
+    max_count = 4
+  }
+
+  tkg_vsphere {
+    cpu        = 8
+    memory_mib = 32768
+    disk_gib   = 80
+  }
+}
+
+resource "tmc_cluster_attachment" "eks" {
+  name               = "qux"
+  cluster_group_name = "default"
+  kubeconfig_file    = pathexpand("~/.kube/eks-config")
+}
+
+data "tmc_cluster" "example" {
+  name               = tmc_cluster.example.name
+  management_cluster = tmc_cluster.example.management_cluster
+  provisioner_name   = tmc_cluster.example.provisioner_name
+}
+
+data "tmc_cluster_nodepools" "vsphere" {
+  management_cluster = tmc_cluster_nodepool.gpu.management_cluster
+  provisioner_name   = tmc_cluster_nodepool.gpu.provisioner_name
+  cluster_name       = tmc_cluster_nodepool.gpu.cluster_name
+}
\ No newline at end of file
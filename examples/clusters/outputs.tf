@@ -0,0 +1,6 @@
+output "example_cluster" {
+  value = data.tmc_cluster.example
+}
+output "vsphere_nodepools" {
+  value = data.tmc_cluster_nodepools.vsphere.names
+}
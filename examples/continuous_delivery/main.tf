@@ -0,0 +1,58 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_continuous_delivery" "production" {
+  cluster_group_name = "production"
+}
+
+resource "tmc_git_repository" "fleet" {
+  name               = "fleet"
+  cluster_group_name = tmc_continuous_delivery.production.cluster_group_name
+  url                = "https://github.com/my-org/fleet"
+  secret_name        = "fleet-deploy-key"
+  interval           = "10m"
+
+  ref {
+    branch = "main"
+  }
+}
+
+resource "tmc_kustomization" "apps" {
+  name                = "apps"
+  cluster_group_name  = tmc_git_repository.fleet.cluster_group_name
+  git_repository_name = tmc_git_repository.fleet.name
+  path                = "/apps/production"
+  prune               = true
+}
+
+resource "tmc_continuous_delivery" "edge" {
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+}
+
+resource "tmc_git_repository" "edge" {
+  name               = "fleet"
+  management_cluster = tmc_continuous_delivery.edge.management_cluster
+  provisioner_name   = tmc_continuous_delivery.edge.provisioner_name
+  cluster_name       = tmc_continuous_delivery.edge.cluster_name
+  url                = "https://github.com/my-org/fleet"
+
+  ref {
+    semver = ">= 1.0.0"
+  }
+}
+
+resource "tmc_kustomization" "edge" {
+  name                = "edge"
+  management_cluster  = tmc_git_repository.edge.management_cluster
+  provisioner_name    = tmc_git_repository.edge.provisioner_name
+  cluster_name        = tmc_git_repository.edge.cluster_name
+  git_repository_name = tmc_git_repository.edge.name
+  path                = "/clusters/my-cluster"
+  target_namespace    = "edge"
+}
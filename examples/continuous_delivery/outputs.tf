@@ -0,0 +1,7 @@
+output "fleet_phase" {
+  value = tmc_git_repository.fleet.phase
+}
+
+output "apps_phase" {
+  value = tmc_kustomization.apps.phase
+}
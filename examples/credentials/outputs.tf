@@ -0,0 +1,11 @@
+output "cloudformation_template" {
+  value = tmc_credential.aws.cloudformation_template
+}
+
+output "aws_credential_phase" {
+  value = tmc_credential.aws.phase
+}
+
+output "data_protection_credentials" {
+  value = data.tmc_credentials.data_protection.names
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_policy_template" "required_labels" {
+  name        = "requiredlabels"
+  description = "Requires objects to carry the given labels"
+  rego        = file("${path.module}/requiredlabels.rego")
+
+  parameters_schema = jsonencode({
+    properties = {
+      labels = {
+        type  = "array"
+        items = { type = "string" }
+      }
+    }
+  })
+}
+
+resource "tmc_custom_policy" "required_labels" {
+  name               = "team-labels"
+  cluster_group_name = "default"
+  template_name      = tmc_policy_template.required_labels.name
+
+  parameters = jsonencode({
+    labels = ["team", "owner"]
+  })
+
+  target_kubernetes_resources {
+    api_groups = ["apps"]
+    kinds      = ["Deployment", "StatefulSet"]
+  }
+}
@@ -0,0 +1,3 @@
+output "custom_policy" {
+  value = tmc_custom_policy.required_labels.id
+}
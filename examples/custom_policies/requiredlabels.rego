@@ -0,0 +1,10 @@
+package requiredlabels
+
+# Objects have to carry all the labels listed in the parameters
+violation[{"msg": msg}] {
+  provided := {label | input.review.object.metadata.labels[label]}
+  required := {label | label := input.parameters.labels[_]}
+  missing := required - provided
+  count(missing) > 0
+  msg := sprintf("missing labels: %v", [missing])
+}
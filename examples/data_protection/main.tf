@@ -0,0 +1,54 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_data_protection" "cluster" {
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+}
+
+resource "tmc_backup_location" "s3" {
+  name                    = "nightly-backups"
+  credential_name         = "my-aws-credential"
+  assigned_cluster_groups = ["default"]
+
+  s3 {
+    bucket = "my-cluster-backups"
+    region = "us-west-2"
+  }
+}
+
+resource "tmc_backup_schedule" "nightly" {
+  name                 = "nightly"
+  management_cluster   = tmc_data_protection.cluster.management_cluster
+  provisioner_name     = tmc_data_protection.cluster.provisioner_name
+  cluster_name         = tmc_data_protection.cluster.cluster_name
+  schedule             = "0 2 * * *"
+  excluded_namespaces  = ["kube-system", "vmware-system-tmc"]
+  ttl                  = "720h"
+  backup_location_name = tmc_backup_location.s3.name
+}
+
+data "tmc_backups" "nightly" {
+  management_cluster = tmc_backup_schedule.nightly.management_cluster
+  provisioner_name   = tmc_backup_schedule.nightly.provisioner_name
+  cluster_name       = tmc_backup_schedule.nightly.cluster_name
+  schedule_name      = tmc_backup_schedule.nightly.name
+}
+
+resource "tmc_backup_restore" "team_a" {
+  name                = "restore-team-a"
+  management_cluster  = tmc_backup_schedule.nightly.management_cluster
+  provisioner_name    = tmc_backup_schedule.nightly.provisioner_name
+  cluster_name        = tmc_backup_schedule.nightly.cluster_name
+  backup_name         = data.tmc_backups.nightly.names[0]
+  included_namespaces = ["team-a"]
+
+  timeouts {
+    create = "2h"
+  }
+}
@@ -0,0 +1,11 @@
+output "backup_location_phase" {
+  value = tmc_backup_location.s3.phase
+}
+
+output "nightly_backups" {
+  value = data.tmc_backups.nightly.names
+}
+
+output "restore_warnings" {
+  value = tmc_backup_restore.team_a.warnings
+}
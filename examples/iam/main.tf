@@ -0,0 +1,38 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_workspace" "example" {
+  name = "foo"
+}
+
+# Authoritative: bindings of the workspace set outside of Terraform are removed
+resource "tmc_workspace_iam_policy" "example" {
+  workspace_name = tmc_workspace.example.name
+
+  role_binding {
+    role   = "workspace.edit"
+    users  = ["jane@example.com"]
+    groups = ["developers"]
+  }
+
+  role_binding {
+    role   = "workspace.view"
+    groups = ["auditors"]
+  }
+}
+
+# Additive: only the given binding is managed
+resource "tmc_cluster_group_iam_member" "example" {
+  cluster_group_name = "default"
+  role               = "clustergroup.admin"
+  group              = "platform"
+}
+
+resource "tmc_organization_iam_member" "example" {
+  role = "organization.view"
+  user = "jane@example.com"
+}
@@ -0,0 +1,7 @@
+output "workspace_policy" {
+  value = tmc_workspace_iam_policy.example.id
+}
+
+output "cluster_group_binding" {
+  value = tmc_cluster_group_iam_member.example.id
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_cluster_inspection" "cis" {
+  name               = "cis-2021-q3"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+  type               = "cis"
+}
+
+resource "tmc_cluster_inspection" "lite" {
+  name                = "lite-2021-q3"
+  management_cluster  = "aws-hosted"
+  provisioner_name    = "my-provisioner"
+  cluster_name        = "my-cluster"
+  type                = "lite"
+  wait_for_completion = false
+}
+
+data "tmc_cluster_inspections" "cis" {
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+  type               = "cis"
+
+  depends_on = [tmc_cluster_inspection.cis]
+}
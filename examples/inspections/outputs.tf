@@ -0,0 +1,11 @@
+output "cis_failed_checks" {
+  value = tmc_cluster_inspection.cis.failed
+}
+
+output "cis_report_url" {
+  value = tmc_cluster_inspection.cis.report_url
+}
+
+output "cis_inspections" {
+  value = data.tmc_cluster_inspections.cis.inspections
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_cluster_integration" "observability" {
+  name               = "tanzu-observability-saas"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+
+  tanzu_observability {
+    url   = "https://example.wavefront.com"
+    token = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "tmc_cluster_integration" "service_mesh" {
+  name               = "tanzu-service-mesh"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+
+  tanzu_service_mesh {
+    namespace_exclusion {
+      match = "kube-system"
+    }
+
+    namespace_exclusion {
+      match = "vmware-system-"
+      type  = "START_WITH"
+    }
+  }
+}
@@ -0,0 +1,7 @@
+output "observability_health" {
+  value = tmc_cluster_integration.observability.health
+}
+
+output "service_mesh_health" {
+  value = tmc_cluster_integration.service_mesh.health
+}
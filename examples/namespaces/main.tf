@@ -0,0 +1,49 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_workspace" "example" {
+  name = "foo"
+}
+
+resource "tmc_namespace" "example" {
+  name               = "team-a"
+  description        = "Namespace of team A"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+  workspace_name     = tmc_workspace.example.name
+
+  labels = {
+    team = "a"
+  }
+}
+
+# The namespace already exists in the cluster, TMC takes it over
+resource "tmc_namespace" "existing" {
+  name               = "monitoring"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+  workspace_name     = tmc_workspace.example.name
+  attach             = true
+}
+
+data "tmc_namespace" "example" {
+  name               = tmc_namespace.example.name
+  management_cluster = tmc_namespace.example.management_cluster
+  provisioner_name   = tmc_namespace.example.provisioner_name
+  cluster_name       = tmc_namespace.example.cluster_name
+}
+
+data "tmc_namespaces" "workspace" {
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+  workspace_name     = tmc_workspace.example.name
+
+  depends_on = [tmc_namespace.example, tmc_namespace.existing]
+}
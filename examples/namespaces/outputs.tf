@@ -0,0 +1,7 @@
+output "example_namespace" {
+  value = data.tmc_namespace.example
+}
+
+output "workspace_namespaces" {
+  value = data.tmc_namespaces.workspace.names
+}
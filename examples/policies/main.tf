@@ -0,0 +1,87 @@
+terraform {
+  required_version = ">= 0.15"
+}
+
+provider "tmc" {
+}
+
+resource "tmc_policy" "security" {
+  name               = "baseline"
+  cluster_group_name = "default"
+
+  security {
+    baseline {
+      disable_native_psp = true
+    }
+  }
+
+  # System namespaces are left alone
+  namespace_selector {
+    match_expression {
+      key      = "kubernetes.io/metadata.name"
+      operator = "NotIn"
+      values   = ["kube-system", "vmware-system-tmc"]
+    }
+  }
+}
+
+resource "tmc_policy" "quota" {
+  name               = "quota"
+  cluster_group_name = "default"
+
+  quota {
+    custom {
+      requests_cpu     = "4"
+      requests_memory  = "8Gi"
+      limits_cpu       = "8"
+      limits_memory    = "16Gi"
+      requests_storage = "100Gi"
+    }
+  }
+}
+
+resource "tmc_policy" "registries" {
+  name           = "registries"
+  workspace_name = "foo"
+
+  image_registry {
+    custom {
+      rule {
+        hostname = "registry.example.com"
+      }
+
+      rule {
+        hostname   = "docker.io"
+        image_name = "bitnami/*"
+      }
+    }
+  }
+}
+
+resource "tmc_policy" "ingress" {
+  name               = "web-ingress"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "my-provisioner"
+  cluster_name       = "my-cluster"
+  namespace_name     = "team-a"
+
+  network {
+    custom_ingress {
+      to_pod_labels = {
+        app = "web"
+      }
+
+      rule {
+        port {
+          port = "8443"
+        }
+
+        namespace_selector {
+          labels = {
+            role = "ingress"
+          }
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,7 @@
+output "security_policy" {
+  value = tmc_policy.security.id
+}
+
+output "ingress_policy" {
+  value = tmc_policy.ingress.id
+}
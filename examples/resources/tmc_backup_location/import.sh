@@ -0,0 +1,2 @@
+# Backup locations can be imported using their name
+terraform import tmc_backup_location.example my-backup-location
@@ -0,0 +1,2 @@
+# Restores can be imported using the management cluster, provisioner, cluster and restore names
+terraform import tmc_backup_restore.example aws-hosted/my-provisioner/my-cluster/restore-team-a
@@ -0,0 +1,2 @@
+# Backup schedules can be imported using the management cluster, provisioner, cluster and schedule names
+terraform import tmc_backup_schedule.example aws-hosted/my-provisioner/my-cluster/nightly
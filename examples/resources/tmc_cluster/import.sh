@@ -0,0 +1,2 @@
+# Clusters can be imported using the management cluster, provisioner and cluster names
+terraform import tmc_cluster.example aws-hosted/my-provisioner/my-cluster
@@ -0,0 +1,2 @@
+# Attached clusters can be imported using their name
+terraform import tmc_cluster_attachment.example my-cluster
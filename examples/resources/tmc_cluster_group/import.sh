@@ -0,0 +1,2 @@
+# Cluster groups can be imported using their name
+terraform import tmc_cluster_group.example my-cluster-group
@@ -0,0 +1,2 @@
+# Role bindings of cluster groups can be imported using the cluster group name, the role and the member, prefixed with user: or group:
+terraform import tmc_cluster_group_iam_member.example my-cluster-group/clustergroup.edit/group:developers
@@ -0,0 +1,2 @@
+# Access policies of cluster groups can be imported using the cluster group name
+terraform import tmc_cluster_group_iam_policy.example my-cluster-group
@@ -0,0 +1,2 @@
+# Role bindings of clusters can be imported using the management cluster, provisioner and cluster names, the role and the member, prefixed with user: or group:
+terraform import tmc_cluster_iam_member.example aws-hosted/my-provisioner/my-cluster/cluster.admin/group:platform
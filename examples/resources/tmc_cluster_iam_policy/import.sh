@@ -0,0 +1,2 @@
+# Access policies of clusters can be imported using the management cluster, provisioner and cluster names
+terraform import tmc_cluster_iam_policy.example aws-hosted/my-provisioner/my-cluster
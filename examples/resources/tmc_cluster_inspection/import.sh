@@ -0,0 +1,2 @@
+# Cluster inspections can be imported using the management cluster, provisioner, cluster and inspection names
+terraform import tmc_cluster_inspection.example aws-hosted/my-provisioner/my-cluster/cis-2021-q3
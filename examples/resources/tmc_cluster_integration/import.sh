@@ -0,0 +1,2 @@
+# Cluster integrations can be imported using the management cluster, provisioner, cluster and integration names
+terraform import tmc_cluster_integration.example aws-hosted/my-provisioner/my-cluster/tanzu-service-mesh
@@ -0,0 +1,2 @@
+# Node pools can be imported using the management cluster, provisioner, cluster and node pool names
+terraform import tmc_cluster_nodepool.example vsphere-tkg/default/my-cluster/my-nodepool
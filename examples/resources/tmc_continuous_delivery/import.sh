@@ -0,0 +1,2 @@
+# Continuous delivery can be imported using the kind of object it is enabled on and the names of the object
+terraform import tmc_continuous_delivery.example cluster_group/my-group
@@ -0,0 +1,2 @@
+# Credentials can be imported using their name, the secrets TMC never returns are then left empty
+terraform import tmc_credential.example aws-credential
@@ -0,0 +1,2 @@
+# Custom policies can be imported using the kind of object they are attached to, the names of the object and the name of the policy
+terraform import tmc_custom_policy.example cluster_group/my-cluster-group/my-policy
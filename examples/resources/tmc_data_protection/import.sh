@@ -0,0 +1,2 @@
+# Data protection can be imported using the management cluster, provisioner and cluster names
+terraform import tmc_data_protection.example aws-hosted/my-provisioner/my-cluster
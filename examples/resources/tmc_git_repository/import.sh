@@ -0,0 +1,2 @@
+# Git repositories can be imported using the kind of object they are applied to, the names of the object, the namespace and the name of the Git repository
+terraform import tmc_git_repository.example cluster/aws-hosted/my-provisioner/my-cluster/tanzu-continuousdelivery-resources/fleet
@@ -0,0 +1,2 @@
+# Kustomizations can be imported using the kind of object they are applied to, the names of the object, the namespace and the name of the Kustomization
+terraform import tmc_kustomization.example cluster_group/my-group/tanzu-continuousdelivery-resources/apps
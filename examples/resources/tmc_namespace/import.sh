@@ -0,0 +1,2 @@
+# Namespaces can be imported using the management cluster, provisioner, cluster and namespace names
+terraform import tmc_namespace.example aws-hosted/my-provisioner/my-cluster/my-namespace
@@ -0,0 +1,2 @@
+# Role bindings of namespaces can be imported using the management cluster, provisioner, cluster and namespace names, the role and the member, prefixed with user: or group:
+terraform import tmc_namespace_iam_member.example aws-hosted/my-provisioner/my-cluster/my-namespace/namespace.edit/user:jane@example.com
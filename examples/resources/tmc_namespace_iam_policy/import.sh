@@ -0,0 +1,2 @@
+# Access policies of namespaces can be imported using the management cluster, provisioner, cluster and namespace names
+terraform import tmc_namespace_iam_policy.example aws-hosted/my-provisioner/my-cluster/my-namespace
@@ -0,0 +1,2 @@
+# Role bindings of the organization can be imported using the role and the member, prefixed with user: or group:
+terraform import tmc_organization_iam_member.example organization/organization.view/user:jane@example.com
@@ -0,0 +1,2 @@
+# The access policy of the organization is imported with the organization ID
+terraform import tmc_organization_iam_policy.example organization
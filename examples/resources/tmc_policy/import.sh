@@ -0,0 +1,5 @@
+# Policies can be imported using the kind of object they are attached to, the names of the object and the name of the policy
+terraform import tmc_policy.cluster_group cluster_group/my-cluster-group/my-policy
+terraform import tmc_policy.workspace workspace/my-workspace/my-policy
+terraform import tmc_policy.cluster cluster/aws-hosted/my-provisioner/my-cluster/my-policy
+terraform import tmc_policy.namespace namespace/aws-hosted/my-provisioner/my-cluster/my-namespace/my-policy
@@ -0,0 +1,2 @@
+# Policy templates can be imported using their name
+terraform import tmc_policy_template.example requiredlabels
@@ -0,0 +1,2 @@
+# Workspaces can be imported using their name
+terraform import tmc_workspace.example my-workspace
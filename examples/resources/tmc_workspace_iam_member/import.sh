@@ -0,0 +1,2 @@
+# Role bindings of workspaces can be imported using the workspace name, the role and the member, prefixed with user: or group:
+terraform import tmc_workspace_iam_member.example my-workspace/workspace.edit/user:jane@example.com
@@ -0,0 +1,2 @@
+# Access policies of workspaces can be imported using the workspace name
+terraform import tmc_workspace_iam_policy.example my-workspace
@@ -22,4 +22,17 @@ data "tmc_workspace" "example" {
   name = tmc_workspace.example.name
 }
 
-data "tmc_workspaces" "all" {}
\ No newline at end of file
+data "tmc_workspaces" "all" {}
+
+data "tmc_workspaces" "non_production" {
+  filter {
+    label    = "env"
+    operator = "not_equals"
+    values   = ["prod", "production"]
+  }
+
+  filter {
+    label    = "createdby"
+    operator = "exists"
+  }
+}
@@ -5,4 +5,5 @@ go 1.15
 require (
 	github.com/hashicorp/terraform-plugin-docs v0.5.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.8.0
+	github.com/open-policy-agent/opa v0.42.2
 )
@@ -0,0 +1,238 @@
+package tmcfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// fluxScopeFields are the fullName fields of the cluster or cluster group
+// continuous delivery is enabled on, only the ones of the scope are set.
+var fluxScopeFields = []string{"clusterGroupName", "managementClusterName", "provisionerName", "clusterName"}
+
+// fluxKeyFields identify the Flux objects applied to a namespace of the
+// clusters of a scope.
+var fluxKeyFields = []string{"clusterGroupName", "managementClusterName", "provisionerName", "clusterName", "namespaceName"}
+
+var (
+	// The continuous delivery of a cluster or cluster group has no name, it
+	// is only keyed by its scope.
+	continuousDeliveries = &kind{
+		singular:  "continuousDelivery",
+		plural:    "continuousDeliveries",
+		uidPrefix: "cd",
+		keyFields: fluxScopeFields,
+		onCreate: func(s *Server, object Object) {
+			setPhase(object, "CREATING")
+		},
+		lifecycle: true,
+	}
+	// Git repositories and Kustomizations are applied as soon as they are
+	// created, in a scope continuous delivery is enabled on.
+	gitRepositories = &kind{
+		singular:     "gitRepository",
+		plural:       "gitRepositories",
+		uidPrefix:    "gr",
+		keyFields:    fluxKeyFields,
+		onCreate:     applyFluxObject,
+		onUpdate:     applyFluxObject,
+		precondition: continuousDeliveryEnabled,
+	}
+	kustomizations = &kind{
+		singular:     "kustomization",
+		plural:       "kustomizations",
+		uidPrefix:    "ks",
+		keyFields:    fluxKeyFields,
+		onCreate:     applyFluxObject,
+		onUpdate:     applyFluxObject,
+		precondition: continuousDeliveryEnabled,
+	}
+)
+
+// fluxKinds are the kinds of Flux objects, by the last segment of their
+// path.
+var fluxKinds = map[string]*kind{
+	"gitrepositories": gitRepositories,
+	"kustomizations":  kustomizations,
+}
+
+func applyFluxObject(s *Server, object Object) {
+	setPhase(object, "APPLIED")
+}
+
+// continuousDeliveryEnabled reports why a Flux object cannot be created when
+// continuous delivery is not enabled on its scope. The caller must hold mu.
+func continuousDeliveryEnabled(s *Server, object Object) string {
+	scope := fluxScope(object["fullName"].(Object))
+
+	cd, ok := s.objects[continuousDeliveries][continuousDeliveries.key(scope)]
+	if status, _ := cd["status"].(Object); !ok || status["phase"] != "READY" {
+		return fmt.Sprintf("continuous delivery is not enabled on %s", describeFluxScope(scope))
+	}
+
+	return ""
+}
+
+// fluxScope returns the fields of the full name identifying the scope of a
+// Flux object.
+func fluxScope(fullName Object) Object {
+	scope := Object{}
+	for _, f := range fluxScopeFields {
+		if v, ok := fullName[f]; ok {
+			scope[f] = v
+		}
+	}
+
+	return scope
+}
+
+func describeFluxScope(scope Object) string {
+	if name, ok := scope["clusterGroupName"]; ok {
+		return fmt.Sprintf("cluster group %v", name)
+	}
+
+	return fmt.Sprintf("cluster %v", scope["clusterName"])
+}
+
+// ContinuousDelivery returns the continuous delivery of a cluster, when it is
+// enabled.
+func (s *Server) ContinuousDelivery(managementClusterName, provisionerName, clusterName string) (Object, bool) {
+	return s.get(continuousDeliveries, Object{
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// SetContinuousDeliveryPhase moves the continuous delivery of a cluster to the
+// given phase, or removes its status when the phase is empty.
+func (s *Server) SetContinuousDeliveryPhase(managementClusterName, provisionerName, clusterName, phase string) {
+	s.setObjectPhase(continuousDeliveries, Object{
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	}, phase)
+}
+
+// ClusterGroupContinuousDelivery returns the continuous delivery of a
+// cluster group, when it is enabled.
+func (s *Server) ClusterGroupContinuousDelivery(clusterGroupName string) (Object, bool) {
+	return s.get(continuousDeliveries, Object{"clusterGroupName": clusterGroupName})
+}
+
+// GitRepository returns a Git repository of a cluster.
+func (s *Server) GitRepository(managementClusterName, provisionerName, clusterName, namespaceName, name string) (Object, bool) {
+	return s.get(gitRepositories, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+		"namespaceName":         namespaceName,
+	})
+}
+
+// ClusterGroupGitRepository returns a Git repository of a cluster group.
+func (s *Server) ClusterGroupGitRepository(clusterGroupName, namespaceName, name string) (Object, bool) {
+	return s.get(gitRepositories, Object{
+		"name":             name,
+		"clusterGroupName": clusterGroupName,
+		"namespaceName":    namespaceName,
+	})
+}
+
+// Kustomization returns a Kustomization of a cluster.
+func (s *Server) Kustomization(managementClusterName, provisionerName, clusterName, namespaceName, name string) (Object, bool) {
+	return s.get(kustomizations, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+		"namespaceName":         namespaceName,
+	})
+}
+
+// ClusterGroupKustomization returns a Kustomization of a cluster group.
+func (s *Server) ClusterGroupKustomization(clusterGroupName, namespaceName, name string) (Object, bool) {
+	return s.get(kustomizations, Object{
+		"name":             name,
+		"clusterGroupName": clusterGroupName,
+		"namespaceName":    namespaceName,
+	})
+}
+
+// handleContinuousDelivery serves the continuous delivery of a cluster or
+// cluster group, which is read, enabled and disabled without a name of its
+// own.
+func (s *Server) handleContinuousDelivery(w http.ResponseWriter, r *http.Request, scope Object) {
+	fullName := queryScope(r, scope)
+
+	switch r.Method {
+	case http.MethodGet:
+		s.advance(continuousDeliveries, fullName)
+
+		object, ok := s.get(continuousDeliveries, fullName)
+		if !ok {
+			writeError(w, http.StatusNotFound, codeNotFound, fmt.Sprintf("continuous delivery is not enabled on %s", describeFluxScope(scope)))
+			return
+		}
+		writeJSON(w, http.StatusOK, Object{continuousDeliveries.singular: continuousDeliveries.response(object)})
+	case http.MethodPost:
+		body := map[string]Object{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalidArgument, err.Error())
+			return
+		}
+
+		object, ok := body[continuousDeliveries.singular]
+		if !ok {
+			writeError(w, http.StatusBadRequest, codeInvalidArgument, fmt.Sprintf("missing %s in request body", continuousDeliveries.singular))
+			return
+		}
+		bodyName, _ := object["fullName"].(Object)
+		if bodyName == nil {
+			bodyName = Object{}
+			object["fullName"] = bodyName
+		}
+		for f, v := range scope {
+			bodyName[f] = v
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		parentKind, parent := clusterGroups, Object{"name": bodyName["clusterGroupName"]}
+		if _, ok := scope["clusterName"]; ok {
+			parentKind, parent = clusters, Object{
+				"name":                  bodyName["clusterName"],
+				"managementClusterName": bodyName["managementClusterName"],
+				"provisionerName":       bodyName["provisionerName"],
+			}
+		}
+		if _, exists := s.objects[parentKind][parentKind.key(parent)]; !exists {
+			writeNotFound(w, parentKind, parent)
+			return
+		}
+
+		if _, exists := s.objects[continuousDeliveries][continuousDeliveries.key(bodyName)]; exists {
+			writeError(w, http.StatusConflict, codeAlreadyExists, fmt.Sprintf("continuous delivery is already enabled on %s", describeFluxScope(scope)))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Object{continuousDeliveries.singular: continuousDeliveries.response(s.create(continuousDeliveries, object))})
+	case http.MethodDelete:
+		s.mu.Lock()
+		object, ok := s.objects[continuousDeliveries][continuousDeliveries.key(fullName)]
+		if ok {
+			s.startDeletion(continuousDeliveries, object)
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			writeError(w, http.StatusNotFound, codeNotFound, fmt.Sprintf("continuous delivery is not enabled on %s", describeFluxScope(scope)))
+			return
+		}
+		writeJSON(w, http.StatusOK, Object{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, codeInvalidArgument, "method not allowed")
+	}
+}
@@ -0,0 +1,82 @@
+package tmcfake
+
+import (
+	"fmt"
+)
+
+// cloudFormationTemplate is the template served for AWS credentials, creating
+// the IAM role TMC assumes with the external ID of the credential.
+const cloudFormationTemplate = `{"AWSTemplateFormatVersion":"2010-09-09","Description":"IAM role assumed by Tanzu Mission Control with the credential %s","Resources":{"Role":{"Type":"AWS::IAM::Role","Properties":{"AssumeRolePolicyDocument":{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::630260974543:root"},"Action":"sts:AssumeRole","Condition":{"StringEquals":{"sts:ExternalId":"%s"}}}]}}}},"Outputs":{"RoleArn":{"Value":{"Fn::GetAtt":["Role","Arn"]}}}}`
+
+// Credentials are checked as soon as they are created or updated, AWS
+// credentials wait in the PENDING phase until their IAM role is set.
+var credentials = &kind{
+	singular:  "credential",
+	plural:    "credentials",
+	uidPrefix: "cred",
+	onCreate: func(s *Server, object Object) {
+		s.checkCredential(object)
+	},
+	onUpdate: func(s *Server, object Object) {
+		s.checkCredential(object)
+	},
+	redact: func(object Object) {
+		data := child(child(object, "spec"), "data")
+		delete(data, "keyValue")
+		if azure, _ := data["azureCredential"].(Object); azure != nil {
+			delete(child(azure, "servicePrincipal"), "clientSecret")
+		}
+	},
+}
+
+// Credential returns the credential with the given name, including the
+// secrets TMC never returns.
+func (s *Server) Credential(name string) (Object, bool) {
+	return s.get(credentials, Object{"name": name})
+}
+
+// InvalidateCredential makes the credential with the given name invalid, as
+// if TMC could not access the account with it, with the message reported in
+// its Ready condition.
+func (s *Server) InvalidateCredential(name, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.invalidCredentials[name] = message
+}
+
+// checkCredential sets the phase of a credential, generating the external ID
+// and the CloudFormation template of AWS credentials. The caller must hold
+// mu.
+func (s *Server) checkCredential(object Object) {
+	name := fmt.Sprint(object["fullName"].(Object)["name"])
+	status := child(object, "status")
+	status["phase"] = "VALID"
+	delete(status, "conditions")
+
+	if aws, _ := child(child(object, "spec"), "data")["awsCredential"].(Object); aws != nil {
+		role := child(aws, "iamRole")
+		if role["extId"] == nil || role["extId"] == "" {
+			role["extId"] = fmt.Sprintf("tmc-ext-id-%08d", s.serial)
+		}
+		status["cloudFormationTemplate"] = fmt.Sprintf(cloudFormationTemplate, name, role["extId"])
+
+		if role["arn"] == nil || role["arn"] == "" {
+			status["phase"] = "PENDING"
+			return
+		}
+	}
+
+	if message, ok := s.invalidCredentials[name]; ok {
+		status["phase"] = "INVALID"
+		status["conditions"] = Object{
+			"Ready": Object{
+				"type":     "Ready",
+				"status":   "FALSE",
+				"severity": "ERROR",
+				"reason":   "AccessDenied",
+				"message":  message,
+			},
+		}
+	}
+}
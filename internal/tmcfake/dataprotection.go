@@ -0,0 +1,312 @@
+package tmcfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ScheduleLabel is the label Velero puts on the backups taken by a schedule.
+const ScheduleLabel = "velero.io/schedule-name"
+
+var (
+	// The data protection of a cluster has no name, it is only keyed by the
+	// cluster.
+	dataProtections = &kind{
+		singular:  "dataProtection",
+		plural:    "dataProtections",
+		uidPrefix: "dp",
+		keyFields: []string{"managementClusterName", "provisionerName", "clusterName"},
+		onCreate: func(s *Server, object Object) {
+			setPhase(object, "CREATING")
+		},
+		lifecycle: true,
+	}
+	backupLocations = &kind{
+		singular:  "backupLocation",
+		plural:    "backupLocations",
+		uidPrefix: "bl",
+		keyFields: []string{"providerName"},
+		onCreate: func(s *Server, object Object) {
+			setPhase(object, "READY")
+		},
+	}
+	backupSchedules = &kind{
+		singular:  "schedule",
+		plural:    "schedules",
+		uidPrefix: "bs",
+		keyFields: []string{"managementClusterName", "provisionerName", "clusterName"},
+	}
+	backups = &kind{
+		singular:  "backup",
+		plural:    "backups",
+		uidPrefix: "bk",
+		keyFields: []string{"managementClusterName", "provisionerName", "clusterName"},
+	}
+	// Restores are in progress until they are polled, then they end up in
+	// the phase set with SetRestoreResult for their backup.
+	restores = &kind{
+		singular:  "restore",
+		plural:    "restores",
+		uidPrefix: "rs",
+		keyFields: []string{"managementClusterName", "provisionerName", "clusterName"},
+		onCreate: func(s *Server, object Object) {
+			fullName := object["fullName"].(Object)
+			backupName := child(object, "spec")["backupName"]
+
+			backup, ok := s.objects[backups][backups.key(Object{
+				"name":                  backupName,
+				"managementClusterName": fullName["managementClusterName"],
+				"provisionerName":       fullName["provisionerName"],
+				"clusterName":           fullName["clusterName"],
+			})]
+			if !ok {
+				object["status"] = Object{
+					"phase":         "FailedValidation",
+					"failureReason": fmt.Sprintf("backup %v not found", backupName),
+				}
+				return
+			}
+			if status, _ := backup["status"].(Object); status["phase"] != "Completed" && status["phase"] != "PartiallyFailed" {
+				object["status"] = Object{
+					"phase":         "FailedValidation",
+					"failureReason": fmt.Sprintf("backup %v is in phase %v", backupName, status["phase"]),
+				}
+				return
+			}
+
+			object["status"] = Object{
+				"phase":          "InProgress",
+				"startTimestamp": time.Now().UTC().Format(time.RFC3339),
+			}
+		},
+		lifecycle: true,
+	}
+)
+
+// restoreResult is how the restores of a backup end.
+type restoreResult struct {
+	phase         string
+	failureReason string
+	warnings      int
+	errors        int
+}
+
+// DataProtection returns the data protection of a cluster, when it is
+// enabled.
+func (s *Server) DataProtection(managementClusterName, provisionerName, clusterName string) (Object, bool) {
+	return s.get(dataProtections, Object{
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// SetDataProtectionPhase moves the data protection of a cluster to the given
+// phase, or removes its status when the phase is empty.
+func (s *Server) SetDataProtectionPhase(managementClusterName, provisionerName, clusterName, phase string) {
+	s.setObjectPhase(dataProtections, Object{
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	}, phase)
+}
+
+// BackupLocation returns the backup location with the given name.
+func (s *Server) BackupLocation(name string) (Object, bool) {
+	return s.get(backupLocations, Object{"providerName": "tmc", "name": name})
+}
+
+// BackupSchedule returns a backup schedule of a cluster.
+func (s *Server) BackupSchedule(managementClusterName, provisionerName, clusterName, name string) (Object, bool) {
+	return s.get(backupSchedules, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// Backup returns a backup of a cluster.
+func (s *Server) Backup(managementClusterName, provisionerName, clusterName, name string) (Object, bool) {
+	return s.get(backups, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// Restore returns a restore of a cluster.
+func (s *Server) Restore(managementClusterName, provisionerName, clusterName, name string) (Object, bool) {
+	return s.get(restores, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// SetRestorePhase moves a restore of a cluster to the given phase, or removes
+// its status when the phase is empty.
+func (s *Server) SetRestorePhase(managementClusterName, provisionerName, clusterName, name, phase string) {
+	s.setObjectPhase(restores, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	}, phase)
+}
+
+// SetRestoreResult makes the restores of the backup with the given name end
+// in the given phase, reporting the given number of warnings and errors,
+// rather than completing without any.
+func (s *Server) SetRestoreResult(backupName, phase, failureReason string, warnings, errors int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.restoreResults[backupName] = restoreResult{
+		phase:         phase,
+		failureReason: failureReason,
+		warnings:      warnings,
+		errors:        errors,
+	}
+}
+
+// finishRestore moves a restore in progress to the phase it ends up in. The
+// caller must hold mu.
+func (s *Server) finishRestore(object Object) {
+	result, ok := s.restoreResults[fmt.Sprint(child(object, "spec")["backupName"])]
+	if !ok {
+		result = restoreResult{phase: "Completed"}
+	}
+
+	status := child(object, "status")
+	status["phase"] = result.phase
+	status["completionTimestamp"] = time.Now().UTC().Format(time.RFC3339)
+	if result.failureReason != "" {
+		status["failureReason"] = result.failureReason
+	}
+	if result.warnings > 0 {
+		status["warnings"] = result.warnings
+	}
+	if result.errors > 0 {
+		status["errors"] = result.errors
+	}
+}
+
+// AddBackup records a backup of a cluster, as Velero would when a schedule
+// fires. The schedule is left empty for backups taken on demand.
+func (s *Server) AddBackup(managementClusterName, provisionerName, clusterName, name, schedule, phase string) {
+	meta := Object{}
+	if schedule != "" {
+		meta["labels"] = Object{ScheduleLabel: schedule}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.create(backups, Object{
+		"fullName": Object{
+			"name":                  name,
+			"managementClusterName": managementClusterName,
+			"provisionerName":       provisionerName,
+			"clusterName":           clusterName,
+		},
+		"meta": meta,
+		"spec": Object{
+			"backupTtl":       "720h0m0s",
+			"storageLocation": "default",
+		},
+		"status": Object{
+			"phase":               phase,
+			"startTimestamp":      "2021-07-01T02:00:00Z",
+			"completionTimestamp": "2021-07-01T02:05:00Z",
+			"expiration":          "2021-07-31T02:00:00Z",
+		},
+	})
+}
+
+// handleDataProtection serves the data protection of a cluster, which is
+// listed, enabled and disabled without a name of its own.
+func (s *Server) handleDataProtection(w http.ResponseWriter, r *http.Request, clusterName string) {
+	fullName := queryScope(r, Object{"clusterName": clusterName})
+
+	switch r.Method {
+	case http.MethodGet:
+		s.advance(dataProtections, fullName)
+
+		items := []Object{}
+		if object, ok := s.get(dataProtections, fullName); ok {
+			items = append(items, object)
+		}
+		writeJSON(w, http.StatusOK, Object{dataProtections.plural: items})
+	case http.MethodPost:
+		body := map[string]Object{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalidArgument, err.Error())
+			return
+		}
+
+		object, ok := body[dataProtections.singular]
+		if !ok {
+			writeError(w, http.StatusBadRequest, codeInvalidArgument, fmt.Sprintf("missing %s in request body", dataProtections.singular))
+			return
+		}
+		bodyName, _ := object["fullName"].(Object)
+		if bodyName == nil {
+			bodyName = Object{}
+			object["fullName"] = bodyName
+		}
+		bodyName["clusterName"] = clusterName
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		cluster := Object{
+			"name":                  clusterName,
+			"managementClusterName": bodyName["managementClusterName"],
+			"provisionerName":       bodyName["provisionerName"],
+		}
+		if _, exists := s.objects[clusters][clusters.key(cluster)]; !exists {
+			writeNotFound(w, clusters, cluster)
+			return
+		}
+
+		if _, exists := s.objects[dataProtections][dataProtections.key(bodyName)]; exists {
+			writeError(w, http.StatusConflict, codeAlreadyExists, fmt.Sprintf("data protection is already enabled on cluster %s", clusterName))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Object{dataProtections.singular: roundTrip(s.create(dataProtections, object))})
+	case http.MethodDelete:
+		s.mu.Lock()
+		object, ok := s.objects[dataProtections][dataProtections.key(fullName)]
+		if ok {
+			s.startDeletion(dataProtections, object)
+
+			if r.URL.Query().Get("deleteBackups") == "true" {
+				scope := Object{
+					"managementClusterName": fullName["managementClusterName"],
+					"provisionerName":       fullName["provisionerName"],
+					"clusterName":           clusterName,
+				}
+				for key, backup := range s.objects[backups] {
+					if inScope(backup, scope) {
+						delete(s.objects[backups], key)
+					}
+				}
+			}
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			writeError(w, http.StatusNotFound, codeNotFound, fmt.Sprintf("data protection is not enabled on cluster %s", clusterName))
+			return
+		}
+		writeJSON(w, http.StatusOK, Object{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, codeInvalidArgument, "method not allowed")
+	}
+}
@@ -0,0 +1,201 @@
+package tmcfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// iamSuffix ends the path of the access policy of an object.
+const iamSuffix = ":iam"
+
+type subject struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type roleBinding struct {
+	Role     string    `json:"role"`
+	Subjects []subject `json:"subjects"`
+}
+
+type bindingDelta struct {
+	Op      string  `json:"op"`
+	Role    string  `json:"role"`
+	Subject subject `json:"subject"`
+}
+
+// RoleBindings returns the members of the roles bound on an object, like
+// user:jane@example.com, by role. The object is "organization" or the
+// singular of its kind followed by its key, like
+// "workspace:tf-acc-workspace" or "cluster:aws-hosted/tf-acc/tf-acc-cluster".
+func (s *Server) RoleBindings(object string) map[string][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members := map[string][]string{}
+	for _, binding := range s.policies[object] {
+		for _, subject := range binding.Subjects {
+			members[binding.Role] = append(members[binding.Role], strings.ToLower(subject.Kind)+":"+subject.Name)
+		}
+	}
+
+	return members
+}
+
+// AddRoleBinding binds a role on an object to a user or a group, as if it
+// was granted outside of Terraform. The object is given as for RoleBindings,
+// and the member as user:<name> or group:<name>.
+func (s *Server) AddRoleBinding(object, role, member string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kind := strings.SplitN(member, ":", 2)
+	s.policies[object] = applyDelta(s.policies[object], bindingDelta{
+		Op:      "OP_ADD",
+		Role:    role,
+		Subject: subject{Name: kind[1], Kind: strings.ToUpper(kind[0])},
+	})
+}
+
+// handleIAM serves the access policy of the object at the given path.
+func (s *Server) handleIAM(w http.ResponseWriter, r *http.Request, segments []string) {
+	var k *kind
+	fullName := Object{}
+
+	switch {
+	case segments[0] == "organization" && len(segments) == 1:
+	case segments[0] == "workspaces" && len(segments) == 2:
+		k, fullName["name"] = workspaces, segments[1]
+	case segments[0] == "clustergroups" && len(segments) == 2:
+		k, fullName["name"] = clusterGroups, segments[1]
+	case segments[0] == "clusters" && len(segments) == 2:
+		k, fullName = clusters, queryScope(r, Object{"name": segments[1]})
+	case segments[0] == "clusters" && len(segments) == 4 && segments[2] == "namespaces":
+		k, fullName = namespaces, queryScope(r, Object{"clusterName": segments[1], "name": segments[3]})
+	default:
+		writeError(w, http.StatusNotFound, codeNotFound, fmt.Sprintf("no route for %s", r.URL.Path))
+		return
+	}
+
+	object := "organization"
+	if k != nil {
+		if _, ok := s.get(k, fullName); !ok {
+			writeNotFound(w, k, fullName)
+			return
+		}
+		object = k.singular + ":" + k.key(fullName)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		policy := s.policy(object)
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, Object{"policyList": []Object{policy}})
+	case http.MethodPut:
+		body := struct {
+			Policy struct {
+				RoleBindings []roleBinding `json:"roleBindings"`
+			} `json:"policy"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalidArgument, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var bindings []roleBinding
+		for _, binding := range body.Policy.RoleBindings {
+			for _, subject := range binding.Subjects {
+				bindings = applyDelta(bindings, bindingDelta{Op: "OP_ADD", Role: binding.Role, Subject: subject})
+			}
+		}
+		s.policies[object] = bindings
+
+		writeJSON(w, http.StatusOK, Object{"policy": s.policy(object)})
+	case http.MethodPatch:
+		body := struct {
+			BindingDeltaList []bindingDelta `json:"bindingDeltaList"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalidArgument, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, delta := range body.BindingDeltaList {
+			if delta.Op != "OP_ADD" && delta.Op != "OP_DELETE" {
+				writeError(w, http.StatusBadRequest, codeInvalidArgument, fmt.Sprintf("unknown binding delta operation %q", delta.Op))
+				return
+			}
+		}
+		for _, delta := range body.BindingDeltaList {
+			s.policies[object] = applyDelta(s.policies[object], delta)
+		}
+
+		writeJSON(w, http.StatusOK, Object{"policy": s.policy(object)})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, codeInvalidArgument, "method not allowed")
+	}
+}
+
+// policy returns the access policy set on an object. The caller must hold
+// mu.
+func (s *Server) policy(object string) Object {
+	bindings := s.policies[object]
+	if bindings == nil {
+		bindings = []roleBinding{}
+	}
+
+	return roundTrip(Object{
+		"meta":         Object{"uid": "iam:" + object},
+		"roleBindings": bindings,
+	})
+}
+
+// applyDelta adds or removes a subject from the binding of a role, dropping
+// the bindings left without subjects. Bindings stay sorted by role, so
+// policies are returned in a stable order.
+func applyDelta(bindings []roleBinding, delta bindingDelta) []roleBinding {
+	updated := make([]roleBinding, 0, len(bindings)+1)
+	found := false
+
+	for _, binding := range bindings {
+		if binding.Role == delta.Role {
+			found = true
+
+			subjects := make([]subject, 0, len(binding.Subjects)+1)
+			for _, bound := range binding.Subjects {
+				if bound != delta.Subject {
+					subjects = append(subjects, bound)
+				}
+			}
+			if delta.Op == "OP_ADD" {
+				subjects = append(subjects, delta.Subject)
+			}
+			binding.Subjects = subjects
+		}
+
+		if len(binding.Subjects) > 0 {
+			updated = append(updated, binding)
+		}
+	}
+
+	if !found && delta.Op == "OP_ADD" {
+		updated = append(updated, roleBinding{Role: delta.Role, Subjects: []subject{delta.Subject}})
+	}
+
+	sort.SliceStable(updated, func(i, j int) bool {
+		return updated[i].Role < updated[j].Role
+	})
+
+	return updated
+}
@@ -0,0 +1,90 @@
+package tmcfake
+
+import "fmt"
+
+// Inspections are running until they are polled, then they end up with the
+// result set with SetInspectionResult.
+var inspections = &kind{
+	singular:  "scan",
+	plural:    "scans",
+	uidPrefix: "scan",
+	keyFields: []string{"managementClusterName", "provisionerName", "clusterName"},
+	onCreate: func(s *Server, object Object) {
+		setPhase(object, "RUNNING")
+	},
+	lifecycle: true,
+}
+
+// inspectionResult is how an inspection ends.
+type inspectionResult struct {
+	phase     string
+	phaseInfo string
+	passed    int
+	failed    int
+	warned    int
+}
+
+// Inspection returns an inspection of a cluster.
+func (s *Server) Inspection(managementClusterName, provisionerName, clusterName, name string) (Object, bool) {
+	return s.get(inspections, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// SetInspectionPhase moves an inspection of a cluster to the given phase, or
+// removes its status when the phase is empty.
+func (s *Server) SetInspectionPhase(managementClusterName, provisionerName, clusterName, name, phase string) {
+	s.setObjectPhase(inspections, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	}, phase)
+}
+
+// SetInspectionResult makes the inspections with the given name complete with
+// the given number of passed, failed and warned checks, rather than passing
+// all of them. An inspection with a phaseInfo ends in the ERROR phase.
+func (s *Server) SetInspectionResult(name, phaseInfo string, passed, failed, warned int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := inspectionResult{
+		phase:  "COMPLETE",
+		passed: passed,
+		failed: failed,
+		warned: warned,
+	}
+	if phaseInfo != "" {
+		result = inspectionResult{phase: "ERROR", phaseInfo: phaseInfo}
+	}
+	s.inspectionResults[name] = result
+}
+
+// finishInspection moves a running inspection to the phase it ends up in. The
+// caller must hold mu.
+func (s *Server) finishInspection(object Object) {
+	name := fmt.Sprint(object["fullName"].(Object)["name"])
+
+	result, ok := s.inspectionResults[name]
+	if !ok {
+		result = inspectionResult{phase: "COMPLETE", passed: 42}
+	}
+
+	status := child(object, "status")
+	status["phase"] = result.phase
+	if result.phase == "ERROR" {
+		status["phaseInfo"] = result.phaseInfo
+		return
+	}
+
+	status["report"] = Object{
+		"passed": result.passed,
+		"failed": result.failed,
+		"warned": result.warned,
+	}
+	status["tarballDownloadUrl"] = fmt.Sprintf("%s/inspection-results/%s.tar.gz", s.URL, name)
+}
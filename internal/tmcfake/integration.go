@@ -0,0 +1,76 @@
+package tmcfake
+
+import "fmt"
+
+// Integrations are installed on their cluster until they are polled, they
+// then become ready and healthy unless FailIntegration was called for them.
+var integrations = &kind{
+	singular:  "integration",
+	plural:    "integrations",
+	uidPrefix: "int",
+	keyFields: []string{"managementClusterName", "provisionerName", "clusterName"},
+	onCreate: func(s *Server, object Object) {
+		setPhase(object, "CREATING")
+
+		if message, ok := s.integrationFailures[integrationKey(object["fullName"].(Object))]; ok {
+			object["status"] = Object{
+				"phase":  "ERROR",
+				"health": "UNHEALTHY",
+				"conditions": Object{
+					"Ready": Object{
+						"type":     "Ready",
+						"status":   "FALSE",
+						"severity": "ERROR",
+						"reason":   "AgentInstallFailed",
+						"message":  message,
+					},
+				},
+			}
+		}
+	},
+	redact: func(object Object) {
+		delete(child(object, "spec"), "secrets")
+	},
+	lifecycle: true,
+}
+
+// Integration returns an integration of a cluster, including the secrets TMC
+// never returns.
+func (s *Server) Integration(managementClusterName, provisionerName, clusterName, name string) (Object, bool) {
+	return s.get(integrations, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// FailIntegration makes enabling the integration with the given name on a
+// cluster fail, with the message reported in its Ready condition.
+func (s *Server) FailIntegration(managementClusterName, provisionerName, clusterName, name, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.integrationFailures[integrationKey(Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})] = message
+}
+
+// SetIntegrationPhase moves an integration of a cluster to the given phase,
+// or removes its status when the phase is empty.
+func (s *Server) SetIntegrationPhase(managementClusterName, provisionerName, clusterName, name, phase string) {
+	s.setObjectPhase(integrations, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	}, phase)
+}
+
+// integrationKey identifies an integration of a cluster.
+func integrationKey(fullName Object) string {
+	return fmt.Sprintf("%v/%v/%v/%v", fullName["managementClusterName"], fullName["provisionerName"], fullName["clusterName"], fullName["name"])
+}
@@ -0,0 +1,311 @@
+package tmcfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// matcher decides whether an object, given as its decoded JSON document,
+// is matched by a search query.
+type matcher func(object map[string]interface{}) bool
+
+type tokenKind int
+
+const (
+	tokenTerm tokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind  tokenKind
+	field string
+	// value is the literal value of the term.
+	value string
+	// pattern is set for unquoted values containing wildcards, and holds
+	// the equivalent regular expression.
+	pattern string
+}
+
+// parseQuery parses the subset of the TMC search syntax produced by
+// tanzuclient.Query: field:value terms combined with and, or, not and
+// parentheses.
+func parseQuery(query string) (matcher, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return func(map[string]interface{}) bool { return true }, nil
+	}
+
+	p := &parser{tokens: tokens}
+
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected input at token %d of query %q", p.pos, query)
+	}
+
+	return m, nil
+}
+
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case r == ' ':
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose})
+			i++
+		default:
+			var word strings.Builder
+			escaped := false
+			for ; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					word.WriteRune(runes[i])
+					escaped = true
+					continue
+				}
+				if runes[i] == ':' || runes[i] == ' ' || runes[i] == '(' || runes[i] == ')' {
+					break
+				}
+				word.WriteRune(runes[i])
+			}
+
+			if i >= len(runes) || runes[i] != ':' {
+				if escaped {
+					return nil, fmt.Errorf("expected a field name, got %q", word.String())
+				}
+				switch word.String() {
+				case "and":
+					tokens = append(tokens, token{kind: tokenAnd})
+				case "or":
+					tokens = append(tokens, token{kind: tokenOr})
+				case "not":
+					tokens = append(tokens, token{kind: tokenNot})
+				default:
+					return nil, fmt.Errorf("unexpected word %q in query", word.String())
+				}
+				continue
+			}
+
+			// Skip the colon separating the field from its value.
+			i++
+
+			t := token{kind: tokenTerm, field: word.String()}
+			n, err := readValue(runes[i:], &t)
+			if err != nil {
+				return nil, err
+			}
+			i += n
+
+			tokens = append(tokens, t)
+		}
+	}
+
+	return tokens, nil
+}
+
+// readValue reads the value of a term into t and returns the number of runes
+// consumed. Quoted values are taken literally, unquoted ones may contain
+// unescaped "*" wildcards.
+func readValue(runes []rune, t *token) (int, error) {
+	var value, pattern strings.Builder
+
+	if len(runes) > 0 && runes[0] == '"' {
+		for i := 1; i < len(runes); i++ {
+			switch runes[i] {
+			case '\\':
+				i++
+				if i < len(runes) {
+					value.WriteRune(runes[i])
+				}
+			case '"':
+				t.value = value.String()
+				return i + 1, nil
+			default:
+				value.WriteRune(runes[i])
+			}
+		}
+		return 0, fmt.Errorf("unterminated quoted value")
+	}
+
+	wildcard := false
+	i := 0
+	for ; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) {
+			i++
+			value.WriteRune(runes[i])
+			pattern.WriteString(regexp.QuoteMeta(string(runes[i])))
+			continue
+		}
+		if r == ' ' || r == ')' {
+			break
+		}
+		if r == '*' {
+			wildcard = true
+			pattern.WriteString(".*")
+			continue
+		}
+		value.WriteRune(r)
+		pattern.WriteString(regexp.QuoteMeta(string(r)))
+	}
+
+	t.value = value.String()
+	if wildcard {
+		t.pattern = "^" + pattern.String() + "$"
+	}
+
+	return i, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek(kind tokenKind) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind
+}
+
+func (p *parser) parseOr() (matcher, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek(tokenOr) {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(o map[string]interface{}) bool { return l(o) || right(o) }
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (matcher, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek(tokenAnd) {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(o map[string]interface{}) bool { return l(o) && right(o) }
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (matcher, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+
+	switch t.kind {
+	case tokenNot:
+		m, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(o map[string]interface{}) bool { return !m(o) }, nil
+	case tokenOpen:
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(tokenClose) {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return m, nil
+	case tokenTerm:
+		return termMatcher(t)
+	}
+
+	return nil, fmt.Errorf("unexpected token in query")
+}
+
+func termMatcher(t token) (matcher, error) {
+	if t.pattern == "" {
+		return func(o map[string]interface{}) bool {
+			v, ok := lookupField(o, t.field)
+			return ok && v == t.value
+		}, nil
+	}
+
+	re, err := regexp.Compile(t.pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(o map[string]interface{}) bool {
+		v, ok := lookupField(o, t.field)
+		return ok && re.MatchString(v)
+	}, nil
+}
+
+// lookupField returns the value of a dotted field of the object. Label keys
+// may contain dots themselves, so they are looked up as a whole.
+func lookupField(object map[string]interface{}, field string) (string, bool) {
+	if strings.HasPrefix(field, "meta.labels.") {
+		meta, _ := object["meta"].(map[string]interface{})
+		labels, _ := meta["labels"].(map[string]interface{})
+		v, ok := labels[strings.TrimPrefix(field, "meta.labels.")]
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprint(v), true
+	}
+
+	var current interface{} = object
+	for _, part := range strings.Split(field, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if current, ok = m[part]; !ok {
+			return "", false
+		}
+	}
+
+	switch v := current.(type) {
+	case string:
+		return v, true
+	case nil:
+		return "", false
+	default:
+		b, _ := json.Marshal(v)
+		return string(b), true
+	}
+}
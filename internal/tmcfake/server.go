@@ -0,0 +1,1083 @@
+// Package tmcfake provides an in-process stand-in for the Tanzu Mission
+// Control API and the VMware Cloud Services token exchange, so the provider
+// can be tested without access to a real TMC organization.
+package tmcfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OrgID is the organization every object of the fake server belongs to.
+const OrgID = "fake-org-id"
+
+// gRPC status codes reported by TMC alongside the HTTP status of an error.
+const (
+	codeInvalidArgument    = 3
+	codeNotFound           = 5
+	codeAlreadyExists      = 6
+	codeFailedPrecondition = 9
+	codeUnauthenticated    = 16
+)
+
+// Object is a TMC object as decoded from its JSON representation.
+type Object = map[string]interface{}
+
+// kind describes a collection of objects served by the fake API.
+type kind struct {
+	// singular is the key wrapping a single object in requests and responses.
+	singular string
+	// plural is the key wrapping the objects of a list response.
+	plural string
+	// uidPrefix is prepended to the generated meta.uid of new objects.
+	uidPrefix string
+	// keyFields are the fullName fields identifying an object, in addition
+	// to its name.
+	keyFields []string
+	// onCreate, when set, fills in the server side fields of a new object.
+	onCreate func(s *Server, object Object)
+	// onUpdate, when set, fills in the server side fields of an updated
+	// object.
+	onUpdate func(s *Server, object Object)
+	// redact, when set, removes the fields the API never returns, like
+	// secrets, from a copy of an object about to be sent.
+	redact func(object Object)
+	// lifecycle is set for objects which go through phases reported in
+	// status.phase before they are ready or gone, like clusters.
+	lifecycle bool
+	// unmanaged, when set, reports whether creating the object conflicts
+	// with one which exists outside of TMC, like a namespace of a cluster.
+	// The caller must hold mu.
+	unmanaged func(s *Server, object Object) bool
+	// precondition, when set, reports why the object cannot be created yet,
+	// like a feature it depends on not being enabled. The caller must hold
+	// mu.
+	precondition func(s *Server, object Object) string
+}
+
+var (
+	workspaces = &kind{
+		singular:  "workspace",
+		plural:    "workspaces",
+		uidPrefix: "ws",
+	}
+	clusterGroups = &kind{
+		singular:  "clusterGroup",
+		plural:    "clusterGroups",
+		uidPrefix: "cg",
+	}
+	clusters = &kind{
+		singular:  "cluster",
+		plural:    "clusters",
+		uidPrefix: "c",
+		keyFields: []string{"managementClusterName", "provisionerName"},
+		onCreate: func(s *Server, object Object) {
+			fullName := object["fullName"].(Object)
+			status := Object{
+				"installerLink": fmt.Sprintf("%s/installer?name=%s", s.URL, fullName["name"]),
+				"phase":         "CREATING",
+			}
+			// Attached clusters wait for their agent to be installed.
+			if fullName["managementClusterName"] == "attached" {
+				status["phase"] = "PENDING"
+			}
+			if message, ok := s.failures[fmt.Sprint(fullName["name"])]; ok {
+				status["phase"] = "ERROR"
+				status["conditions"] = Object{
+					"Ready": Object{
+						"type":     "Ready",
+						"status":   "FALSE",
+						"severity": "ERROR",
+						"reason":   "ProvisioningFailed",
+						"message":  message,
+					},
+				}
+			}
+			object["status"] = status
+
+			if spec, _ := object["spec"].(Object); spec != nil {
+				if tkgAws, _ := spec["tkgAws"].(Object); tkgAws != nil {
+					s.defaultTkgAws(tkgAws)
+				}
+				if tkgVsphere, _ := spec["tkgVsphere"].(Object); tkgVsphere != nil {
+					defaultTkgVsphere(tkgVsphere)
+				}
+				if tkgServiceVsphere, _ := spec["tkgServiceVsphere"].(Object); tkgServiceVsphere != nil {
+					defaultTkgServiceVsphere(tkgServiceVsphere)
+				}
+			}
+		},
+		lifecycle: true,
+	}
+	nodePools = &kind{
+		singular:  "nodepool",
+		plural:    "nodepools",
+		uidPrefix: "np",
+		keyFields: []string{"managementClusterName", "provisionerName", "clusterName"},
+		onCreate: func(s *Server, object Object) {
+			setPhase(object, "CREATING")
+		},
+		lifecycle: true,
+	}
+	namespaces = &kind{
+		singular:  "namespace",
+		plural:    "namespaces",
+		uidPrefix: "ns",
+		keyFields: []string{"managementClusterName", "provisionerName", "clusterName"},
+		onCreate: func(s *Server, object Object) {
+			// Attached namespaces are no longer unmanaged.
+			delete(s.unmanaged, namespaceKey(object["fullName"].(Object)))
+		},
+		unmanaged: func(s *Server, object Object) bool {
+			spec, _ := object["spec"].(Object)
+			return s.unmanaged[namespaceKey(object["fullName"].(Object))] && spec["attach"] != true
+		},
+	}
+	// Policies are attached to cluster groups, workspaces, clusters or
+	// namespaces, only the fields of their scope are set in their full name.
+	policies = &kind{
+		singular:  "policy",
+		plural:    "policies",
+		uidPrefix: "pol",
+		keyFields: []string{"clusterGroupName", "workspaceName", "managementClusterName", "provisionerName", "clusterName", "namespaceName"},
+	}
+	policyTemplates = &kind{
+		singular:  "template",
+		plural:    "templates",
+		uidPrefix: "pt",
+	}
+	provisioners = &kind{
+		singular:  "provisioner",
+		plural:    "provisioners",
+		uidPrefix: "prv",
+		keyFields: []string{"managementClusterName"},
+	}
+	// The options of vSphere with Tanzu clusters are listed per provisioner.
+	tanzuKubernetesReleases = &kind{
+		singular:  "release",
+		plural:    "releases",
+		uidPrefix: "tkr",
+		keyFields: []string{"managementClusterName", "provisionerName"},
+	}
+	virtualMachineClasses = &kind{
+		singular:  "virtualMachineClass",
+		plural:    "virtualMachineClasses",
+		uidPrefix: "vmc",
+		keyFields: []string{"managementClusterName", "provisionerName"},
+	}
+	storageClasses = &kind{
+		singular:  "storageClass",
+		plural:    "storageClasses",
+		uidPrefix: "sc",
+		keyFields: []string{"managementClusterName", "provisionerName"},
+	}
+)
+
+// provisionerKinds are the kinds listed under a provisioner, by the last
+// segment of their path.
+var provisionerKinds = map[string]*kind{
+	"tanzukubernetesreleases": tanzuKubernetesReleases,
+	"virtualmachineclasses":   virtualMachineClasses,
+	"storageclasses":          storageClasses,
+}
+
+// Server is a fake TMC API backed by in-memory state. The zero value is not
+// usable, create one with NewServer.
+type Server struct {
+	*httptest.Server
+
+	// APIToken is the only API token the fake token exchange accepts.
+	APIToken string
+
+	mu       sync.Mutex
+	tokens   map[string]bool
+	objects  map[*kind]map[string]Object
+	failures map[string]string
+	// unmanaged holds the keys of namespaces which exist in clusters
+	// without being managed by TMC.
+	unmanaged map[string]bool
+	// policies holds the role bindings set on objects, by the names
+	// RoleBindings takes.
+	policies map[string][]roleBinding
+	// restoreResults holds how the restores of backups end, by backup
+	// name.
+	restoreResults map[string]restoreResult
+	// invalidCredentials holds why TMC cannot use credentials, by
+	// credential name.
+	invalidCredentials map[string]string
+	// integrationFailures holds why integrations cannot be enabled, by
+	// integration key.
+	integrationFailures map[string]string
+	// inspectionResults holds how inspections end, by inspection name.
+	inspectionResults map[string]inspectionResult
+	// delayedDeletions holds the objects deleted in an error phase or
+	// without a status, by kind and key, and whether they were polled since
+	// they were deleted.
+	delayedDeletions map[string]bool
+	serial           int
+}
+
+// NewServer starts a fake TMC API. It has to be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		APIToken:            "fake-api-token",
+		tokens:              map[string]bool{},
+		objects:             map[*kind]map[string]Object{},
+		failures:            map[string]string{},
+		unmanaged:           map[string]bool{},
+		policies:            map[string][]roleBinding{},
+		restoreResults:      map[string]restoreResult{},
+		invalidCredentials:  map[string]string{},
+		integrationFailures: map[string]string{},
+		inspectionResults:   map[string]inspectionResult{},
+		delayedDeletions:    map[string]bool{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/csp/gateway/am/api/auth/api-tokens/authorize", s.handleAuthorize)
+	mux.HandleFunc("/installer", s.handleInstaller)
+	mux.HandleFunc("/v1alpha1/", s.authenticated(s.handleAPI))
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// ExpireTokens revokes all the access tokens handed out so far.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]bool{}
+}
+
+// FailCluster makes the creation of clusters with the given name fail, with
+// the message reported in their Ready condition.
+func (s *Server) FailCluster(name, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[name] = message
+}
+
+// SetClusterPhase moves a cluster to the given phase, or removes its status
+// when the phase is empty, like clusters still being registered.
+func (s *Server) SetClusterPhase(managementClusterName, provisionerName, name, phase string) {
+	s.setObjectPhase(clusters, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+	}, phase)
+}
+
+// AddUnmanagedNamespace creates a namespace in a cluster without TMC
+// managing it, so that creating it through TMC conflicts unless it is
+// attached.
+func (s *Server) AddUnmanagedNamespace(managementClusterName, provisionerName, clusterName, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unmanaged[namespaceKey(Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})] = true
+}
+
+// namespaceKey identifies a namespace in a cluster, whether TMC manages it or
+// not.
+func namespaceKey(fullName Object) string {
+	return fmt.Sprintf("%v/%v/%v/%v", fullName["managementClusterName"], fullName["provisionerName"], fullName["clusterName"], fullName["name"])
+}
+
+// AddProvisioner creates a provisioner, which the provider cannot do itself.
+func (s *Server) AddProvisioner(managementClusterName, name string, labels map[string]string) {
+	meta := Object{}
+	if len(labels) > 0 {
+		l := Object{}
+		for k, v := range labels {
+			l[k] = v
+		}
+		meta["labels"] = l
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.create(provisioners, Object{
+		"fullName": Object{
+			"name":                  name,
+			"managementClusterName": managementClusterName,
+		},
+		"meta": meta,
+	})
+}
+
+// AddTanzuKubernetesRelease makes a Tanzu Kubernetes release available to the
+// vSphere with Tanzu clusters of a provisioner.
+func (s *Server) AddTanzuKubernetesRelease(managementClusterName, provisionerName, version string, compatible bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.create(tanzuKubernetesReleases, Object{
+		"fullName": provisionerScoped(managementClusterName, provisionerName, version),
+		"spec": Object{
+			"kubernetesVersion": strings.TrimPrefix(strings.SplitN(version, "+", 2)[0], "v"),
+			"version":           version,
+		},
+		"status": Object{"compatible": compatible},
+	})
+}
+
+// AddVirtualMachineClass makes a virtual machine class available to the
+// vSphere with Tanzu clusters of a provisioner.
+func (s *Server) AddVirtualMachineClass(managementClusterName, provisionerName, name string, cpuCores int, memory string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.create(virtualMachineClasses, Object{
+		"fullName": provisionerScoped(managementClusterName, provisionerName, name),
+		"spec": Object{
+			"hardwareConfig": Object{
+				"cpuCores": strconv.Itoa(cpuCores),
+				"memory":   memory,
+			},
+		},
+	})
+}
+
+// AddStorageClass makes a storage class available to the vSphere with Tanzu
+// clusters of a provisioner.
+func (s *Server) AddStorageClass(managementClusterName, provisionerName, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.create(storageClasses, Object{
+		"fullName": provisionerScoped(managementClusterName, provisionerName, name),
+	})
+}
+
+func provisionerScoped(managementClusterName, provisionerName, name string) Object {
+	return Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+	}
+}
+
+// Workspace returns the workspace with the given name.
+func (s *Server) Workspace(name string) (Object, bool) {
+	return s.get(workspaces, Object{"name": name})
+}
+
+// ClusterGroup returns the cluster group with the given name.
+func (s *Server) ClusterGroup(name string) (Object, bool) {
+	return s.get(clusterGroups, Object{"name": name})
+}
+
+// Cluster returns the cluster with the given full name.
+func (s *Server) Cluster(managementClusterName, provisionerName, name string) (Object, bool) {
+	return s.get(clusters, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+	})
+}
+
+// NodePool returns a node pool of a cluster, as stored by the server.
+func (s *Server) NodePool(managementClusterName, provisionerName, clusterName, name string) (Object, bool) {
+	return s.get(nodePools, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// SetNodePoolPhase moves a node pool to the given phase, or removes its
+// status when the phase is empty.
+func (s *Server) SetNodePoolPhase(managementClusterName, provisionerName, clusterName, name, phase string) {
+	s.setObjectPhase(nodePools, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	}, phase)
+}
+
+// Namespace returns a managed namespace of a cluster.
+func (s *Server) Namespace(managementClusterName, provisionerName, clusterName, name string) (Object, bool) {
+	return s.get(namespaces, Object{
+		"name":                  name,
+		"managementClusterName": managementClusterName,
+		"provisionerName":       provisionerName,
+		"clusterName":           clusterName,
+	})
+}
+
+// Policy returns the policy with the given full name, which holds the fields
+// of the object the policy is attached to, like
+// Object{"clusterGroupName": "default", "name": "baseline"}.
+func (s *Server) Policy(fullName Object) (Object, bool) {
+	return s.get(policies, fullName)
+}
+
+// PolicyTemplate returns the policy template with the given name.
+func (s *Server) PolicyTemplate(name string) (Object, bool) {
+	return s.get(policyTemplates, Object{"name": name})
+}
+
+func (s *Server) get(k *kind, fullName Object) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[k][k.key(fullName)]
+	if !ok {
+		return nil, false
+	}
+
+	return roundTrip(object), true
+}
+
+func (k *kind) key(fullName Object) string {
+	parts := make([]string, 0, len(k.keyFields)+1)
+	for _, f := range k.keyFields {
+		parts = append(parts, fmt.Sprint(fullName[f]))
+	}
+	parts = append(parts, fmt.Sprint(fullName["name"]))
+
+	return strings.Join(parts, "/")
+}
+
+// create stores a new object, filling in its server side fields. The caller
+// must hold mu.
+func (s *Server) create(k *kind, object Object) Object {
+	s.serial++
+
+	fullName := object["fullName"].(Object)
+	fullName["orgId"] = OrgID
+
+	meta, _ := object["meta"].(Object)
+	if meta == nil {
+		meta = Object{}
+		object["meta"] = meta
+	}
+	meta["uid"] = fmt.Sprintf("%s:%08d", k.uidPrefix, s.serial)
+	meta["creationTime"] = time.Now().UTC().Format(time.RFC3339)
+	meta["resourceVersion"] = "1"
+
+	if k.onCreate != nil {
+		k.onCreate(s, object)
+	}
+
+	if s.objects[k] == nil {
+		s.objects[k] = map[string]Object{}
+	}
+	s.objects[k][k.key(fullName)] = object
+
+	return object
+}
+
+// defaultTkgAws fills in the network settings TMC picks for TKG clusters on
+// AWS when they are left out, creating the VPC and its subnets. The caller
+// must hold mu.
+func (s *Server) defaultTkgAws(tkgAws Object) {
+	settings := child(tkgAws, "settings")
+	network := child(settings, "network")
+	clusterNetwork := child(network, "cluster")
+	provider := child(network, "provider")
+	vpc := child(provider, "vpc")
+
+	if _, ok := clusterNetwork["pods"]; !ok {
+		clusterNetwork["pods"] = []interface{}{Object{"cidrBlocks": "192.168.0.0/16"}}
+	}
+	if _, ok := clusterNetwork["services"]; !ok {
+		clusterNetwork["services"] = []interface{}{Object{"cidrBlocks": "10.96.0.0/12"}}
+	}
+
+	if _, ok := vpc["id"]; !ok {
+		if _, ok := vpc["cidrBlock"]; !ok {
+			vpc["cidrBlock"] = "10.0.0.0/16"
+		}
+		vpc["id"] = fmt.Sprintf("vpc-%08d", s.serial)
+	}
+
+	subnets, _ := provider["subnets"].([]interface{})
+	if len(subnets) == 0 {
+		// A private and a public subnet in each availability zone of the
+		// control plane.
+		zones, _ := child(child(tkgAws, "topology"), "controlPlane")["availabilityZones"].([]interface{})
+		for i, zone := range zones {
+			subnets = append(subnets,
+				Object{"availabilityZone": zone, "cidrBlock": fmt.Sprintf("10.0.%d.0/24", 2*i), "isPublic": false},
+				Object{"availabilityZone": zone, "cidrBlock": fmt.Sprintf("10.0.%d.0/24", 2*i+1), "isPublic": true},
+			)
+		}
+	}
+	for i, subnet := range subnets {
+		subnet := subnet.(Object)
+		if _, ok := subnet["id"]; !ok {
+			subnet["id"] = fmt.Sprintf("subnet-%08d%d", s.serial, i)
+		}
+	}
+	provider["subnets"] = subnets
+}
+
+// defaultTkgVsphere fills in the CIDR blocks TMC picks for TKG clusters on
+// vSphere when they are left out.
+func defaultTkgVsphere(tkgVsphere Object) {
+	network := child(child(tkgVsphere, "settings"), "network")
+
+	if pods := child(network, "pods"); pods["cidrBlocks"] == nil {
+		pods["cidrBlocks"] = []interface{}{"100.96.0.0/11"}
+	}
+	if services := child(network, "services"); services["cidrBlocks"] == nil {
+		services["cidrBlocks"] = []interface{}{"100.64.0.0/13"}
+	}
+}
+
+// defaultTkgServiceVsphere fills in the CIDR blocks and storage classes TMC
+// picks for vSphere with Tanzu clusters when they are left out.
+func defaultTkgServiceVsphere(tkgServiceVsphere Object) {
+	settings := child(tkgServiceVsphere, "settings")
+	network := child(settings, "network")
+
+	if pods := child(network, "pods"); pods["cidrBlocks"] == nil {
+		pods["cidrBlocks"] = []interface{}{"192.168.0.0/16"}
+	}
+	if services := child(network, "services"); services["cidrBlocks"] == nil {
+		services["cidrBlocks"] = []interface{}{"10.96.0.0/12"}
+	}
+
+	if storage := child(settings, "storage"); storage["classes"] == nil {
+		controlPlane := child(child(tkgServiceVsphere, "topology"), "controlPlane")
+		storage["classes"] = []interface{}{controlPlane["storageClass"]}
+	}
+}
+
+// child returns the object nested under the given field, adding it first if
+// it is missing.
+func child(object Object, field string) Object {
+	nested, _ := object[field].(Object)
+	if nested == nil {
+		nested = Object{}
+		object[field] = nested
+	}
+	return nested
+}
+
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, codeInvalidArgument, "method not allowed")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidArgument, err.Error())
+		return
+	}
+
+	// The API token must not be accepted from the query string.
+	if r.PostForm.Get("refresh_token") != s.APIToken {
+		writeError(w, http.StatusBadRequest, codeInvalidArgument, "invalid_grant: invalid refresh token")
+		return
+	}
+
+	s.mu.Lock()
+	s.serial++
+	token := fmt.Sprintf("fake-access-token-%d", s.serial)
+	s.tokens[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, Object{
+		"token_type":   "bearer",
+		"access_token": token,
+		"expires_in":   1799,
+	})
+}
+
+// installerManifest is served from the installer link of attached clusters.
+const installerManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: vmware-system-tmc
+`
+
+// handleInstaller serves the manifest installing the agent on an attached
+// cluster. As nothing applies it for real, downloading it is taken as the
+// agent being installed.
+func (s *Server) handleInstaller(w http.ResponseWriter, r *http.Request) {
+	fullName := Object{
+		"name":                  r.URL.Query().Get("name"),
+		"managementClusterName": "attached",
+		"provisionerName":       "attached",
+	}
+
+	s.mu.Lock()
+	object, ok := s.objects[clusters][clusters.key(fullName)]
+	if ok {
+		if status, _ := object["status"].(Object); status["phase"] == "PENDING" {
+			setPhase(object, "ATTACH_COMPLETE")
+		}
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeNotFound(w, clusters, fullName)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-yaml")
+	fmt.Fprint(w, installerManifest)
+}
+
+func (s *Server) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		s.mu.Lock()
+		valid := s.tokens[token]
+		s.mu.Unlock()
+
+		if !valid {
+			writeError(w, http.StatusUnauthorized, codeUnauthenticated, "invalid access token")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1alpha1/"), "/")
+	if strings.HasSuffix(path, iamSuffix) {
+		s.handleIAM(w, r, strings.Split(strings.TrimSuffix(path, iamSuffix), "/"))
+		return
+	}
+
+	segments := strings.Split(path, "/")
+
+	switch {
+	case segments[0] == "workspaces" && len(segments) <= 2:
+		s.handleKind(w, r, workspaces, segments[1:], Object{})
+	case segments[0] == "clustergroups" && len(segments) <= 2:
+		s.handleKind(w, r, clusterGroups, segments[1:], Object{})
+	case segments[0] == "clusters" && len(segments) <= 2:
+		s.handleKind(w, r, clusters, segments[1:], Object{})
+	case segments[0] == "clusters" && len(segments) >= 3 && len(segments) <= 4 && segments[2] == "nodepools":
+		s.handleKind(w, r, nodePools, segments[3:], Object{"clusterName": segments[1]})
+	case segments[0] == "clusters" && len(segments) >= 3 && len(segments) <= 4 && segments[2] == "namespaces":
+		s.handleKind(w, r, namespaces, segments[3:], Object{"clusterName": segments[1]})
+	case segments[0] == "clustergroups" && len(segments) >= 3 && len(segments) <= 4 && segments[2] == "policies":
+		s.handleKind(w, r, policies, segments[3:], Object{"clusterGroupName": segments[1]})
+	case segments[0] == "workspaces" && len(segments) >= 3 && len(segments) <= 4 && segments[2] == "policies":
+		s.handleKind(w, r, policies, segments[3:], Object{"workspaceName": segments[1]})
+	case segments[0] == "clusters" && len(segments) >= 3 && len(segments) <= 4 && segments[2] == "policies":
+		s.handleKind(w, r, policies, segments[3:], Object{"clusterName": segments[1]})
+	case segments[0] == "clusters" && len(segments) >= 5 && len(segments) <= 6 && segments[2] == "namespaces" && segments[4] == "policies":
+		s.handleKind(w, r, policies, segments[5:], Object{"clusterName": segments[1], "namespaceName": segments[3]})
+	case segments[0] == "clusters" && len(segments) >= 3 && len(segments) <= 4 && segments[2] == "integrations":
+		s.handleKind(w, r, integrations, segments[3:], Object{"clusterName": segments[1]})
+	case segments[0] == "clusters" && len(segments) >= 4 && len(segments) <= 5 && segments[2] == "inspection" && segments[3] == "scans":
+		s.handleKind(w, r, inspections, segments[4:], Object{"clusterName": segments[1]})
+	case segments[0] == "clusters" && len(segments) == 4 && segments[2] == "fluxcd" && segments[3] == "continuousdelivery":
+		s.handleContinuousDelivery(w, r, Object{"clusterName": segments[1]})
+	case segments[0] == "clustergroups" && len(segments) == 4 && segments[2] == "fluxcd" && segments[3] == "continuousdelivery":
+		s.handleContinuousDelivery(w, r, Object{"clusterGroupName": segments[1]})
+	case segments[0] == "clusters" && len(segments) >= 6 && len(segments) <= 7 && segments[2] == "namespaces" && segments[4] == "fluxcd" && fluxKinds[segments[5]] != nil:
+		s.handleKind(w, r, fluxKinds[segments[5]], segments[6:], Object{"clusterName": segments[1], "namespaceName": segments[3]})
+	case segments[0] == "clustergroups" && len(segments) >= 5 && len(segments) <= 6 && segments[2] == "namespace" && segments[3] == "fluxcd" && fluxKinds[segments[4]] != nil:
+		s.handleKind(w, r, fluxKinds[segments[4]], segments[5:], Object{"clusterGroupName": segments[1]})
+	case segments[0] == "clusters" && len(segments) == 3 && segments[2] == "dataprotection":
+		s.handleDataProtection(w, r, segments[1])
+	case segments[0] == "clusters" && len(segments) >= 4 && len(segments) <= 5 && segments[2] == "dataprotection" && segments[3] == "schedules":
+		s.handleKind(w, r, backupSchedules, segments[4:], Object{"clusterName": segments[1]})
+	case segments[0] == "clusters" && len(segments) >= 4 && len(segments) <= 5 && segments[2] == "dataprotection" && segments[3] == "backups":
+		s.handleKind(w, r, backups, segments[4:], Object{"clusterName": segments[1]})
+	case segments[0] == "clusters" && len(segments) >= 4 && len(segments) <= 5 && segments[2] == "dataprotection" && segments[3] == "restores":
+		s.handleKind(w, r, restores, segments[4:], Object{"clusterName": segments[1]})
+	case segments[0] == "dataprotection" && len(segments) >= 4 && len(segments) <= 5 && segments[1] == "providers" && segments[3] == "backuplocations":
+		s.handleKind(w, r, backupLocations, segments[4:], Object{"providerName": segments[2]})
+	case segments[0] == "account" && len(segments) >= 2 && len(segments) <= 3 && segments[1] == "credentials":
+		s.handleKind(w, r, credentials, segments[2:], Object{})
+	case segments[0] == "policy" && len(segments) >= 2 && len(segments) <= 3 && segments[1] == "templates":
+		s.handleKind(w, r, policyTemplates, segments[2:], Object{})
+	case segments[0] == "managementclusters" && len(segments) >= 3 && len(segments) <= 4 && segments[2] == "provisioners":
+		s.handleKind(w, r, provisioners, segments[3:], Object{"managementClusterName": segments[1]})
+	case segments[0] == "managementclusters" && len(segments) == 5 && segments[2] == "provisioners" && provisionerKinds[segments[4]] != nil:
+		s.handleKind(w, r, provisionerKinds[segments[4]], nil, Object{"managementClusterName": segments[1], "provisionerName": segments[3]})
+	default:
+		writeError(w, http.StatusNotFound, codeNotFound, fmt.Sprintf("no route for %s", r.URL.Path))
+	}
+}
+
+// handleKind serves the collection of objects of a kind, or a single object
+// of it when its name is given. scope holds the fullName fields taken from
+// the parent path of the collection.
+func (s *Server) handleKind(w http.ResponseWriter, r *http.Request, k *kind, name []string, scope Object) {
+	if len(name) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, r, k, queryScope(r, scope))
+		case http.MethodPost:
+			s.post(w, r, k, scope)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, codeInvalidArgument, "method not allowed")
+		}
+		return
+	}
+
+	fullName := queryScope(r, scope)
+	fullName["name"] = name[0]
+
+	switch r.Method {
+	case http.MethodGet:
+		if k.lifecycle {
+			s.advance(k, fullName)
+		}
+
+		object, ok := s.get(k, fullName)
+		if !ok {
+			writeNotFound(w, k, fullName)
+			return
+		}
+		writeJSON(w, http.StatusOK, Object{k.singular: k.response(object)})
+	case http.MethodPut:
+		s.put(w, r, k, fullName)
+	case http.MethodDelete:
+		s.mu.Lock()
+		object, ok := s.objects[k][k.key(fullName)]
+		if ok && k.lifecycle {
+			s.startDeletion(k, object)
+		} else {
+			delete(s.objects[k], k.key(fullName))
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			writeNotFound(w, k, fullName)
+			return
+		}
+		writeJSON(w, http.StatusOK, Object{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, codeInvalidArgument, "method not allowed")
+	}
+}
+
+// queryScope completes the scope taken from the path with the fields of the
+// full name not in the path, which are passed as query parameters.
+func queryScope(r *http.Request, scope Object) Object {
+	fullName := Object{}
+	for f, v := range scope {
+		fullName[f] = v
+	}
+	for param, values := range r.URL.Query() {
+		if strings.HasPrefix(param, "fullName.") && len(values) > 0 {
+			fullName[strings.TrimPrefix(param, "fullName.")] = values[0]
+		}
+	}
+
+	return fullName
+}
+
+func (s *Server) post(w http.ResponseWriter, r *http.Request, k *kind, scope Object) {
+	object, ok := decodeObject(w, r, k)
+	if !ok {
+		return
+	}
+
+	fullName := object["fullName"].(Object)
+	for f, v := range scope {
+		fullName[f] = v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.objects[k][k.key(fullName)]; exists {
+		writeError(w, http.StatusConflict, codeAlreadyExists, fmt.Sprintf("%s %s already exists", k.singular, fullName["name"]))
+		return
+	}
+
+	if k.unmanaged != nil && k.unmanaged(s, object) {
+		writeError(w, http.StatusConflict, codeAlreadyExists, fmt.Sprintf("%s %s already exists and is not managed by TMC", k.singular, fullName["name"]))
+		return
+	}
+
+	if k.precondition != nil {
+		if reason := k.precondition(s, object); reason != "" {
+			writeError(w, http.StatusBadRequest, codeFailedPrecondition, fmt.Sprintf("cannot create %s %s: %s", k.singular, fullName["name"], reason))
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, Object{k.singular: k.response(s.create(k, object))})
+}
+
+func (s *Server) put(w http.ResponseWriter, r *http.Request, k *kind, fullName Object) {
+	object, ok := decodeObject(w, r, k)
+	if !ok {
+		return
+	}
+
+	// The full name in the body completes the one from the path.
+	bodyName := object["fullName"].(Object)
+	for f, v := range bodyName {
+		if _, set := fullName[f]; !set {
+			fullName[f] = v
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.objects[k][k.key(fullName)]
+	if !exists {
+		writeNotFound(w, k, fullName)
+		return
+	}
+
+	meta, _ := object["meta"].(Object)
+	if meta == nil {
+		meta = Object{}
+	}
+	existingMeta := existing["meta"].(Object)
+	for _, f := range []string{"uid", "creationTime"} {
+		meta[f] = existingMeta[f]
+	}
+	version, _ := strconv.Atoi(fmt.Sprint(existingMeta["resourceVersion"]))
+	meta["resourceVersion"] = strconv.Itoa(version + 1)
+
+	existing["meta"] = meta
+	for field, value := range object {
+		if field != "fullName" && field != "meta" && field != "status" {
+			existing[field] = value
+		}
+	}
+
+	if k.onUpdate != nil {
+		k.onUpdate(s, existing)
+	}
+
+	if status, _ := existing["status"].(Object); k.lifecycle && status["phase"] != "ERROR" {
+		setPhase(existing, "UPDATING")
+	}
+
+	writeJSON(w, http.StatusOK, Object{k.singular: k.response(existing)})
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, k *kind, scope Object) {
+	params := r.URL.Query()
+
+	match, err := parseQuery(params.Get("query"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidArgument, err.Error())
+		return
+	}
+
+	offset, _ := strconv.Atoi(params.Get("pagination.offset"))
+	size, err := strconv.Atoi(params.Get("pagination.size"))
+	if err != nil || size <= 0 {
+		size = 1000
+	}
+
+	s.mu.Lock()
+	keys := make([]string, 0, len(s.objects[k]))
+	for key := range s.objects[k] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	matching := []Object{}
+	for _, key := range keys {
+		object := roundTrip(s.objects[k][key])
+		if !inScope(object, scope) || !match(object) {
+			continue
+		}
+		matching = append(matching, k.response(object))
+	}
+	s.mu.Unlock()
+
+	page := []Object{}
+	if offset < len(matching) {
+		end := offset + size
+		if end > len(matching) {
+			end = len(matching)
+		}
+		page = matching[offset:end]
+	}
+
+	response := Object{k.plural: page}
+	if params.Get("includeTotalCount") == "true" {
+		response["totalCount"] = strconv.Itoa(len(matching))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// advance moves an object with a lifecycle to the next phase, as if the
+// operation in progress completed between two polls of the client.
+func (s *Server) advance(k *kind, fullName Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[k][k.key(fullName)]
+	if !ok {
+		return
+	}
+
+	key := k.plural + "/" + k.key(fullName)
+	if polled, ok := s.delayedDeletions[key]; ok {
+		if polled {
+			delete(s.objects[k], k.key(fullName))
+			delete(s.delayedDeletions, key)
+		} else {
+			s.delayedDeletions[key] = true
+		}
+		return
+	}
+
+	status, _ := object["status"].(Object)
+	switch status["phase"] {
+	case "CREATING", "UPDATING", "ATTACH_COMPLETE":
+		status["phase"] = "READY"
+		status["health"] = "HEALTHY"
+	case "InProgress":
+		s.finishRestore(object)
+	case "RUNNING":
+		s.finishInspection(object)
+	case "DELETING":
+		delete(s.objects[k], k.key(fullName))
+	}
+}
+
+// errorPhases are the phases of the objects which failed, like a cluster
+// which could not be provisioned or a restore which could not be run.
+var errorPhases = map[string]bool{
+	"ERROR":            true,
+	"UPGRADE_FAILED":   true,
+	"Failed":           true,
+	"FailedValidation": true,
+}
+
+// startDeletion starts deleting an object of a lifecycle kind, which is
+// removed when it is next polled. Objects in an error phase or without a
+// status keep reporting it on the next poll, and are only removed on the one
+// after, so that waiting for their deletion has to get past them. The caller
+// must hold mu.
+func (s *Server) startDeletion(k *kind, object Object) {
+	status, _ := object["status"].(Object)
+	if phase, _ := status["phase"].(string); phase != "" && !errorPhases[phase] {
+		setPhase(object, "DELETING")
+		return
+	}
+
+	s.delayedDeletions[k.plural+"/"+k.key(object["fullName"].(Object))] = false
+}
+
+// setObjectPhase sets the phase of an object, or removes its status when the
+// phase is empty, to check how the provider handles objects in that state.
+func (s *Server) setObjectPhase(k *kind, fullName Object, phase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[k][k.key(fullName)]
+	if !ok {
+		return
+	}
+
+	if phase == "" {
+		delete(object, "status")
+		return
+	}
+	setPhase(object, phase)
+}
+
+func setPhase(object Object, phase string) {
+	status, _ := object["status"].(Object)
+	if status == nil {
+		status = Object{}
+		object["status"] = status
+	}
+
+	status["phase"] = phase
+}
+
+func inScope(object Object, scope Object) bool {
+	fullName := object["fullName"].(Object)
+	for f, v := range scope {
+		if fullName[f] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// response returns the copy of the object sent in responses.
+func (k *kind) response(object Object) Object {
+	copied := roundTrip(object)
+	if k.redact != nil {
+		k.redact(copied)
+	}
+
+	return copied
+}
+
+// roundTrip returns a deep copy of the object with the types the JSON
+// decoder produces, which is what queries are evaluated against.
+func roundTrip(object Object) Object {
+	b, _ := json.Marshal(object)
+
+	copied := Object{}
+	_ = json.Unmarshal(b, &copied)
+
+	return copied
+}
+
+func decodeObject(w http.ResponseWriter, r *http.Request, k *kind) (Object, bool) {
+	body := map[string]Object{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidArgument, err.Error())
+		return nil, false
+	}
+
+	object, ok := body[k.singular]
+	if !ok {
+		writeError(w, http.StatusBadRequest, codeInvalidArgument, fmt.Sprintf("missing %s in request body", k.singular))
+		return nil, false
+	}
+
+	if fullName, _ := object["fullName"].(Object); fullName == nil || fullName["name"] == "" || fullName["name"] == nil {
+		writeError(w, http.StatusBadRequest, codeInvalidArgument, "fullName.name is required")
+		return nil, false
+	}
+
+	return object, true
+}
+
+func writeNotFound(w http.ResponseWriter, k *kind, fullName Object) {
+	writeError(w, http.StatusNotFound, codeNotFound, fmt.Sprintf("%s %s not found", k.singular, fullName["name"]))
+}
+
+func writeError(w http.ResponseWriter, status int, code int, message string) {
+	writeJSON(w, status, Object{
+		"error":   message,
+		"code":    code,
+		"message": message,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -1,20 +1,45 @@
 package tanzuclient
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
+// DefaultCSPURL is the VMware Cloud Services Console used to exchange API
+// tokens for access tokens when no other endpoint is configured.
+const DefaultCSPURL = "https://console.cloud.vmware.com"
+
+// Access tokens are renewed this long before they are due to expire so that
+// a request is never sent with a token that lapses while in flight.
+const tokenRefreshWindow = 5 * time.Minute
+
 // Client is a client for working with the TMC Web API.
 // It is created by `NewClient`.
 type Client struct {
 	http           *http.Client
 	baseURL        string
-	token          AccessToken
+	cspURL         string
+	apiToken       string
 	AcceptLanguage string
+
+	// MaxAttempts is the number of times a request is sent before a
+	// transient failure is returned to the caller.
+	MaxAttempts int
+	// MaxRetryWait caps the delay between two attempts of the same request.
+	MaxRetryWait time.Duration
+
+	// tokenMu guards token and tokenExpiry, as the client is shared between
+	// the goroutines Terraform uses to walk the resource graph in parallel.
+	tokenMu     sync.Mutex
+	token       AccessToken
+	tokenExpiry time.Time
 }
 
 type AccessToken struct {
@@ -23,41 +48,118 @@ type AccessToken struct {
 	ExpiresIn int64  `json:"expires_in"`
 }
 
-func NewClient(url, apiToken *string) (*Client, error) {
-	var token *AccessToken
-
-	if (url == nil) || (apiToken == nil) {
+func NewClient(ctx context.Context, orgURL, cspURL, apiToken *string) (*Client, error) {
+	if (orgURL == nil) || (apiToken == nil) {
 		return nil, errors.New("credentials not set!! please ensure the provider credentials are configured properly")
 	}
 
-	// Use apitoken (previously known as refresh token) to generate an access token.
-	// Usually the access token is valid for a little less than 30minutes.
-	loginURL := "https://console.cloud.vmware.com/csp/gateway/am/api/auth/api-tokens/authorize?refresh_token=" + *apiToken
+	authURL := DefaultCSPURL
+	if cspURL != nil && *cspURL != "" {
+		authURL = strings.TrimSuffix(*cspURL, "/")
+	}
 
-	resp, err := http.Post(loginURL, "application/json", nil)
-	if err != nil {
+	client := &Client{
+		baseURL:  *orgURL,
+		cspURL:   authURL,
+		apiToken: *apiToken,
+		http: &http.Client{
+			Timeout: time.Minute,
+		},
+		MaxAttempts:  DefaultMaxAttempts,
+		MaxRetryWait: DefaultMaxRetryWait,
+	}
+
+	client.tokenMu.Lock()
+	defer client.tokenMu.Unlock()
+
+	if err := client.authorize(ctx); err != nil {
 		return nil, err
 	}
+
+	return client, nil
+}
+
+// authorize uses the apitoken (previously known as refresh token) to generate
+// an access token. Usually the access token is valid for a little less than
+// 30minutes. The caller must hold tokenMu.
+func (c *Client) authorize(ctx context.Context) error {
+	var token AccessToken
+
+	loginURL := c.cspURL + "/csp/gateway/am/api/auth/api-tokens/authorize"
+
+	// The token is sent as a form value rather than in the query string so that
+	// it does not end up in the access logs of proxies along the way.
+	form := url.Values{}
+	form.Set("refresh_token", c.apiToken)
+
+	issuedAt := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", loginURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to authorize against the VMware Cloud Services Console, status code: %d", resp.StatusCode)
+	}
+
 	err = json.NewDecoder(resp.Body).Decode(&token)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &Client{
-		baseURL: *url,
-		token:   *token,
-		http: &http.Client{
-			Timeout: time.Minute,
-		},
-	}, nil
+	if token.Token == "" {
+		return errors.New("the VMware Cloud Services Console did not return an access token")
+	}
+
+	c.token = token
+	c.tokenExpiry = issuedAt.Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return nil
 }
 
-func (c *Client) sendRequest(req *http.Request, v interface{}) error {
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token.Token))
+// accessToken returns a valid access token, re-authorizing first if the
+// current one has expired or is about to.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
 
-	res, err := c.http.Do(req)
+	if c.token.Token == "" || time.Now().Add(tokenRefreshWindow).After(c.tokenExpiry) {
+		if err := c.authorize(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	return c.token.Token, nil
+}
+
+// refreshAccessToken forces a new access token to be generated after the API
+// rejected the stale one. If another goroutine has already replaced the stale
+// token in the meantime, its token is reused instead.
+func (c *Client) refreshAccessToken(ctx context.Context, stale string) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token.Token != stale {
+		return c.token.Token, nil
+	}
+
+	if err := c.authorize(ctx); err != nil {
+		return "", err
+	}
+
+	return c.token.Token, nil
+}
+
+func (c *Client) sendRequest(req *http.Request, v interface{}) error {
+	res, err := c.sendWithRetry(req)
 	if err != nil {
 		return err
 	}
@@ -65,12 +167,15 @@ func (c *Client) sendRequest(req *http.Request, v interface{}) error {
 	defer res.Body.Close()
 
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{StatusCode: res.StatusCode}
+
 		var errRes errorResponse
 		if err = json.NewDecoder(res.Body).Decode(&errRes); err == nil {
-			return errors.New(errRes.Message)
+			apiErr.Code = errRes.Code
+			apiErr.Message = errRes.Message
 		}
 
-		return fmt.Errorf("unknown error, status code: %d", res.StatusCode)
+		return apiErr
 	}
 
 	if err = json.NewDecoder(res.Body).Decode(v); err != nil {
@@ -79,3 +184,63 @@ func (c *Client) sendRequest(req *http.Request, v interface{}) error {
 
 	return nil
 }
+
+// send performs the request with a valid access token.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	token, err := c.accessToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.do(req, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// The token can still be revoked or expire early on the server side, so
+	// refresh it and try the request one more time.
+	if res.StatusCode == http.StatusUnauthorized {
+		res.Body.Close()
+
+		token, err = c.refreshAccessToken(req.Context(), token)
+		if err != nil {
+			return nil, err
+		}
+
+		retry, err := rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.do(retry, token)
+	}
+
+	return res, nil
+}
+
+func (c *Client) do(req *http.Request, token string) (*http.Response, error) {
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	return c.http.Do(req)
+}
+
+// rewindRequest returns a copy of req whose body can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be replayed after the access token was refreshed")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry.Body = body
+
+	return retry, nil
+}
@@ -0,0 +1,184 @@
+package tanzuclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testServer stands in for both the VMware Cloud Services Console and the TMC
+// API. Every token exchange issues a new access token, token-1, token-2...
+type testServer struct {
+	*httptest.Server
+
+	exchanges int32
+}
+
+func newTestServer(t *testing.T, api http.HandlerFunc) *testServer {
+	s := &testServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/csp/gateway/am/api/auth/api-tokens/authorize", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("refresh_token") != "api-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		n := atomic.AddInt32(&s.exchanges, 1)
+		fmt.Fprintf(w, `{"token_type": "bearer", "access_token": "token-%d", "expires_in": 1799}`, n)
+	})
+	mux.HandleFunc("/", api)
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// newTestClient returns a client of the server which retries without waiting
+// for long.
+func newTestClient(t *testing.T, s *testServer) *Client {
+	apiToken := "api-token"
+
+	client, err := NewClient(context.Background(), &s.URL, &s.URL, &apiToken)
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	client.MaxRetryWait = 10 * time.Millisecond
+
+	return client
+}
+
+// clusterGroupHandler returns the cluster group named after the path to the
+// requests made with one of the given access tokens, and rejects the others.
+func clusterGroupHandler(tokens ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, token := range tokens {
+			if r.Header.Get("Authorization") == "Bearer "+token {
+				fmt.Fprintf(w, `{"clusterGroup": {"fullName": {"name": %q}}}`, r.URL.Path[len("/v1alpha1/clustergroups/"):])
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"code": 16, "message": "token is expired"}`)
+	}
+}
+
+func TestClientExpiredToken(t *testing.T) {
+	s := newTestServer(t, clusterGroupHandler("token-2"))
+	client := newTestClient(t, s)
+
+	// The token issued by NewClient lapses before the request is sent
+	client.tokenExpiry = time.Now().Add(-time.Minute)
+
+	group, err := client.GetClusterGroup(context.Background(), "tf-acc")
+	if err != nil {
+		t.Fatalf("GetClusterGroup: %s", err)
+	}
+	if group.FullName.Name != "tf-acc" {
+		t.Errorf("expected cluster group tf-acc, got %s", group.FullName.Name)
+	}
+	if exchanges := atomic.LoadInt32(&s.exchanges); exchanges != 2 {
+		t.Errorf("expected 2 token exchanges, got %d", exchanges)
+	}
+}
+
+func TestClientRejectedToken(t *testing.T) {
+	var requests int32
+	handler := clusterGroupHandler("token-2")
+
+	s := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		handler(w, r)
+	})
+	client := newTestClient(t, s)
+
+	// TMC revoked the token issued by NewClient, which still looks valid
+	group, err := client.GetClusterGroup(context.Background(), "tf-acc")
+	if err != nil {
+		t.Fatalf("GetClusterGroup: %s", err)
+	}
+	if group.FullName.Name != "tf-acc" {
+		t.Errorf("expected cluster group tf-acc, got %s", group.FullName.Name)
+	}
+	if exchanges := atomic.LoadInt32(&s.exchanges); exchanges != 2 {
+		t.Errorf("expected 2 token exchanges, got %d", exchanges)
+	}
+	if sent := atomic.LoadInt32(&requests); sent != 2 {
+		t.Errorf("expected the request to be sent 2 times, got %d", sent)
+	}
+}
+
+func TestClientRejectedTokenTwice(t *testing.T) {
+	s := newTestServer(t, clusterGroupHandler())
+	client := newTestClient(t, s)
+
+	_, err := client.GetClusterGroup(context.Background(), "tf-acc")
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected a 401 error, got %v", err)
+	}
+
+	// The token is only refreshed once per request
+	if exchanges := atomic.LoadInt32(&s.exchanges); exchanges != 2 {
+		t.Errorf("expected 2 token exchanges, got %d", exchanges)
+	}
+}
+
+func TestClientConcurrentRefresh(t *testing.T) {
+	const requests = 20
+
+	testCases := []struct {
+		name   string
+		expire func(client *Client)
+	}{
+		{
+			name: "expired token",
+			expire: func(client *Client) {
+				client.tokenExpiry = time.Now().Add(-time.Minute)
+			},
+		},
+		{
+			name:   "rejected token",
+			expire: func(client *Client) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestServer(t, clusterGroupHandler("token-2"))
+			client := newTestClient(t, s)
+
+			tc.expire(client)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, requests)
+
+			for i := 0; i < requests; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					if _, err := client.GetClusterGroup(context.Background(), fmt.Sprintf("group-%d", i)); err != nil {
+						errs <- err
+					}
+				}(i)
+			}
+
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Errorf("GetClusterGroup: %s", err)
+			}
+			if exchanges := atomic.LoadInt32(&s.exchanges); exchanges != 2 {
+				t.Errorf("expected 2 token exchanges for %d requests, got %d", requests, exchanges)
+			}
+		})
+	}
+}
@@ -2,27 +2,77 @@ package tanzuclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 )
 
+// CIDRBlock is a range of addresses allocated to the pods or services of a
+// cluster.
+type CIDRBlock struct {
+	CidrBlocks string `json:"cidrBlocks"`
+}
+
+// AWSSubnet is a subnet of the VPC hosting a TKG cluster on AWS. Subnets
+// without an ID are created along with the cluster.
+type AWSSubnet struct {
+	ID               string `json:"id,omitempty"`
+	AvailabilityZone string `json:"availabilityZone"`
+	CidrBlock        string `json:"cidrBlock,omitempty"`
+	IsPublic         bool   `json:"isPublic"`
+}
+
 type Network struct {
 	ClusterNetwork struct {
-		Pods []struct {
-			CidrBlocks string `json:"cidrBlocks"`
-		} `json:"pods"`
-		Services []struct {
-			CidrBlocks string `json:"cidrBlocks"`
-		} `json:"services"`
+		Pods     []CIDRBlock `json:"pods,omitempty"`
+		Services []CIDRBlock `json:"services,omitempty"`
 	} `json:"cluster"`
 	Provider struct {
+		Subnets []AWSSubnet `json:"subnets,omitempty"`
+		// Vpc is either an existing VPC referenced by its ID, or the CIDR
+		// block of a VPC created along with the cluster.
 		Vpc struct {
-			CidrBlock string `json:"cidrBlock"`
+			ID        string `json:"id,omitempty"`
+			CidrBlock string `json:"cidrBlock,omitempty"`
 		} `json:"vpc"`
 	} `json:"provider"`
 }
 
+type NodePoolInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// AWSNodePlacement is an availability zone the nodes of a pool are spread over.
+type AWSNodePlacement struct {
+	AvailabilityZone string `json:"awsAvailabilityZone"`
+}
+
+type AWSNodeSpec struct {
+	AvailabilityZone string             `json:"availabilityZone"`
+	InstanceType     string             `json:"instanceType"`
+	NodePlacement    []AWSNodePlacement `json:"nodePlacement,omitempty"`
+	Version          string             `json:"version,omitempty"`
+}
+
+type AWSNodePoolSpec struct {
+	// The API encodes the number of workers as a string.
+	WorkerNodeCount string            `json:"workerNodeCount"`
+	CloudLabels     map[string]string `json:"cloudLabels,omitempty"`
+	NodeLabels      map[string]string `json:"nodeLabels,omitempty"`
+	TkgAws          AWSNodeSpec       `json:"tkgAws"`
+}
+
+// AWSNodePool is a pool of worker nodes created along with a TKG cluster on
+// AWS.
+type AWSNodePool struct {
+	Info NodePoolInfo    `json:"info"`
+	Spec AWSNodePoolSpec `json:"spec"`
+}
+
 type AWSCluster struct {
 	Distribution struct {
 		ProvisionerCredentialName string `json:"provisionerCredentialName"`
@@ -39,17 +89,183 @@ type AWSCluster struct {
 		ControlPlane struct {
 			AvailabilityZones []string `json:"availabilityZones"`
 			InstanceType      string   `json:"instanceType"`
+			// HighAvailability runs three control plane nodes, one in each of
+			// the availability zones, instead of a single one.
+			HighAvailability bool `json:"highAvailability"`
+		} `json:"controlPlane"`
+		NodePools []AWSNodePool `json:"nodePools,omitempty"`
+	} `json:"topology"`
+}
+
+// VsphereWorkspace is the vSphere inventory a TKG cluster is deployed into.
+type VsphereWorkspace struct {
+	Datacenter       string `json:"datacenter"`
+	Datastore        string `json:"datastore"`
+	Folder           string `json:"folder"`
+	ResourcePool     string `json:"resourcePool"`
+	WorkspaceNetwork string `json:"workspaceNetwork"`
+}
+
+// VsphereCIDRBlocks are the ranges of addresses allocated to the pods or
+// services of a cluster on vSphere.
+type VsphereCIDRBlocks struct {
+	CidrBlocks []string `json:"cidrBlocks,omitempty"`
+}
+
+type VsphereNetwork struct {
+	Pods     VsphereCIDRBlocks `json:"pods"`
+	Services VsphereCIDRBlocks `json:"services"`
+	// ControlPlaneEndpoint is the virtual IP address of the API server.
+	ControlPlaneEndpoint string `json:"controlPlaneEndpoint,omitempty"`
+}
+
+// VsphereVMConfig is the size of the virtual machines of a cluster. The API
+// encodes the numbers as strings.
+type VsphereVMConfig struct {
+	CPU       string `json:"cpu"`
+	DiskGiB   string `json:"diskGib"`
+	MemoryMiB string `json:"memoryMib"`
+}
+
+type VsphereNodeSpec struct {
+	VMConfig VsphereVMConfig `json:"vmConfig"`
+}
+
+type VsphereNodePoolSpec struct {
+	WorkerNodeCount string            `json:"workerNodeCount"`
+	NodeLabels      map[string]string `json:"nodeLabels,omitempty"`
+	TkgVsphere      VsphereNodeSpec   `json:"tkgVsphere"`
+}
+
+// VsphereNodePool is a pool of worker nodes created along with a TKG cluster
+// on vSphere.
+type VsphereNodePool struct {
+	Info NodePoolInfo        `json:"info"`
+	Spec VsphereNodePoolSpec `json:"spec"`
+}
+
+type TkgVsphere struct {
+	Distribution struct {
+		Version   string           `json:"version"`
+		Workspace VsphereWorkspace `json:"workspace"`
+	} `json:"distribution"`
+	Settings struct {
+		Network  VsphereNetwork `json:"network"`
+		Security struct {
+			SshKey string `json:"sshKey"`
+		} `json:"security"`
+	} `json:"settings"`
+	Topology struct {
+		ControlPlane struct {
+			VMConfig         VsphereVMConfig `json:"vmConfig"`
+			HighAvailability bool            `json:"highAvailability"`
 		} `json:"controlPlane"`
+		NodePools []VsphereNodePool `json:"nodePools,omitempty"`
+	} `json:"topology"`
+}
+
+// TKGSVolume is a volume mounted on the nodes of a vSphere with Tanzu cluster.
+type TKGSVolume struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	// Capacity of the volume in GiB
+	Capacity     float64 `json:"capacity"`
+	StorageClass string  `json:"storageClass,omitempty"`
+}
+
+type TKGSNodeSpec struct {
+	// Class is the name of the virtual machine class of the nodes.
+	Class        string       `json:"class"`
+	StorageClass string       `json:"storageClass"`
+	Volumes      []TKGSVolume `json:"volumes,omitempty"`
+}
+
+type TKGSNodePoolSpec struct {
+	WorkerNodeCount   string            `json:"workerNodeCount"`
+	NodeLabels        map[string]string `json:"nodeLabels,omitempty"`
+	TkgServiceVsphere TKGSNodeSpec      `json:"tkgServiceVsphere"`
+}
+
+// TKGSNodePool is a pool of worker nodes created along with a vSphere with
+// Tanzu cluster.
+type TKGSNodePool struct {
+	Info NodePoolInfo     `json:"info"`
+	Spec TKGSNodePoolSpec `json:"spec"`
+}
+
+// TkgServiceVsphere is the spec of clusters provisioned by the Tanzu
+// Kubernetes Grid Service of a vSphere supervisor cluster.
+type TkgServiceVsphere struct {
+	Distribution struct {
+		// Version is the name of the Tanzu Kubernetes release of the cluster.
+		Version string `json:"version"`
+	} `json:"distribution"`
+	Settings struct {
+		Network struct {
+			Pods     VsphereCIDRBlocks `json:"pods"`
+			Services VsphereCIDRBlocks `json:"services"`
+		} `json:"network"`
+		Storage struct {
+			Classes      []string `json:"classes,omitempty"`
+			DefaultClass string   `json:"defaultClass,omitempty"`
+		} `json:"storage"`
+	} `json:"settings"`
+	Topology struct {
+		ControlPlane struct {
+			TKGSNodeSpec     `json:",inline"`
+			HighAvailability bool `json:"highAvailability"`
+		} `json:"controlPlane"`
+		NodePools []TKGSNodePool `json:"nodePools,omitempty"`
 	} `json:"topology"`
 }
 
 type ClusterSpec struct {
 	ClusterGroupName string `json:"clusterGroupName"`
-	//TkgAws           AWSCluster `json:"tkgAws,omitempty"`
+	// TkgAws is only set for Tanzu Kubernetes Grid clusters provisioned on AWS.
+	TkgAws *AWSCluster `json:"tkgAws,omitempty"`
+	// TkgVsphere is only set for Tanzu Kubernetes Grid clusters provisioned on
+	// vSphere.
+	TkgVsphere *TkgVsphere `json:"tkgVsphere,omitempty"`
+	// TkgServiceVsphere is only set for clusters provisioned by a vSphere
+	// with Tanzu supervisor cluster.
+	TkgServiceVsphere *TkgServiceVsphere `json:"tkgServiceVsphere,omitempty"`
 }
 
+// Phases of the lifecycle of a cluster reported in ClusterStatus.
+const (
+	ClusterPhaseUnspecified    = "PHASE_UNSPECIFIED"
+	ClusterPhasePending        = "PENDING"
+	ClusterPhaseProcessing     = "PROCESSING"
+	ClusterPhaseCreating       = "CREATING"
+	ClusterPhaseAttachComplete = "ATTACH_COMPLETE"
+	ClusterPhaseReady          = "READY"
+	ClusterPhaseUpdating       = "UPDATING"
+	ClusterPhaseUpgrading      = "UPGRADING"
+	ClusterPhaseUpgradeFailed  = "UPGRADE_FAILED"
+	ClusterPhaseDetaching      = "DETACHING"
+	ClusterPhaseDeleting       = "DELETING"
+	ClusterPhaseError          = "ERROR"
+)
+
+// ClusterHealthHealthy is the health reported in ClusterStatus once the
+// agent of the cluster is up and running.
+const ClusterHealthHealthy = "HEALTHY"
+
+// Existing clusters are attached under this management cluster and
+// provisioner, as TMC does not manage their lifecycle.
+const (
+	AttachedManagementClusterName = "attached"
+	AttachedProvisionerName       = "attached"
+)
+
 type ClusterStatus struct {
 	InstallerLink string `json:"installerLink"`
+	// Lifecycle phase of the cluster
+	Phase string `json:"phase,omitempty"`
+	// Health of the cluster as reported by the agent
+	Health string `json:"health,omitempty"`
+	// Conditions of the cluster, keyed by their type
+	Conditions map[string]Condition `json:"conditions,omitempty"`
 }
 
 type Cluster struct {
@@ -63,10 +279,20 @@ type ClusterJSONObject struct {
 	Cluster Cluster `json:"cluster"`
 }
 
-func (c *Client) GetCluster(fullName string, managementClusterName string, provisionerName string) (*Cluster, error) {
-	requestURL := fmt.Sprintf("%s/v1alpha1/clusters/%s?fullName.managementClusterName=%s&fullName.provisionerName=%s", c.baseURL, fullName, managementClusterName, provisionerName)
+// clusterQuery returns the query parameters identifying a cluster by the
+// management cluster and provisioner it belongs to.
+func clusterQuery(managementClusterName string, provisionerName string) url.Values {
+	query := url.Values{}
+	query.Set("fullName.managementClusterName", managementClusterName)
+	query.Set("fullName.provisionerName", provisionerName)
+
+	return query
+}
+
+func (c *Client) GetCluster(ctx context.Context, fullName string, managementClusterName string, provisionerName string) (*Cluster, error) {
+	requestURL := fmt.Sprintf("%s/v1alpha1/clusters/%s?%s", c.baseURL, url.PathEscape(fullName), clusterQuery(managementClusterName, provisionerName).Encode())
 
-	req, err := http.NewRequest("GET", requestURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +306,7 @@ func (c *Client) GetCluster(fullName string, managementClusterName string, provi
 	return &res.Cluster, nil
 }
 
-func (c *Client) CreateCluster(name string, description string, managementCluster string, provisionerName string, clusterGroupName string, labels map[string]interface{}) (*Cluster, error) {
+func (c *Client) CreateCluster(ctx context.Context, name string, description string, managementCluster string, provisionerName string, spec *ClusterSpec, labels map[string]interface{}) (*Cluster, error) {
 	requestURL := fmt.Sprintf("%s/v1alpha1/clusters", c.baseURL)
 
 	newCluster := &Cluster{
@@ -95,9 +321,7 @@ func (c *Client) CreateCluster(name string, description string, managementCluste
 			Description: description,
 			Labels:      labels,
 		},
-		Spec: &ClusterSpec{
-			ClusterGroupName: clusterGroupName,
-		},
+		Spec: spec,
 	}
 
 	newClusterObject := ClusterJSONObject{
@@ -110,7 +334,7 @@ func (c *Client) CreateCluster(name string, description string, managementCluste
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(json_data))
+	req, err := http.NewRequestWithContext(ctx, "POST", requestURL, bytes.NewBuffer(json_data))
 	if err != nil {
 		return nil, err
 	}
@@ -124,8 +348,8 @@ func (c *Client) CreateCluster(name string, description string, managementCluste
 	return &res.Cluster, nil
 }
 
-func (c *Client) UpdateCluster(name string, description string, managementCluster string, provisionerName string, clusterGroupName string, labels map[string]interface{}) (*Cluster, error) {
-	requestURL := fmt.Sprintf("%s/v1alpha1/clusters/%s", c.baseURL, name)
+func (c *Client) UpdateCluster(ctx context.Context, name string, description string, managementCluster string, provisionerName string, spec *ClusterSpec, labels map[string]interface{}) (*Cluster, error) {
+	requestURL := fmt.Sprintf("%s/v1alpha1/clusters/%s", c.baseURL, url.PathEscape(name))
 
 	newCluster := &Cluster{
 		FullName: &FullNameProvisioned{
@@ -139,9 +363,7 @@ func (c *Client) UpdateCluster(name string, description string, managementCluste
 			Description: description,
 			Labels:      labels,
 		},
-		Spec: &ClusterSpec{
-			ClusterGroupName: clusterGroupName,
-		},
+		Spec: spec,
 	}
 
 	newClusterObject := ClusterJSONObject{
@@ -154,7 +376,7 @@ func (c *Client) UpdateCluster(name string, description string, managementCluste
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", requestURL, bytes.NewBuffer(json_data))
+	req, err := http.NewRequestWithContext(ctx, "PUT", requestURL, bytes.NewBuffer(json_data))
 	if err != nil {
 		return nil, err
 	}
@@ -168,10 +390,10 @@ func (c *Client) UpdateCluster(name string, description string, managementCluste
 	return &res.Cluster, nil
 }
 
-func (c *Client) DeleteCluster(name string, managementCluster string, provisionerName string) error {
-	requestURL := fmt.Sprintf("%s/v1alpha1/clusters/%s?fullName.managementClusterName=%s&fullName.provisionerName=%s", c.baseURL, name, managementCluster, provisionerName)
+func (c *Client) DeleteCluster(ctx context.Context, name string, managementCluster string, provisionerName string) error {
+	requestURL := fmt.Sprintf("%s/v1alpha1/clusters/%s?%s", c.baseURL, url.PathEscape(name), clusterQuery(managementCluster, provisionerName).Encode())
 
-	req, err := http.NewRequest("DELETE", requestURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
 	if err != nil {
 		return err
 	}
@@ -184,3 +406,25 @@ func (c *Client) DeleteCluster(name string, managementCluster string, provisione
 
 	return nil
 }
+
+// GetClusterInstallerManifest downloads the manifest installing the TMC agent
+// on a cluster from the installer link in its status. The link embeds its
+// own credentials, so the request is sent without an access token.
+func (c *Client) GetClusterInstallerManifest(ctx context.Context, installerLink string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", installerLink, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download the installer manifest, status code: %d", res.StatusCode)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
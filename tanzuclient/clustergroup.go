@@ -2,9 +2,11 @@ package tanzuclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type ClusterGroup struct {
@@ -20,13 +22,18 @@ type ClusterGroupJsonObject struct {
 
 type AllClusterGroups struct {
 	ClusterGroups []ClusterGroup `json:"clusterGroups"`
+	pageInfo
+}
+
+func (a *AllClusterGroups) pageLength() int {
+	return len(a.ClusterGroups)
 }
 
 // Fetch Details about an existing Cluster Group using its name
-func (c *Client) GetClusterGroup(name string) (*ClusterGroup, error) {
-	requestURL := fmt.Sprintf("%s/v1alpha1/clustergroups/%s", c.baseURL, name)
+func (c *Client) GetClusterGroup(ctx context.Context, name string) (*ClusterGroup, error) {
+	requestURL := fmt.Sprintf("%s/v1alpha1/clustergroups/%s", c.baseURL, url.PathEscape(name))
 
-	req, err := http.NewRequest("GET", requestURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +50,7 @@ func (c *Client) GetClusterGroup(name string) (*ClusterGroup, error) {
 // Create a new Cluster Group with a given name.
 // Also accepts a description for the Cluster Group and
 // a set of labels to be added to the Cluster Group
-func (c *Client) CreateClusterGroup(name string, description string, labels map[string]interface{}) (*ClusterGroup, error) {
+func (c *Client) CreateClusterGroup(ctx context.Context, name string, description string, labels map[string]interface{}) (*ClusterGroup, error) {
 
 	requestURL := c.baseURL + "/v1alpha1/clustergroups"
 
@@ -67,7 +74,7 @@ func (c *Client) CreateClusterGroup(name string, description string, labels map[
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(json_data))
+	req, err := http.NewRequestWithContext(ctx, "POST", requestURL, bytes.NewBuffer(json_data))
 	if err != nil {
 		return nil, err
 	}
@@ -82,10 +89,10 @@ func (c *Client) CreateClusterGroup(name string, description string, labels map[
 }
 
 // Deletes an already existing Cluster Group with a given name.
-func (c *Client) DeleteClusterGroup(name string) error {
-	requestURL := c.baseURL + "/v1alpha1/clustergroups/" + name
+func (c *Client) DeleteClusterGroup(ctx context.Context, name string) error {
+	requestURL := c.baseURL + "/v1alpha1/clustergroups/" + url.PathEscape(name)
 
-	req, err := http.NewRequest("DELETE", requestURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
 	if err != nil {
 		return err
 	}
@@ -102,9 +109,9 @@ func (c *Client) DeleteClusterGroup(name string) error {
 // Updates the Cluster Group using its name.
 // Only the description and labels can be updated.
 // Changing the Name forces replacement
-func (c *Client) UpdateClusterGroup(name string, description string, labels map[string]interface{}) (*ClusterGroup, error) {
+func (c *Client) UpdateClusterGroup(ctx context.Context, name string, description string, labels map[string]interface{}) (*ClusterGroup, error) {
 
-	requestURL := c.baseURL + "/v1alpha1/clustergroups/" + name
+	requestURL := c.baseURL + "/v1alpha1/clustergroups/" + url.PathEscape(name)
 
 	newClusterGroup := &ClusterGroup{
 		FullName: &FullName{
@@ -126,7 +133,7 @@ func (c *Client) UpdateClusterGroup(name string, description string, labels map[
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", requestURL, bytes.NewBuffer(json_data))
+	req, err := http.NewRequestWithContext(ctx, "PUT", requestURL, bytes.NewBuffer(json_data))
 	if err != nil {
 		return nil, err
 	}
@@ -140,22 +147,27 @@ func (c *Client) UpdateClusterGroup(name string, description string, labels map[
 	return &res.ClusterGroup, nil
 }
 
-func (c *Client) GetAllClusterGroups(labels map[string]interface{}) (*[]ClusterGroup, error) {
+// Fetch all the Cluster Groups matching the given query,
+// following the pagination of the API until the last page.
+func (c *Client) GetAllClusterGroups(ctx context.Context, query Query) (*[]ClusterGroup, error) {
 
-	queryString := buildLabelQuery(labels)
+	requestURL := c.baseURL + "/v1alpha1/clustergroups"
 
-	requestURL := c.baseURL + "/v1alpha1/clustergroups?query=" + queryString
+	params := url.Values{}
+	params.Set("query", query.String())
 
-	req, err := http.NewRequest("GET", requestURL, nil)
-	if err != nil {
-		return nil, err
-	}
+	clusterGroups := []ClusterGroup{}
 
-	res := &AllClusterGroups{}
+	pages := c.newPager(requestURL, params)
+	for pages.HasNext() {
+		res := &AllClusterGroups{}
 
-	if err := c.sendRequest(req, &res); err != nil {
-		return nil, err
+		if err := pages.Next(ctx, res); err != nil {
+			return nil, err
+		}
+
+		clusterGroups = append(clusterGroups, res.ClusterGroups...)
 	}
 
-	return &res.ClusterGroups, nil
+	return &clusterGroups, nil
 }
@@ -0,0 +1,374 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Phases of the continuous delivery of clusters and cluster groups.
+const (
+	ContinuousDeliveryPhasePending  = "PENDING"
+	ContinuousDeliveryPhaseCreating = "CREATING"
+	ContinuousDeliveryPhaseReady    = "READY"
+	ContinuousDeliveryPhaseError    = "ERROR"
+	ContinuousDeliveryPhaseDeleting = "DELETING"
+)
+
+// Phases of the Flux objects TMC applies to clusters, like Git repositories
+// and Kustomizations.
+const (
+	FluxPhasePending = "PENDING"
+	FluxPhaseApplied = "APPLIED"
+	FluxPhaseError   = "ERROR"
+)
+
+// FluxFullName identifies a Flux object along with the cluster or cluster
+// group it is applied to, only the fields of its scope are set. The
+// continuous delivery of a scope has no name of its own.
+type FluxFullName struct {
+	OrgID                 string `json:"orgId,omitempty"`
+	Name                  string `json:"name,omitempty"`
+	ClusterGroupName      string `json:"clusterGroupName,omitempty"`
+	ManagementClusterName string `json:"managementClusterName,omitempty"`
+	ProvisionerName       string `json:"provisionerName,omitempty"`
+	ClusterName           string `json:"clusterName,omitempty"`
+	NamespaceName         string `json:"namespaceName,omitempty"`
+}
+
+type FluxStatus struct {
+	Phase      string               `json:"phase,omitempty"`
+	Conditions map[string]Condition `json:"conditions,omitempty"`
+}
+
+type ContinuousDelivery struct {
+	FullName *FluxFullName `json:"fullName"`
+	Meta     *MetaData     `json:"meta"`
+	Status   *FluxStatus   `json:"status"`
+}
+
+type ContinuousDeliveryJSONObject struct {
+	ContinuousDelivery ContinuousDelivery `json:"continuousDelivery"`
+}
+
+// GitRepositoryRef selects the revision of a Git repository to apply, only
+// one of its fields is set.
+type GitRepositoryRef struct {
+	Branch string `json:"branch,omitempty"`
+	Tag    string `json:"tag,omitempty"`
+	// Semver is a range of versions, the latest tag matching it is applied.
+	Semver string `json:"semver,omitempty"`
+}
+
+type GitRepositorySpec struct {
+	URL string `json:"url"`
+	// SecretRef is the name of the source secret holding the credentials to
+	// access the repository, when it is not public.
+	SecretRef string `json:"secretRef,omitempty"`
+	// Interval is how often the repository is fetched, like 5m.
+	Interval string            `json:"interval"`
+	Ref      *GitRepositoryRef `json:"ref"`
+}
+
+type GitRepository struct {
+	FullName *FluxFullName      `json:"fullName"`
+	Meta     *MetaData          `json:"meta"`
+	Spec     *GitRepositorySpec `json:"spec"`
+	Status   *FluxStatus        `json:"status"`
+}
+
+type GitRepositoryJSONObject struct {
+	GitRepository GitRepository `json:"gitRepository"`
+}
+
+// KustomizationSource names the Git repository a Kustomization is applied
+// from.
+type KustomizationSource struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type KustomizationSpec struct {
+	// Path of the directory of the repository holding the manifests.
+	Path string `json:"path"`
+	// Prune removes the objects which are no longer in the repository.
+	Prune    bool   `json:"prune"`
+	Interval string `json:"interval"`
+	// TargetNamespace overrides the namespace of the objects applied.
+	TargetNamespace string               `json:"targetNamespace,omitempty"`
+	Source          *KustomizationSource `json:"source"`
+}
+
+type Kustomization struct {
+	FullName *FluxFullName      `json:"fullName"`
+	Meta     *MetaData          `json:"meta"`
+	Spec     *KustomizationSpec `json:"spec"`
+	Status   *FluxStatus        `json:"status"`
+}
+
+type KustomizationJSONObject struct {
+	Kustomization Kustomization `json:"kustomization"`
+}
+
+// FluxScope identifies the cluster or cluster group continuous delivery is
+// enabled on.
+type FluxScope struct {
+	path     string
+	query    url.Values
+	fullName FluxFullName
+}
+
+func ClusterFluxScope(managementClusterName string, provisionerName string, name string) FluxScope {
+	return FluxScope{
+		path:  fmt.Sprintf("/v1alpha1/clusters/%s", url.PathEscape(name)),
+		query: clusterQuery(managementClusterName, provisionerName),
+		fullName: FluxFullName{
+			ManagementClusterName: managementClusterName,
+			ProvisionerName:       provisionerName,
+			ClusterName:           name,
+		},
+	}
+}
+
+func ClusterGroupFluxScope(name string) FluxScope {
+	return FluxScope{
+		path:     fmt.Sprintf("/v1alpha1/clustergroups/%s", url.PathEscape(name)),
+		fullName: FluxFullName{ClusterGroupName: name},
+	}
+}
+
+// url returns the URL of a collection of Flux objects of the scope, or of one
+// of them when its name is given. The objects of a cluster group are applied
+// to the namespace of every cluster of the group, which is passed in the query
+// rather than in the path.
+func (s FluxScope) url(baseURL string, namespaceName string, collection string, name string) string {
+	path := s.path
+	query := url.Values{}
+	for param, values := range s.query {
+		query[param] = values
+	}
+
+	if namespaceName != "" {
+		if s.fullName.ClusterGroupName != "" {
+			path += "/namespace"
+			query.Set("fullName.namespaceName", namespaceName)
+		} else {
+			path += "/namespaces/" + url.PathEscape(namespaceName)
+		}
+	}
+
+	requestURL := fmt.Sprintf("%s%s/fluxcd/%s", baseURL, path, collection)
+	if name != "" {
+		requestURL += "/" + url.PathEscape(name)
+	}
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	return requestURL
+}
+
+// newFullName returns the full name of an object of the scope.
+func (s FluxScope) newFullName(namespaceName string, name string) *FluxFullName {
+	fullName := s.fullName
+	fullName.NamespaceName = namespaceName
+	fullName.Name = name
+
+	return &fullName
+}
+
+// GetContinuousDelivery returns the continuous delivery of a cluster or
+// cluster group, or an error for which IsNotFound is true when it is not
+// enabled.
+func (c *Client) GetContinuousDelivery(ctx context.Context, scope FluxScope) (*ContinuousDelivery, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", scope.url(c.baseURL, "", "continuousdelivery", ""), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := ContinuousDeliveryJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.ContinuousDelivery, nil
+}
+
+// EnableContinuousDelivery installs Flux on a cluster, or on every cluster
+// of a cluster group, so Git repositories and Kustomizations can be applied.
+func (c *Client) EnableContinuousDelivery(ctx context.Context, scope FluxScope) (*ContinuousDelivery, error) {
+	newContinuousDeliveryObject := ContinuousDeliveryJSONObject{
+		ContinuousDelivery: ContinuousDelivery{
+			FullName: scope.newFullName("", ""),
+		},
+	}
+
+	json_data, err := json.Marshal(newContinuousDeliveryObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", scope.url(c.baseURL, "", "continuousdelivery", ""), bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := ContinuousDeliveryJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.ContinuousDelivery, nil
+}
+
+// DisableContinuousDelivery removes Flux from a cluster, or from the clusters
+// of a cluster group.
+func (c *Client) DisableContinuousDelivery(ctx context.Context, scope FluxScope) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", scope.url(c.baseURL, "", "continuousdelivery", ""), nil)
+	if err != nil {
+		return err
+	}
+
+	res := ContinuousDeliveryJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) GetGitRepository(ctx context.Context, scope FluxScope, namespaceName string, name string) (*GitRepository, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", scope.url(c.baseURL, namespaceName, "gitrepositories", name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := GitRepositoryJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.GitRepository, nil
+}
+
+func (c *Client) CreateGitRepository(ctx context.Context, scope FluxScope, namespaceName string, name string, spec *GitRepositorySpec) (*GitRepository, error) {
+	return c.sendGitRepository(ctx, "POST", scope.url(c.baseURL, namespaceName, "gitrepositories", ""), scope, namespaceName, name, spec)
+}
+
+func (c *Client) UpdateGitRepository(ctx context.Context, scope FluxScope, namespaceName string, name string, spec *GitRepositorySpec) (*GitRepository, error) {
+	return c.sendGitRepository(ctx, "PUT", scope.url(c.baseURL, namespaceName, "gitrepositories", name), scope, namespaceName, name, spec)
+}
+
+func (c *Client) sendGitRepository(ctx context.Context, method string, requestURL string, scope FluxScope, namespaceName string, name string, spec *GitRepositorySpec) (*GitRepository, error) {
+	newGitRepositoryObject := GitRepositoryJSONObject{
+		GitRepository: GitRepository{
+			FullName: scope.newFullName(namespaceName, name),
+			Spec:     spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newGitRepositoryObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := GitRepositoryJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.GitRepository, nil
+}
+
+func (c *Client) DeleteGitRepository(ctx context.Context, scope FluxScope, namespaceName string, name string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", scope.url(c.baseURL, namespaceName, "gitrepositories", name), nil)
+	if err != nil {
+		return err
+	}
+
+	res := GitRepositoryJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) GetKustomization(ctx context.Context, scope FluxScope, namespaceName string, name string) (*Kustomization, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", scope.url(c.baseURL, namespaceName, "kustomizations", name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := KustomizationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Kustomization, nil
+}
+
+func (c *Client) CreateKustomization(ctx context.Context, scope FluxScope, namespaceName string, name string, spec *KustomizationSpec) (*Kustomization, error) {
+	return c.sendKustomization(ctx, "POST", scope.url(c.baseURL, namespaceName, "kustomizations", ""), scope, namespaceName, name, spec)
+}
+
+func (c *Client) UpdateKustomization(ctx context.Context, scope FluxScope, namespaceName string, name string, spec *KustomizationSpec) (*Kustomization, error) {
+	return c.sendKustomization(ctx, "PUT", scope.url(c.baseURL, namespaceName, "kustomizations", name), scope, namespaceName, name, spec)
+}
+
+func (c *Client) sendKustomization(ctx context.Context, method string, requestURL string, scope FluxScope, namespaceName string, name string, spec *KustomizationSpec) (*Kustomization, error) {
+	newKustomizationObject := KustomizationJSONObject{
+		Kustomization: Kustomization{
+			FullName: scope.newFullName(namespaceName, name),
+			Spec:     spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newKustomizationObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := KustomizationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Kustomization, nil
+}
+
+func (c *Client) DeleteKustomization(ctx context.Context, scope FluxScope, namespaceName string, name string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", scope.url(c.baseURL, namespaceName, "kustomizations", name), nil)
+	if err != nil {
+		return err
+	}
+
+	res := KustomizationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
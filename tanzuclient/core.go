@@ -1,14 +1,10 @@
 package tanzuclient
 
-import (
-	"fmt"
-	"strings"
-)
-
 type MetaData struct {
-	UID         string                 `json:"uid"`
-	Labels      map[string]interface{} `json:"labels,omitempty"`
-	Description string                 `json:"description,omitempty"`
+	UID          string                 `json:"uid"`
+	Labels       map[string]interface{} `json:"labels,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	CreationTime string                 `json:"creationTime,omitempty"`
 }
 
 type FullName struct {
@@ -22,28 +18,38 @@ type FullNameProvisioned struct {
 	ProvisionerName string `json:"provisionerName,omitempty"`
 }
 
-type errorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+// ClusterScopedFullName identifies an object, like a node pool or a
+// namespace, by the provisioned cluster it belongs to.
+type ClusterScopedFullName struct {
+	FullNameProvisioned `json:",inline"`
+	ClusterName         string `json:"clusterName"`
 }
 
-func buildLabelQuery(labels map[string]interface{}) string {
-
-	var query strings.Builder
-	var labelArray []string
-
-	for k, v := range labels {
-		newFilter := fmt.Sprintf("meta.labels.%s:%s", k, v)
-		labelArray = append(labelArray, newFilter)
+func newClusterScopedFullName(name string, clusterName string, managementClusterName string, provisionerName string) *ClusterScopedFullName {
+	return &ClusterScopedFullName{
+		FullNameProvisioned: FullNameProvisioned{
+			FullName: FullName{
+				Name:                  name,
+				ManagementClusterName: managementClusterName,
+			},
+			ProvisionerName: provisionerName,
+		},
+		ClusterName: clusterName,
 	}
+}
 
-	for i, label := range labelArray {
-		query.WriteString(label)
-		if i == len(labelArray)-1 {
-			break
-		}
-		query.WriteString(" and ")
-	}
+// Condition describes an aspect of the state of an object, such as whether
+// it is ready, as reported in its status.
+type Condition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Severity           string `json:"severity,omitempty"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
 
-	return query.String()
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
 }
@@ -0,0 +1,227 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Capabilities of credentials, telling what TMC uses them for.
+const (
+	CredentialCapabilityManagedK8sProvider = "MANAGED_K8S_PROVIDER"
+	CredentialCapabilityDataProtection     = "DATA_PROTECTION"
+)
+
+// Providers of the accounts credentials give access to.
+const (
+	CredentialProviderAWSEC2    = "AWS_EC2"
+	CredentialProviderAzureAKS  = "AZURE_AKS"
+	CredentialProviderGenericS3 = "GENERIC_S3"
+	CredentialProviderAzureAD   = "AZURE_AD"
+)
+
+// Phases of credentials.
+const (
+	// CredentialPhaseCreated is reported while TMC checks the access the
+	// credential gives.
+	CredentialPhaseCreated = "CREATED"
+	// CredentialPhasePending is reported by AWS credentials waiting for
+	// the IAM role TMC assumes to be set.
+	CredentialPhasePending = "PENDING"
+	CredentialPhaseValid   = "VALID"
+	CredentialPhaseInvalid = "INVALID"
+	CredentialPhaseError   = "ERROR"
+)
+
+// Keys of the key value data of data protection credentials, which Velero
+// reads from its cloud credentials.
+const (
+	CredentialKeyAWSAccessKeyID      = "aws_access_key_id"
+	CredentialKeyAWSSecretAccessKey  = "aws_secret_access_key"
+	CredentialKeyAzureSubscriptionID = "AZURE_SUBSCRIPTION_ID"
+	CredentialKeyAzureTenantID       = "AZURE_TENANT_ID"
+	CredentialKeyAzureClientID       = "AZURE_CLIENT_ID"
+	CredentialKeyAzureClientSecret   = "AZURE_CLIENT_SECRET"
+	CredentialKeyAzureResourceGroup  = "AZURE_RESOURCE_GROUP"
+)
+
+type CredentialIAMRole struct {
+	ARN string `json:"arn,omitempty"`
+	// ExtID is the external ID TMC passes when it assumes the role,
+	// generated when the credential is created.
+	ExtID string `json:"extId,omitempty"`
+}
+
+type AWSCredential struct {
+	AccountID string             `json:"accountId,omitempty"`
+	IAMRole   *CredentialIAMRole `json:"iamRole,omitempty"`
+}
+
+type AzureServicePrincipal struct {
+	SubscriptionID string `json:"subscriptionId"`
+	TenantID       string `json:"tenantId"`
+	ResourceGroup  string `json:"resourceGroup,omitempty"`
+	ClientID       string `json:"clientId"`
+	// ClientSecret is never returned by TMC.
+	ClientSecret   string `json:"clientSecret,omitempty"`
+	AzureCloudName string `json:"azureCloudName,omitempty"`
+}
+
+type AzureCredential struct {
+	ServicePrincipal *AzureServicePrincipal `json:"servicePrincipal,omitempty"`
+}
+
+// KeyValueCredential holds secrets as key value pairs, which TMC never
+// returns.
+type KeyValueCredential struct {
+	Data map[string]string `json:"data,omitempty"`
+}
+
+type CredentialData struct {
+	AWSCredential   *AWSCredential      `json:"awsCredential,omitempty"`
+	AzureCredential *AzureCredential    `json:"azureCredential,omitempty"`
+	KeyValue        *KeyValueCredential `json:"keyValue,omitempty"`
+}
+
+type CredentialMeta struct {
+	Provider string `json:"provider"`
+}
+
+type CredentialSpec struct {
+	Meta       *CredentialMeta `json:"meta"`
+	Capability string          `json:"capability"`
+	Data       *CredentialData `json:"data"`
+}
+
+type CredentialStatus struct {
+	Phase      string               `json:"phase,omitempty"`
+	Conditions map[string]Condition `json:"conditions,omitempty"`
+	// CloudFormationTemplate creates the IAM role assumed with an AWS
+	// credential, in the AWS account the credential gives access to.
+	CloudFormationTemplate string `json:"cloudFormationTemplate,omitempty"`
+}
+
+type Credential struct {
+	FullName *FullName         `json:"fullName"`
+	Meta     *MetaData         `json:"meta"`
+	Spec     *CredentialSpec   `json:"spec"`
+	Status   *CredentialStatus `json:"status"`
+}
+
+type CredentialJSONObject struct {
+	Credential Credential `json:"credential"`
+}
+
+type AllCredentials struct {
+	Credentials []Credential `json:"credentials"`
+	pageInfo
+}
+
+func (a *AllCredentials) pageLength() int {
+	return len(a.Credentials)
+}
+
+func credentialsURL(baseURL string) string {
+	return fmt.Sprintf("%s/v1alpha1/account/credentials", baseURL)
+}
+
+func (c *Client) GetCredential(ctx context.Context, name string) (*Credential, error) {
+	requestURL := fmt.Sprintf("%s/%s", credentialsURL(c.baseURL), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := CredentialJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Credential, nil
+}
+
+// GetAllCredentials returns the credentials of the organization matching the
+// query.
+func (c *Client) GetAllCredentials(ctx context.Context, query Query) ([]Credential, error) {
+	params := url.Values{}
+	params.Set("query", query.String())
+
+	credentials := []Credential{}
+
+	pages := c.newPager(credentialsURL(c.baseURL), params)
+	for pages.HasNext() {
+		res := AllCredentials{}
+
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		credentials = append(credentials, res.Credentials...)
+	}
+
+	return credentials, nil
+}
+
+func (c *Client) CreateCredential(ctx context.Context, name string, description string, spec *CredentialSpec) (*Credential, error) {
+	return c.sendCredential(ctx, "POST", credentialsURL(c.baseURL), name, description, spec)
+}
+
+func (c *Client) UpdateCredential(ctx context.Context, name string, description string, spec *CredentialSpec) (*Credential, error) {
+	requestURL := fmt.Sprintf("%s/%s", credentialsURL(c.baseURL), url.PathEscape(name))
+
+	return c.sendCredential(ctx, "PUT", requestURL, name, description, spec)
+}
+
+func (c *Client) sendCredential(ctx context.Context, method string, requestURL string, name string, description string, spec *CredentialSpec) (*Credential, error) {
+	newCredentialObject := CredentialJSONObject{
+		Credential: Credential{
+			FullName: &FullName{
+				Name: name,
+			},
+			Meta: &MetaData{
+				Description: description,
+			},
+			Spec: spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newCredentialObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := CredentialJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Credential, nil
+}
+
+func (c *Client) DeleteCredential(ctx context.Context, name string) error {
+	requestURL := fmt.Sprintf("%s/%s", credentialsURL(c.baseURL), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := CredentialJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,573 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Phases of the data protection of a cluster and of backup locations.
+const (
+	DataProtectionPhasePending  = "PENDING"
+	DataProtectionPhaseCreating = "CREATING"
+	DataProtectionPhaseReady    = "READY"
+	DataProtectionPhaseError    = "ERROR"
+	DataProtectionPhaseDeleting = "DELETING"
+)
+
+// Providers of the object storage backups are kept in.
+const (
+	BackupTargetProviderAWS   = "AWS"
+	BackupTargetProviderAzure = "AZURE"
+)
+
+// backupLocationProvider is the data protection provider managing the
+// backup locations, which is always TMC itself.
+const backupLocationProvider = "tmc"
+
+// DataProtectionFullName identifies the data protection of a cluster, which
+// has no name of its own.
+type DataProtectionFullName struct {
+	OrgID                 string `json:"orgId,omitempty"`
+	ManagementClusterName string `json:"managementClusterName"`
+	ProvisionerName       string `json:"provisionerName"`
+	ClusterName           string `json:"clusterName"`
+}
+
+type DataProtectionSpec struct {
+	// EnableCsiSnapshots backs up volumes with CSI snapshots rather than
+	// with Restic.
+	EnableCsiSnapshots bool `json:"enableCsiSnapshots,omitempty"`
+}
+
+type DataProtectionStatus struct {
+	// Phase of the installation of Velero on the cluster
+	Phase string `json:"phase,omitempty"`
+	// Conditions of the data protection, keyed by their type
+	Conditions map[string]Condition `json:"conditions,omitempty"`
+}
+
+type DataProtection struct {
+	FullName *DataProtectionFullName `json:"fullName"`
+	Meta     *MetaData               `json:"meta"`
+	Spec     *DataProtectionSpec     `json:"spec"`
+	Status   *DataProtectionStatus   `json:"status"`
+}
+
+type DataProtectionJSONObject struct {
+	DataProtection DataProtection `json:"dataProtection"`
+}
+
+type AllDataProtections struct {
+	DataProtections []DataProtection `json:"dataProtections"`
+}
+
+func dataProtectionURL(baseURL string, clusterName string) string {
+	return fmt.Sprintf("%s/v1alpha1/clusters/%s/dataprotection", baseURL, url.PathEscape(clusterName))
+}
+
+// GetDataProtection returns the data protection of a cluster, or an error
+// for which IsNotFound is true when it is not enabled.
+func (c *Client) GetDataProtection(ctx context.Context, clusterName string, managementClusterName string, provisionerName string) (*DataProtection, error) {
+	requestURL := fmt.Sprintf("%s?%s", dataProtectionURL(c.baseURL, clusterName), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := AllDataProtections{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	// TMC lists the data protection of the cluster, there is one at most.
+	if len(res.DataProtections) == 0 {
+		return nil, &APIError{
+			StatusCode: http.StatusNotFound,
+			Code:       grpcCodeNotFound,
+			Message:    fmt.Sprintf("data protection is not enabled on cluster %s", clusterName),
+		}
+	}
+
+	return &res.DataProtections[0], nil
+}
+
+// EnableDataProtection installs Velero on a cluster so it can be backed up.
+func (c *Client) EnableDataProtection(ctx context.Context, clusterName string, managementClusterName string, provisionerName string, spec *DataProtectionSpec) (*DataProtection, error) {
+	newDataProtectionObject := DataProtectionJSONObject{
+		DataProtection: DataProtection{
+			FullName: &DataProtectionFullName{
+				ManagementClusterName: managementClusterName,
+				ProvisionerName:       provisionerName,
+				ClusterName:           clusterName,
+			},
+			Spec: spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newDataProtectionObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", dataProtectionURL(c.baseURL, clusterName), bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := DataProtectionJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.DataProtection, nil
+}
+
+// DisableDataProtection removes Velero from a cluster. The backups of the
+// cluster are only deleted from their location when deleteBackups is set.
+func (c *Client) DisableDataProtection(ctx context.Context, clusterName string, managementClusterName string, provisionerName string, deleteBackups bool) error {
+	params := clusterQuery(managementClusterName, provisionerName)
+	params.Set("deleteBackups", strconv.FormatBool(deleteBackups))
+
+	requestURL := fmt.Sprintf("%s?%s", dataProtectionURL(c.baseURL, clusterName), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := DataProtectionJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BackupLocationFullName identifies a backup location of the organization.
+type BackupLocationFullName struct {
+	OrgID        string `json:"orgId,omitempty"`
+	ProviderName string `json:"providerName"`
+	Name         string `json:"name"`
+}
+
+// CredentialReference names the credential of the organization used to
+// access an account, like the object storage of a backup location.
+type CredentialReference struct {
+	Name string `json:"name"`
+}
+
+// BackupLocationAssignedGroup is a cluster group whose clusters can use a
+// backup location.
+type BackupLocationAssignedGroup struct {
+	ClusterGroup *BackupLocationClusterGroup `json:"clustergroup"`
+}
+
+type BackupLocationClusterGroup struct {
+	Name string `json:"name"`
+}
+
+type BackupLocationAWSConfig struct {
+	// S3ForcePathStyle addresses the bucket in the path of the URL, which
+	// S3 compatible storage like MinIO expects.
+	S3ForcePathStyle bool   `json:"s3ForcePathStyle,omitempty"`
+	S3URL            string `json:"s3Url,omitempty"`
+	PublicURL        string `json:"publicUrl,omitempty"`
+}
+
+type BackupLocationAzureConfig struct {
+	ResourceGroup  string `json:"resourceGroup"`
+	StorageAccount string `json:"storageAccount"`
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+}
+
+type BackupLocationConfig struct {
+	AWSConfig   *BackupLocationAWSConfig   `json:"awsConfig,omitempty"`
+	AzureConfig *BackupLocationAzureConfig `json:"azureConfig,omitempty"`
+}
+
+type BackupLocationSpec struct {
+	TargetProvider string                        `json:"targetProvider"`
+	Credential     *CredentialReference          `json:"credential"`
+	Bucket         string                        `json:"bucket"`
+	Region         string                        `json:"region,omitempty"`
+	AssignedGroups []BackupLocationAssignedGroup `json:"assignedGroups,omitempty"`
+	Config         *BackupLocationConfig         `json:"config,omitempty"`
+}
+
+type BackupLocationStatus struct {
+	Phase      string               `json:"phase,omitempty"`
+	Conditions map[string]Condition `json:"conditions,omitempty"`
+}
+
+type BackupLocation struct {
+	FullName *BackupLocationFullName `json:"fullName"`
+	Meta     *MetaData               `json:"meta"`
+	Spec     *BackupLocationSpec     `json:"spec"`
+	Status   *BackupLocationStatus   `json:"status"`
+}
+
+type BackupLocationJSONObject struct {
+	BackupLocation BackupLocation `json:"backupLocation"`
+}
+
+func backupLocationsURL(baseURL string) string {
+	return fmt.Sprintf("%s/v1alpha1/dataprotection/providers/%s/backuplocations", baseURL, backupLocationProvider)
+}
+
+func (c *Client) GetBackupLocation(ctx context.Context, name string) (*BackupLocation, error) {
+	requestURL := fmt.Sprintf("%s/%s", backupLocationsURL(c.baseURL), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := BackupLocationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.BackupLocation, nil
+}
+
+func (c *Client) CreateBackupLocation(ctx context.Context, name string, description string, spec *BackupLocationSpec) (*BackupLocation, error) {
+	return c.sendBackupLocation(ctx, "POST", backupLocationsURL(c.baseURL), name, description, spec)
+}
+
+func (c *Client) UpdateBackupLocation(ctx context.Context, name string, description string, spec *BackupLocationSpec) (*BackupLocation, error) {
+	requestURL := fmt.Sprintf("%s/%s", backupLocationsURL(c.baseURL), url.PathEscape(name))
+
+	return c.sendBackupLocation(ctx, "PUT", requestURL, name, description, spec)
+}
+
+func (c *Client) sendBackupLocation(ctx context.Context, method string, requestURL string, name string, description string, spec *BackupLocationSpec) (*BackupLocation, error) {
+	newBackupLocationObject := BackupLocationJSONObject{
+		BackupLocation: BackupLocation{
+			FullName: &BackupLocationFullName{
+				ProviderName: backupLocationProvider,
+				Name:         name,
+			},
+			Meta: &MetaData{
+				Description: description,
+			},
+			Spec: spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newBackupLocationObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := BackupLocationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.BackupLocation, nil
+}
+
+func (c *Client) DeleteBackupLocation(ctx context.Context, name string) error {
+	requestURL := fmt.Sprintf("%s/%s", backupLocationsURL(c.baseURL), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := BackupLocationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BackupTemplate describes what a backup holds, either for a single backup
+// or for every backup taken by a schedule.
+type BackupTemplate struct {
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+	// BackupTTL is how long backups are kept, as a Go duration like 720h0m0s
+	BackupTTL       string `json:"backupTtl,omitempty"`
+	SnapshotVolumes *bool  `json:"snapshotVolumes,omitempty"`
+	StorageLocation string `json:"storageLocation,omitempty"`
+}
+
+type BackupScheduleRate struct {
+	// Rate is the cron expression backups are taken on.
+	Rate string `json:"rate"`
+}
+
+type BackupScheduleSpec struct {
+	Schedule *BackupScheduleRate `json:"schedule"`
+	Template *BackupTemplate     `json:"template"`
+	Paused   bool                `json:"paused,omitempty"`
+}
+
+type BackupScheduleStatus struct {
+	Phase string `json:"phase,omitempty"`
+}
+
+type BackupSchedule struct {
+	FullName *ClusterScopedFullName `json:"fullName"`
+	Meta     *MetaData              `json:"meta"`
+	Spec     *BackupScheduleSpec    `json:"spec"`
+	Status   *BackupScheduleStatus  `json:"status"`
+}
+
+type BackupScheduleJSONObject struct {
+	Schedule BackupSchedule `json:"schedule"`
+}
+
+func backupSchedulesURL(baseURL string, clusterName string) string {
+	return fmt.Sprintf("%s/schedules", dataProtectionURL(baseURL, clusterName))
+}
+
+func (c *Client) GetBackupSchedule(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) (*BackupSchedule, error) {
+	requestURL := fmt.Sprintf("%s/%s?%s", backupSchedulesURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := BackupScheduleJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Schedule, nil
+}
+
+func (c *Client) CreateBackupSchedule(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string, spec *BackupScheduleSpec) (*BackupSchedule, error) {
+	return c.sendBackupSchedule(ctx, "POST", backupSchedulesURL(c.baseURL, clusterName), name, clusterName, managementClusterName, provisionerName, spec)
+}
+
+func (c *Client) UpdateBackupSchedule(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string, spec *BackupScheduleSpec) (*BackupSchedule, error) {
+	requestURL := fmt.Sprintf("%s/%s", backupSchedulesURL(c.baseURL, clusterName), url.PathEscape(name))
+
+	return c.sendBackupSchedule(ctx, "PUT", requestURL, name, clusterName, managementClusterName, provisionerName, spec)
+}
+
+func (c *Client) sendBackupSchedule(ctx context.Context, method string, requestURL string, name string, clusterName string, managementClusterName string, provisionerName string, spec *BackupScheduleSpec) (*BackupSchedule, error) {
+	newBackupScheduleObject := BackupScheduleJSONObject{
+		Schedule: BackupSchedule{
+			FullName: newClusterScopedFullName(name, clusterName, managementClusterName, provisionerName),
+			Spec:     spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newBackupScheduleObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := BackupScheduleJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Schedule, nil
+}
+
+func (c *Client) DeleteBackupSchedule(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) error {
+	requestURL := fmt.Sprintf("%s/%s?%s", backupSchedulesURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := BackupScheduleJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type BackupStatus struct {
+	// Phase of the backup, like InProgress, Completed or Failed
+	Phase               string `json:"phase,omitempty"`
+	StartTimestamp      string `json:"startTimestamp,omitempty"`
+	CompletionTimestamp string `json:"completionTimestamp,omitempty"`
+	Expiration          string `json:"expiration,omitempty"`
+	Errors              int    `json:"errors,omitempty"`
+	Warnings            int    `json:"warnings,omitempty"`
+}
+
+type Backup struct {
+	FullName *ClusterScopedFullName `json:"fullName"`
+	Meta     *MetaData              `json:"meta"`
+	Spec     *BackupTemplate        `json:"spec"`
+	Status   *BackupStatus          `json:"status"`
+}
+
+type AllBackups struct {
+	Backups []Backup `json:"backups"`
+	pageInfo
+}
+
+func (a *AllBackups) pageLength() int {
+	return len(a.Backups)
+}
+
+func backupsURL(baseURL string, clusterName string) string {
+	return fmt.Sprintf("%s/backups", dataProtectionURL(baseURL, clusterName))
+}
+
+func (c *Client) GetAllBackups(ctx context.Context, clusterName string, managementClusterName string, provisionerName string, query Query) ([]Backup, error) {
+	params := clusterQuery(managementClusterName, provisionerName)
+	params.Set("query", query.String())
+
+	backups := []Backup{}
+
+	pages := c.newPager(backupsURL(c.baseURL, clusterName), params)
+	for pages.HasNext() {
+		res := AllBackups{}
+
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		backups = append(backups, res.Backups...)
+	}
+
+	return backups, nil
+}
+
+// Phases of a restore, as reported by Velero.
+const (
+	RestorePhaseNew              = "New"
+	RestorePhaseInProgress       = "InProgress"
+	RestorePhaseCompleted        = "Completed"
+	RestorePhasePartiallyFailed  = "PartiallyFailed"
+	RestorePhaseFailed           = "Failed"
+	RestorePhaseFailedValidation = "FailedValidation"
+	// RestorePhaseDeleting is reported by TMC rather than Velero, while the
+	// record of the restore is being deleted.
+	RestorePhaseDeleting = "DELETING"
+)
+
+type RestoreSpec struct {
+	BackupName         string   `json:"backupName"`
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+	IncludedResources  []string `json:"includedResources,omitempty"`
+	ExcludedResources  []string `json:"excludedResources,omitempty"`
+}
+
+type RestoreStatus struct {
+	Phase               string `json:"phase,omitempty"`
+	StartTimestamp      string `json:"startTimestamp,omitempty"`
+	CompletionTimestamp string `json:"completionTimestamp,omitempty"`
+	// FailureReason explains why a restore failed or did not pass validation
+	FailureReason string `json:"failureReason,omitempty"`
+	Errors        int    `json:"errors,omitempty"`
+	Warnings      int    `json:"warnings,omitempty"`
+}
+
+type Restore struct {
+	FullName *ClusterScopedFullName `json:"fullName"`
+	Meta     *MetaData              `json:"meta"`
+	Spec     *RestoreSpec           `json:"spec"`
+	Status   *RestoreStatus         `json:"status"`
+}
+
+type RestoreJSONObject struct {
+	Restore Restore `json:"restore"`
+}
+
+func restoresURL(baseURL string, clusterName string) string {
+	return fmt.Sprintf("%s/restores", dataProtectionURL(baseURL, clusterName))
+}
+
+func (c *Client) GetRestore(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) (*Restore, error) {
+	requestURL := fmt.Sprintf("%s/%s?%s", restoresURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := RestoreJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Restore, nil
+}
+
+// CreateRestore starts restoring a backup onto the cluster it was taken of.
+func (c *Client) CreateRestore(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string, spec *RestoreSpec) (*Restore, error) {
+	newRestoreObject := RestoreJSONObject{
+		Restore: Restore{
+			FullName: newClusterScopedFullName(name, clusterName, managementClusterName, provisionerName),
+			Spec:     spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newRestoreObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", restoresURL(c.baseURL, clusterName), bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := RestoreJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Restore, nil
+}
+
+// DeleteRestore deletes the record of a restore, the restored objects are
+// left in the cluster.
+func (c *Client) DeleteRestore(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) error {
+	requestURL := fmt.Sprintf("%s/%s?%s", restoresURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := RestoreJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package tanzuclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// gRPC status codes which TMC reports alongside the HTTP status of a failed
+// request.
+const (
+	grpcCodeNotFound         = 5
+	grpcCodeAlreadyExists    = 6
+	grpcCodePermissionDenied = 7
+)
+
+// APIError is returned by the client when TMC answers a request with an
+// unsuccessful status code.
+type APIError struct {
+	// HTTP status code of the response
+	StatusCode int
+	// Error code reported by TMC in the response body
+	Code int
+	// Error message reported by TMC in the response body
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("unknown error, status code: %d", e.StatusCode)
+	}
+
+	return e.Message
+}
+
+// IsNotFound reports whether err is an APIError for an object which does
+// not exist.
+func IsNotFound(err error) bool {
+	return isAPIError(err, http.StatusNotFound, grpcCodeNotFound)
+}
+
+// IsConflict reports whether err is an APIError for an object which already
+// exists or was changed concurrently.
+func IsConflict(err error) bool {
+	return isAPIError(err, http.StatusConflict, grpcCodeAlreadyExists)
+}
+
+// IsForbidden reports whether err is an APIError for a request the caller is
+// not allowed to make.
+func IsForbidden(err error) bool {
+	return isAPIError(err, http.StatusForbidden, grpcCodePermissionDenied)
+}
+
+func isAPIError(err error, statusCode int, code int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == statusCode || apiErr.Code == code
+}
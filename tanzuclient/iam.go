@@ -0,0 +1,170 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Kinds of the subjects of role bindings.
+const (
+	SubjectKindUser  = "USER"
+	SubjectKindGroup = "GROUP"
+)
+
+// Operations of the deltas applied to access policies.
+const (
+	BindingDeltaAdd    = "OP_ADD"
+	BindingDeltaDelete = "OP_DELETE"
+)
+
+type Subject struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// RoleBinding grants a TMC role, like workspace.edit, to users and groups.
+type RoleBinding struct {
+	Role     string    `json:"role"`
+	Subjects []Subject `json:"subjects"`
+}
+
+// IAMPolicy is the access policy of an object, which holds the role bindings
+// set on the object itself.
+type IAMPolicy struct {
+	Meta         *MetaData     `json:"meta,omitempty"`
+	RoleBindings []RoleBinding `json:"roleBindings"`
+}
+
+// BindingDelta adds or removes a single subject from the binding of a role.
+type BindingDelta struct {
+	Op      string  `json:"op"`
+	Role    string  `json:"role"`
+	Subject Subject `json:"subject"`
+}
+
+// IAMScope identifies the object an access policy is attached to.
+type IAMScope struct {
+	path  string
+	query url.Values
+}
+
+func OrganizationIAMScope() IAMScope {
+	return IAMScope{path: "/v1alpha1/organization"}
+}
+
+func ClusterGroupIAMScope(name string) IAMScope {
+	return IAMScope{path: fmt.Sprintf("/v1alpha1/clustergroups/%s", url.PathEscape(name))}
+}
+
+func WorkspaceIAMScope(name string) IAMScope {
+	return IAMScope{path: fmt.Sprintf("/v1alpha1/workspaces/%s", url.PathEscape(name))}
+}
+
+func ClusterIAMScope(managementClusterName string, provisionerName string, name string) IAMScope {
+	return IAMScope{
+		path:  fmt.Sprintf("/v1alpha1/clusters/%s", url.PathEscape(name)),
+		query: clusterQuery(managementClusterName, provisionerName),
+	}
+}
+
+func NamespaceIAMScope(managementClusterName string, provisionerName string, clusterName string, name string) IAMScope {
+	return IAMScope{
+		path:  fmt.Sprintf("/v1alpha1/clusters/%s/namespaces/%s", url.PathEscape(clusterName), url.PathEscape(name)),
+		query: clusterQuery(managementClusterName, provisionerName),
+	}
+}
+
+func (s IAMScope) url(baseURL string) string {
+	requestURL := fmt.Sprintf("%s%s:iam", baseURL, s.path)
+	if len(s.query) > 0 {
+		requestURL += "?" + s.query.Encode()
+	}
+
+	return requestURL
+}
+
+type iamPolicyList struct {
+	// The policies of the object and of its ancestors, the object's own first
+	PolicyList []IAMPolicy `json:"policyList"`
+}
+
+type iamPolicyObject struct {
+	Policy IAMPolicy `json:"policy"`
+}
+
+// GetIAMPolicy returns the access policy set on the object of the scope,
+// without the role bindings it inherits from its ancestors.
+func (c *Client) GetIAMPolicy(ctx context.Context, scope IAMScope) (*IAMPolicy, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", scope.url(c.baseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := iamPolicyList{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	if len(res.PolicyList) == 0 {
+		return &IAMPolicy{}, nil
+	}
+
+	return &res.PolicyList[0], nil
+}
+
+// SetIAMPolicy replaces all the role bindings set on the object of the
+// scope.
+func (c *Client) SetIAMPolicy(ctx context.Context, scope IAMScope, roleBindings []RoleBinding) (*IAMPolicy, error) {
+	policyObject := iamPolicyObject{
+		Policy: IAMPolicy{
+			RoleBindings: roleBindings,
+		},
+	}
+
+	json_data, err := json.Marshal(policyObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", scope.url(c.baseURL), bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := iamPolicyObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Policy, nil
+}
+
+// UpdateIAMPolicy applies the deltas to the role bindings set on the object
+// of the scope, leaving the other bindings alone.
+func (c *Client) UpdateIAMPolicy(ctx context.Context, scope IAMScope, deltas []BindingDelta) (*IAMPolicy, error) {
+	json_data, err := json.Marshal(map[string]interface{}{
+		"bindingDeltaList": deltas,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", scope.url(c.baseURL), bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := iamPolicyObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Policy, nil
+}
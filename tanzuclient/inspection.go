@@ -0,0 +1,200 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Types of inspections.
+const (
+	InspectionTypeCIS         = "cis"
+	InspectionTypeConformance = "conformance"
+	InspectionTypeLite        = "lite"
+)
+
+// Phases of an inspection.
+const (
+	InspectionPhasePending  = "PENDING"
+	InspectionPhaseRunning  = "RUNNING"
+	InspectionPhaseComplete = "COMPLETE"
+	InspectionPhaseError    = "ERROR"
+	InspectionPhaseDeleting = "DELETING"
+)
+
+// InspectionOptions are the options of an inspection type, none of which are
+// supported yet.
+type InspectionOptions struct{}
+
+// InspectionSpec selects the type of an inspection, only one of its fields is
+// set.
+type InspectionSpec struct {
+	// CISSpec checks the cluster against the CIS Kubernetes benchmark
+	CISSpec *InspectionOptions `json:"cisSpec,omitempty"`
+	// ConformanceSpec runs the Kubernetes conformance tests
+	ConformanceSpec *InspectionOptions `json:"conformanceSpec,omitempty"`
+	// LiteSpec runs a quick subset of the conformance tests
+	LiteSpec *InspectionOptions `json:"liteSpec,omitempty"`
+}
+
+// NewInspectionSpec returns the spec of an inspection of the given type.
+func NewInspectionSpec(inspectionType string) *InspectionSpec {
+	spec := &InspectionSpec{}
+
+	switch inspectionType {
+	case InspectionTypeCIS:
+		spec.CISSpec = &InspectionOptions{}
+	case InspectionTypeConformance:
+		spec.ConformanceSpec = &InspectionOptions{}
+	case InspectionTypeLite:
+		spec.LiteSpec = &InspectionOptions{}
+	}
+
+	return spec
+}
+
+// Type returns the type of the inspection, or an empty string if it is not
+// known.
+func (s *InspectionSpec) Type() string {
+	switch {
+	case s == nil:
+		return ""
+	case s.CISSpec != nil:
+		return InspectionTypeCIS
+	case s.ConformanceSpec != nil:
+		return InspectionTypeConformance
+	case s.LiteSpec != nil:
+		return InspectionTypeLite
+	}
+
+	return ""
+}
+
+// InspectionReport sums up the checks of a completed inspection.
+type InspectionReport struct {
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+	Warned int `json:"warned"`
+}
+
+type InspectionStatus struct {
+	Phase string `json:"phase,omitempty"`
+	// PhaseInfo explains why an inspection failed
+	PhaseInfo string            `json:"phaseInfo,omitempty"`
+	Report    *InspectionReport `json:"report,omitempty"`
+	// TarballDownloadURL is where the detailed results of a completed
+	// inspection are downloaded from
+	TarballDownloadURL string `json:"tarballDownloadUrl,omitempty"`
+}
+
+type Inspection struct {
+	FullName *ClusterScopedFullName `json:"fullName"`
+	Meta     *MetaData              `json:"meta"`
+	Spec     *InspectionSpec        `json:"spec"`
+	Status   *InspectionStatus      `json:"status"`
+}
+
+type InspectionJSONObject struct {
+	Scan Inspection `json:"scan"`
+}
+
+type AllInspections struct {
+	Scans []Inspection `json:"scans"`
+	pageInfo
+}
+
+func (a *AllInspections) pageLength() int {
+	return len(a.Scans)
+}
+
+func inspectionsURL(baseURL string, clusterName string) string {
+	return fmt.Sprintf("%s/v1alpha1/clusters/%s/inspection/scans", baseURL, url.PathEscape(clusterName))
+}
+
+func (c *Client) GetInspection(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) (*Inspection, error) {
+	requestURL := fmt.Sprintf("%s/%s?%s", inspectionsURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := InspectionJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Scan, nil
+}
+
+// GetAllInspections returns the inspections of a cluster matching the query,
+// including the completed ones.
+func (c *Client) GetAllInspections(ctx context.Context, clusterName string, managementClusterName string, provisionerName string, query Query) ([]Inspection, error) {
+	params := clusterQuery(managementClusterName, provisionerName)
+	params.Set("query", query.String())
+
+	inspections := []Inspection{}
+
+	pages := c.newPager(inspectionsURL(c.baseURL, clusterName), params)
+	for pages.HasNext() {
+		res := AllInspections{}
+
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		inspections = append(inspections, res.Scans...)
+	}
+
+	return inspections, nil
+}
+
+// CreateInspection launches an inspection of a cluster.
+func (c *Client) CreateInspection(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string, spec *InspectionSpec) (*Inspection, error) {
+	newInspectionObject := InspectionJSONObject{
+		Scan: Inspection{
+			FullName: newClusterScopedFullName(name, clusterName, managementClusterName, provisionerName),
+			Spec:     spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newInspectionObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", inspectionsURL(c.baseURL, clusterName), bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := InspectionJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Scan, nil
+}
+
+// DeleteInspection deletes an inspection along with its results.
+func (c *Client) DeleteInspection(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) error {
+	requestURL := fmt.Sprintf("%s/%s?%s", inspectionsURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := InspectionJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
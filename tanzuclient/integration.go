@@ -0,0 +1,136 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Names of the integrations TMC can enable on a cluster.
+const (
+	IntegrationTanzuObservability = "tanzu-observability-saas"
+	IntegrationTanzuServiceMesh   = "tanzu-service-mesh"
+)
+
+// Phases of the integrations of a cluster.
+const (
+	IntegrationPhaseCreating = "CREATING"
+	IntegrationPhaseUpdating = "UPDATING"
+	IntegrationPhaseReady    = "READY"
+	IntegrationPhaseError    = "ERROR"
+	IntegrationPhaseDeleting = "DELETING"
+)
+
+// Health of an integration, as reported by its agent on the cluster.
+const (
+	IntegrationHealthHealthy   = "HEALTHY"
+	IntegrationHealthUnhealthy = "UNHEALTHY"
+)
+
+type IntegrationSpec struct {
+	// Configurations are the settings of the integration, which depend on
+	// the integration.
+	Configurations map[string]interface{} `json:"configurations,omitempty"`
+	// Secrets, like the API token of Tanzu Observability, are never
+	// returned by TMC.
+	Secrets map[string]string `json:"secrets,omitempty"`
+}
+
+type IntegrationStatus struct {
+	Phase      string               `json:"phase,omitempty"`
+	Health     string               `json:"health,omitempty"`
+	Conditions map[string]Condition `json:"conditions,omitempty"`
+}
+
+type Integration struct {
+	FullName *ClusterScopedFullName `json:"fullName"`
+	Meta     *MetaData              `json:"meta"`
+	Spec     *IntegrationSpec       `json:"spec"`
+	Status   *IntegrationStatus     `json:"status"`
+}
+
+type IntegrationJSONObject struct {
+	Integration Integration `json:"integration"`
+}
+
+func integrationsURL(baseURL string, clusterName string) string {
+	return fmt.Sprintf("%s/v1alpha1/clusters/%s/integrations", baseURL, url.PathEscape(clusterName))
+}
+
+func (c *Client) GetIntegration(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) (*Integration, error) {
+	requestURL := fmt.Sprintf("%s/%s?%s", integrationsURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := IntegrationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Integration, nil
+}
+
+// EnableIntegration enables an integration on a cluster, installing its
+// agent.
+func (c *Client) EnableIntegration(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string, spec *IntegrationSpec) (*Integration, error) {
+	return c.sendIntegration(ctx, "POST", integrationsURL(c.baseURL, clusterName), name, clusterName, managementClusterName, provisionerName, spec)
+}
+
+func (c *Client) UpdateIntegration(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string, spec *IntegrationSpec) (*Integration, error) {
+	requestURL := fmt.Sprintf("%s/%s", integrationsURL(c.baseURL, clusterName), url.PathEscape(name))
+
+	return c.sendIntegration(ctx, "PUT", requestURL, name, clusterName, managementClusterName, provisionerName, spec)
+}
+
+func (c *Client) sendIntegration(ctx context.Context, method string, requestURL string, name string, clusterName string, managementClusterName string, provisionerName string, spec *IntegrationSpec) (*Integration, error) {
+	newIntegrationObject := IntegrationJSONObject{
+		Integration: Integration{
+			FullName: newClusterScopedFullName(name, clusterName, managementClusterName, provisionerName),
+			Spec:     spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newIntegrationObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := IntegrationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Integration, nil
+}
+
+// DisableIntegration disables an integration of a cluster, removing its
+// agent.
+func (c *Client) DisableIntegration(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) error {
+	requestURL := fmt.Sprintf("%s/%s?%s", integrationsURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := IntegrationJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
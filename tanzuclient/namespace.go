@@ -0,0 +1,142 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type NamespaceSpec struct {
+	WorkspaceName string `json:"workspaceName"`
+	// Attach is set to take over a namespace which already exists in the
+	// cluster instead of creating it.
+	Attach bool `json:"attach,omitempty"`
+}
+
+type NamespaceStatus struct {
+	Phase string `json:"phase,omitempty"`
+}
+
+type Namespace struct {
+	FullName *ClusterScopedFullName `json:"fullName"`
+	Meta     *MetaData              `json:"meta"`
+	Spec     *NamespaceSpec         `json:"spec"`
+	Status   *NamespaceStatus       `json:"status,omitempty"`
+}
+
+type NamespaceJSONObject struct {
+	Namespace Namespace `json:"namespace"`
+}
+
+type AllNamespaces struct {
+	Namespaces []Namespace `json:"namespaces"`
+	pageInfo
+}
+
+func (a *AllNamespaces) pageLength() int {
+	return len(a.Namespaces)
+}
+
+func namespacesURL(baseURL string, clusterName string) string {
+	return fmt.Sprintf("%s/v1alpha1/clusters/%s/namespaces", baseURL, url.PathEscape(clusterName))
+}
+
+func (c *Client) GetNamespace(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) (*Namespace, error) {
+	requestURL := fmt.Sprintf("%s/%s?%s", namespacesURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NamespaceJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Namespace, nil
+}
+
+func (c *Client) GetAllNamespaces(ctx context.Context, clusterName string, managementClusterName string, provisionerName string, query Query) ([]Namespace, error) {
+	params := clusterQuery(managementClusterName, provisionerName)
+	params.Set("query", query.String())
+
+	namespaces := []Namespace{}
+
+	pages := c.newPager(namespacesURL(c.baseURL, clusterName), params)
+	for pages.HasNext() {
+		res := AllNamespaces{}
+
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		namespaces = append(namespaces, res.Namespaces...)
+	}
+
+	return namespaces, nil
+}
+
+func (c *Client) CreateNamespace(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string, description string, spec *NamespaceSpec, labels map[string]interface{}) (*Namespace, error) {
+	requestURL := namespacesURL(c.baseURL, clusterName)
+
+	return c.sendNamespace(ctx, "POST", requestURL, name, clusterName, managementClusterName, provisionerName, description, spec, labels)
+}
+
+func (c *Client) UpdateNamespace(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string, description string, spec *NamespaceSpec, labels map[string]interface{}) (*Namespace, error) {
+	requestURL := fmt.Sprintf("%s/%s", namespacesURL(c.baseURL, clusterName), url.PathEscape(name))
+
+	return c.sendNamespace(ctx, "PUT", requestURL, name, clusterName, managementClusterName, provisionerName, description, spec, labels)
+}
+
+func (c *Client) sendNamespace(ctx context.Context, method string, requestURL string, name string, clusterName string, managementClusterName string, provisionerName string, description string, spec *NamespaceSpec, labels map[string]interface{}) (*Namespace, error) {
+	newNamespaceObject := NamespaceJSONObject{
+		Namespace: Namespace{
+			FullName: newClusterScopedFullName(name, clusterName, managementClusterName, provisionerName),
+			Meta: &MetaData{
+				Description: description,
+				Labels:      labels,
+			},
+			Spec: spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newNamespaceObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := NamespaceJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Namespace, nil
+}
+
+func (c *Client) DeleteNamespace(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) error {
+	requestURL := fmt.Sprintf("%s/%s?%s", namespacesURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := NamespaceJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
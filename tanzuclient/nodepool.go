@@ -0,0 +1,161 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Taint is a Kubernetes taint applied to every node of a node pool.
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// NodePoolAutoscaling bounds the number of worker nodes the cluster
+// autoscaler can scale a node pool to.
+type NodePoolAutoscaling struct {
+	Enabled  bool   `json:"enabled"`
+	MinCount string `json:"minCount,omitempty"`
+	MaxCount string `json:"maxCount,omitempty"`
+}
+
+type NodePoolSpec struct {
+	WorkerNodeCount string               `json:"workerNodeCount"`
+	CloudLabels     map[string]string    `json:"cloudLabels,omitempty"`
+	NodeLabels      map[string]string    `json:"nodeLabels,omitempty"`
+	Taints          []Taint              `json:"taints,omitempty"`
+	Autoscaling     *NodePoolAutoscaling `json:"autoscaling,omitempty"`
+	// Only the node spec of the cluster's infrastructure is set
+	TkgAws            *AWSNodeSpec     `json:"tkgAws,omitempty"`
+	TkgVsphere        *VsphereNodeSpec `json:"tkgVsphere,omitempty"`
+	TkgServiceVsphere *TKGSNodeSpec    `json:"tkgServiceVsphere,omitempty"`
+}
+
+type NodePoolStatus struct {
+	// Lifecycle phase of the node pool
+	Phase string `json:"phase,omitempty"`
+	// Conditions of the node pool, keyed by their type
+	Conditions map[string]Condition `json:"conditions,omitempty"`
+}
+
+type NodePool struct {
+	FullName *ClusterScopedFullName `json:"fullName"`
+	Meta     *MetaData              `json:"meta"`
+	Spec     *NodePoolSpec          `json:"spec"`
+	Status   *NodePoolStatus        `json:"status"`
+}
+
+type NodePoolJSONObject struct {
+	NodePool NodePool `json:"nodepool"`
+}
+
+type AllNodePools struct {
+	NodePools []NodePool `json:"nodepools"`
+	pageInfo
+}
+
+func (a *AllNodePools) pageLength() int {
+	return len(a.NodePools)
+}
+
+func nodePoolsURL(baseURL string, clusterName string) string {
+	return fmt.Sprintf("%s/v1alpha1/clusters/%s/nodepools", baseURL, url.PathEscape(clusterName))
+}
+
+func (c *Client) GetNodePool(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) (*NodePool, error) {
+	requestURL := fmt.Sprintf("%s/%s?%s", nodePoolsURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NodePoolJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.NodePool, nil
+}
+
+func (c *Client) GetAllNodePools(ctx context.Context, clusterName string, managementClusterName string, provisionerName string) ([]NodePool, error) {
+	nodePools := []NodePool{}
+
+	pages := c.newPager(nodePoolsURL(c.baseURL, clusterName), clusterQuery(managementClusterName, provisionerName))
+	for pages.HasNext() {
+		res := AllNodePools{}
+
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		nodePools = append(nodePools, res.NodePools...)
+	}
+
+	return nodePools, nil
+}
+
+func (c *Client) CreateNodePool(ctx context.Context, name string, description string, clusterName string, managementClusterName string, provisionerName string, spec *NodePoolSpec) (*NodePool, error) {
+	requestURL := nodePoolsURL(c.baseURL, clusterName)
+
+	return c.sendNodePool(ctx, "POST", requestURL, name, description, clusterName, managementClusterName, provisionerName, spec)
+}
+
+func (c *Client) UpdateNodePool(ctx context.Context, name string, description string, clusterName string, managementClusterName string, provisionerName string, spec *NodePoolSpec) (*NodePool, error) {
+	requestURL := fmt.Sprintf("%s/%s", nodePoolsURL(c.baseURL, clusterName), url.PathEscape(name))
+
+	return c.sendNodePool(ctx, "PUT", requestURL, name, description, clusterName, managementClusterName, provisionerName, spec)
+}
+
+func (c *Client) sendNodePool(ctx context.Context, method string, requestURL string, name string, description string, clusterName string, managementClusterName string, provisionerName string, spec *NodePoolSpec) (*NodePool, error) {
+	newNodePoolObject := NodePoolJSONObject{
+		NodePool: NodePool{
+			FullName: newClusterScopedFullName(name, clusterName, managementClusterName, provisionerName),
+			Meta: &MetaData{
+				Description: description,
+			},
+			Spec: spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newNodePoolObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := NodePoolJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.NodePool, nil
+}
+
+func (c *Client) DeleteNodePool(ctx context.Context, name string, clusterName string, managementClusterName string, provisionerName string) error {
+	requestURL := fmt.Sprintf("%s/%s?%s", nodePoolsURL(c.baseURL, clusterName), url.PathEscape(name), clusterQuery(managementClusterName, provisionerName).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := NodePoolJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package tanzuclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageSize is the number of objects requested per page when walking
+// through a list endpoint.
+const defaultPageSize = 100
+
+// listPage is implemented by the responses of TMC list endpoints.
+type listPage interface {
+	// pageLength returns the number of objects contained in the page.
+	pageLength() int
+	// totalCount returns the number of objects matching the request across
+	// all pages, or -1 when the API did not report it.
+	totalCount() int
+}
+
+// pageInfo holds the pagination details TMC adds to every list response.
+type pageInfo struct {
+	TotalCount json.Number `json:"totalCount,omitempty"`
+}
+
+func (p pageInfo) totalCount() int {
+	total, err := strconv.Atoi(p.TotalCount.String())
+	if err != nil {
+		return -1
+	}
+
+	return total
+}
+
+// pager walks through all the pages of a TMC list endpoint using the
+// pagination.offset and pagination.size query parameters.
+type pager struct {
+	client   *Client
+	url      string
+	query    url.Values
+	pageSize int
+	offset   int
+	done     bool
+}
+
+func (c *Client) newPager(requestURL string, query url.Values) *pager {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	return &pager{
+		client:   c,
+		url:      requestURL,
+		query:    query,
+		pageSize: defaultPageSize,
+	}
+}
+
+// HasNext reports whether there are pages left to fetch.
+func (p *pager) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page into page.
+func (p *pager) Next(ctx context.Context, page listPage) error {
+	query := url.Values{}
+	for k, v := range p.query {
+		query[k] = v
+	}
+	query.Set("pagination.offset", strconv.Itoa(p.offset))
+	query.Set("pagination.size", strconv.Itoa(p.pageSize))
+	query.Set("includeTotalCount", "true")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.sendRequest(req, page); err != nil {
+		return err
+	}
+
+	length := page.pageLength()
+	p.offset += length
+
+	// Stop on an empty page even if more objects were announced, as the
+	// collection may have shrunk while it was being listed.
+	if total := page.totalCount(); total >= 0 {
+		p.done = length == 0 || p.offset >= total
+	} else {
+		p.done = length < p.pageSize
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Types of the policies built on the recipes of TMC.
+const (
+	PolicyTypeSecurity = "security-policy"
+	PolicyTypeImage    = "image-policy"
+	PolicyTypeNetwork  = "network-policy"
+	PolicyTypeQuota    = "namespace-quota-policy"
+)
+
+// PolicyFullName identifies a policy along with the object it is attached
+// to, only the fields of the scope of the policy are set.
+type PolicyFullName struct {
+	OrgID                 string `json:"orgId,omitempty"`
+	Name                  string `json:"name"`
+	ClusterGroupName      string `json:"clusterGroupName,omitempty"`
+	WorkspaceName         string `json:"workspaceName,omitempty"`
+	ManagementClusterName string `json:"managementClusterName,omitempty"`
+	ProvisionerName       string `json:"provisionerName,omitempty"`
+	ClusterName           string `json:"clusterName,omitempty"`
+	NamespaceName         string `json:"namespaceName,omitempty"`
+}
+
+type LabelSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// NamespaceSelector restricts the namespaces a policy applies to.
+type NamespaceSelector struct {
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions"`
+}
+
+type PolicySpec struct {
+	Type          string `json:"type"`
+	Recipe        string `json:"recipe"`
+	RecipeVersion string `json:"recipeVersion,omitempty"`
+	// Input holds the settings of the recipe, their shape depends on the
+	// recipe.
+	Input             map[string]interface{} `json:"input,omitempty"`
+	NamespaceSelector *NamespaceSelector     `json:"namespaceSelector,omitempty"`
+}
+
+type Policy struct {
+	FullName *PolicyFullName `json:"fullName"`
+	Meta     *MetaData       `json:"meta"`
+	Spec     *PolicySpec     `json:"spec"`
+}
+
+type PolicyJSONObject struct {
+	Policy Policy `json:"policy"`
+}
+
+// PolicyScope identifies the object policies are attached to.
+type PolicyScope struct {
+	path     string
+	query    url.Values
+	fullName PolicyFullName
+}
+
+func ClusterGroupPolicyScope(name string) PolicyScope {
+	return PolicyScope{
+		path:     fmt.Sprintf("/v1alpha1/clustergroups/%s", url.PathEscape(name)),
+		fullName: PolicyFullName{ClusterGroupName: name},
+	}
+}
+
+func WorkspacePolicyScope(name string) PolicyScope {
+	return PolicyScope{
+		path:     fmt.Sprintf("/v1alpha1/workspaces/%s", url.PathEscape(name)),
+		fullName: PolicyFullName{WorkspaceName: name},
+	}
+}
+
+func ClusterPolicyScope(managementClusterName string, provisionerName string, name string) PolicyScope {
+	return PolicyScope{
+		path:  fmt.Sprintf("/v1alpha1/clusters/%s", url.PathEscape(name)),
+		query: clusterQuery(managementClusterName, provisionerName),
+		fullName: PolicyFullName{
+			ManagementClusterName: managementClusterName,
+			ProvisionerName:       provisionerName,
+			ClusterName:           name,
+		},
+	}
+}
+
+func NamespacePolicyScope(managementClusterName string, provisionerName string, clusterName string, name string) PolicyScope {
+	return PolicyScope{
+		path:  fmt.Sprintf("/v1alpha1/clusters/%s/namespaces/%s", url.PathEscape(clusterName), url.PathEscape(name)),
+		query: clusterQuery(managementClusterName, provisionerName),
+		fullName: PolicyFullName{
+			ManagementClusterName: managementClusterName,
+			ProvisionerName:       provisionerName,
+			ClusterName:           clusterName,
+			NamespaceName:         name,
+		},
+	}
+}
+
+// url returns the URL of the policies of the scope, or of one of them when
+// its name is given.
+func (s PolicyScope) url(baseURL string, name string) string {
+	requestURL := fmt.Sprintf("%s%s/policies", baseURL, s.path)
+	if name != "" {
+		requestURL += "/" + url.PathEscape(name)
+	}
+	if len(s.query) > 0 {
+		requestURL += "?" + s.query.Encode()
+	}
+
+	return requestURL
+}
+
+func (c *Client) GetPolicy(ctx context.Context, scope PolicyScope, name string) (*Policy, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", scope.url(c.baseURL, name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := PolicyJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Policy, nil
+}
+
+func (c *Client) CreatePolicy(ctx context.Context, scope PolicyScope, name string, description string, spec *PolicySpec) (*Policy, error) {
+	return c.sendPolicy(ctx, "POST", scope.url(c.baseURL, ""), scope, name, description, spec)
+}
+
+func (c *Client) UpdatePolicy(ctx context.Context, scope PolicyScope, name string, description string, spec *PolicySpec) (*Policy, error) {
+	return c.sendPolicy(ctx, "PUT", scope.url(c.baseURL, name), scope, name, description, spec)
+}
+
+func (c *Client) sendPolicy(ctx context.Context, method string, requestURL string, scope PolicyScope, name string, description string, spec *PolicySpec) (*Policy, error) {
+	fullName := scope.fullName
+	fullName.Name = name
+
+	newPolicyObject := PolicyJSONObject{
+		Policy: Policy{
+			FullName: &fullName,
+			Meta: &MetaData{
+				Description: description,
+			},
+			Spec: spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newPolicyObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := PolicyJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Policy, nil
+}
+
+func (c *Client) DeletePolicy(ctx context.Context, scope PolicyScope, name string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", scope.url(c.baseURL, name), nil)
+	if err != nil {
+		return err
+	}
+
+	res := PolicyJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
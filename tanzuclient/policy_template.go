@@ -0,0 +1,171 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// PolicyTypeCustom is the type of the policies built on policy templates,
+// their recipe is the name of the template.
+const PolicyTypeCustom = "custom-policy"
+
+// Kinds of the templates of custom policies.
+const (
+	PolicyTemplateObjectType = "ConstraintTemplate"
+	PolicyTemplateType       = "OPAGatekeeper"
+)
+
+// ConstraintTarget is the target of Gatekeeper checking the admission
+// requests of Kubernetes.
+const ConstraintTarget = "admission.k8s.gatekeeper.sh"
+
+// ConstraintTemplate is the OPA Gatekeeper object defining the Rego source of
+// a policy template and the parameters of the policies built on it.
+type ConstraintTemplate struct {
+	APIVersion string                     `json:"apiVersion"`
+	Kind       string                     `json:"kind"`
+	Metadata   ConstraintTemplateMetadata `json:"metadata"`
+	Spec       ConstraintTemplateSpec     `json:"spec"`
+}
+
+type ConstraintTemplateMetadata struct {
+	Name string `json:"name"`
+}
+
+type ConstraintTemplateSpec struct {
+	CRD     ConstraintTemplateCRD      `json:"crd"`
+	Targets []ConstraintTemplateTarget `json:"targets"`
+}
+
+type ConstraintTemplateCRD struct {
+	Spec ConstraintTemplateCRDSpec `json:"spec"`
+}
+
+type ConstraintTemplateCRDSpec struct {
+	Names      ConstraintTemplateNames       `json:"names"`
+	Validation *ConstraintTemplateValidation `json:"validation,omitempty"`
+}
+
+type ConstraintTemplateNames struct {
+	Kind string `json:"kind"`
+}
+
+type ConstraintTemplateValidation struct {
+	// OpenAPIV3Schema is the schema of the parameters of the policies.
+	OpenAPIV3Schema json.RawMessage `json:"openAPIV3Schema,omitempty"`
+}
+
+type ConstraintTemplateTarget struct {
+	Target string `json:"target"`
+	Rego   string `json:"rego"`
+}
+
+// PolicyTemplateDataInventory is a kind of Kubernetes objects synced to OPA,
+// so the Rego source can look them up.
+type PolicyTemplateDataInventory struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+type PolicyTemplateSpec struct {
+	// Object is the template, as a serialized ConstraintTemplate.
+	Object        string                        `json:"object"`
+	ObjectType    string                        `json:"objectType"`
+	TemplateType  string                        `json:"templateType"`
+	DataInventory []PolicyTemplateDataInventory `json:"dataInventory,omitempty"`
+}
+
+type PolicyTemplate struct {
+	FullName *FullName           `json:"fullName"`
+	Meta     *MetaData           `json:"meta"`
+	Spec     *PolicyTemplateSpec `json:"spec"`
+}
+
+type PolicyTemplateJSONObject struct {
+	Template PolicyTemplate `json:"template"`
+}
+
+func policyTemplatesURL(baseURL string) string {
+	return fmt.Sprintf("%s/v1alpha1/policy/templates", baseURL)
+}
+
+func (c *Client) GetPolicyTemplate(ctx context.Context, name string) (*PolicyTemplate, error) {
+	requestURL := fmt.Sprintf("%s/%s", policyTemplatesURL(c.baseURL), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := PolicyTemplateJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Template, nil
+}
+
+func (c *Client) CreatePolicyTemplate(ctx context.Context, name string, description string, spec *PolicyTemplateSpec) (*PolicyTemplate, error) {
+	return c.sendPolicyTemplate(ctx, "POST", policyTemplatesURL(c.baseURL), name, description, spec)
+}
+
+func (c *Client) UpdatePolicyTemplate(ctx context.Context, name string, description string, spec *PolicyTemplateSpec) (*PolicyTemplate, error) {
+	requestURL := fmt.Sprintf("%s/%s", policyTemplatesURL(c.baseURL), url.PathEscape(name))
+
+	return c.sendPolicyTemplate(ctx, "PUT", requestURL, name, description, spec)
+}
+
+func (c *Client) sendPolicyTemplate(ctx context.Context, method string, requestURL string, name string, description string, spec *PolicyTemplateSpec) (*PolicyTemplate, error) {
+	newPolicyTemplateObject := PolicyTemplateJSONObject{
+		Template: PolicyTemplate{
+			FullName: &FullName{
+				Name: name,
+			},
+			Meta: &MetaData{
+				Description: description,
+			},
+			Spec: spec,
+		},
+	}
+
+	json_data, err := json.Marshal(newPolicyTemplateObject)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(json_data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := PolicyTemplateJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Template, nil
+}
+
+func (c *Client) DeletePolicyTemplate(ctx context.Context, name string) error {
+	requestURL := fmt.Sprintf("%s/%s", policyTemplatesURL(c.baseURL), url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res := PolicyTemplateJSONObject{}
+
+	if err := c.sendRequest(req, &res); err != nil {
+		return err
+	}
+
+	return nil
+}
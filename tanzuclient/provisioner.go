@@ -2,9 +2,11 @@ package tanzuclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type Provisioner struct {
@@ -20,12 +22,17 @@ type ProvisionerResponse struct {
 
 type AllProvisioners struct {
 	Provisioners []Provisioner `json:"provisioners"`
+	pageInfo
 }
 
-func (c *Client) GetProvisioner(mgmtClusterName, name string) (*Provisioner, error) {
-	tmcURL := fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners/%s", c.baseURL, mgmtClusterName, name)
+func (a *AllProvisioners) pageLength() int {
+	return len(a.Provisioners)
+}
+
+func (c *Client) GetProvisioner(ctx context.Context, mgmtClusterName, name string) (*Provisioner, error) {
+	tmcURL := fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners/%s", c.baseURL, url.PathEscape(mgmtClusterName), url.PathEscape(name))
 
-	req, err := http.NewRequest("GET", tmcURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", tmcURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -39,27 +46,30 @@ func (c *Client) GetProvisioner(mgmtClusterName, name string) (*Provisioner, err
 	return &res.Provisioner, nil
 }
 
-func (c *Client) GetAllProvisioners(mgmtClusterName string, labels map[string]interface{}) ([]Provisioner, error) {
-	queryString := buildLabelQuery(labels)
+func (c *Client) GetAllProvisioners(ctx context.Context, mgmtClusterName string, query Query) ([]Provisioner, error) {
+	tmcURL := fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners", c.baseURL, url.PathEscape(mgmtClusterName))
 
-	tmcURL := fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners?query=%s", c.baseURL, mgmtClusterName, queryString)
+	params := url.Values{}
+	params.Set("query", query.String())
 
-	req, err := http.NewRequest("GET", tmcURL, nil)
-	if err != nil {
-		return nil, err
-	}
+	provisioners := []Provisioner{}
 
-	res := AllProvisioners{}
+	pages := c.newPager(tmcURL, params)
+	for pages.HasNext() {
+		res := AllProvisioners{}
 
-	if err := c.sendRequest(req, &res); err != nil {
-		return nil, err
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		provisioners = append(provisioners, res.Provisioners...)
 	}
 
-	return res.Provisioners, nil
+	return provisioners, nil
 }
 
-func (c *Client) CreateProvisioner(mgmtClusterName string, name string, description string, labels map[string]interface{}) (*Provisioner, error) {
-	tmcURL := fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners", c.baseURL, mgmtClusterName)
+func (c *Client) CreateProvisioner(ctx context.Context, mgmtClusterName string, name string, description string, labels map[string]interface{}) (*Provisioner, error) {
+	tmcURL := fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners", c.baseURL, url.PathEscape(mgmtClusterName))
 
 	provisioner := &Provisioner{
 		FullName: &FullName{
@@ -81,7 +91,7 @@ func (c *Client) CreateProvisioner(mgmtClusterName string, name string, descript
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", tmcURL, bytes.NewBuffer(json_data))
+	req, err := http.NewRequestWithContext(ctx, "POST", tmcURL, bytes.NewBuffer(json_data))
 	if err != nil {
 		return nil, err
 	}
@@ -95,10 +105,10 @@ func (c *Client) CreateProvisioner(mgmtClusterName string, name string, descript
 	return &res.Provisioner, nil
 }
 
-func (c *Client) DeleteProvisioner(mgmtClusterName, name string) error {
-	tmcURL := fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners/%s", c.baseURL, mgmtClusterName, name)
+func (c *Client) DeleteProvisioner(ctx context.Context, mgmtClusterName, name string) error {
+	tmcURL := fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners/%s", c.baseURL, url.PathEscape(mgmtClusterName), url.PathEscape(name))
 
-	req, err := http.NewRequest("DELETE", tmcURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", tmcURL, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,127 @@
+package tanzuclient
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Query is a search query understood by the TMC list endpoints, passed to
+// them through the query parameter. Queries are built from terms which can
+// be combined with And, Or and Not.
+type Query struct {
+	expr string
+}
+
+// queryReserved holds the characters with a meaning in the query syntax,
+// which need escaping when they appear in field names or patterns.
+const queryReserved = `+-&|!(){}[]^"~*?:\/ `
+
+// String returns the query in the syntax expected by TMC.
+func (q Query) String() string {
+	return q.expr
+}
+
+// IsEmpty reports whether the query matches everything.
+func (q Query) IsEmpty() bool {
+	return q.expr == ""
+}
+
+// LabelField returns the name of the field holding the label key.
+func LabelField(key string) string {
+	return "meta.labels." + key
+}
+
+// Term matches objects whose field is exactly value.
+func Term(field, value string) Query {
+	return Query{expr: fmt.Sprintf("%s:%s", escapeReserved(field), quoteValue(value))}
+}
+
+// Wildcard matches objects whose field matches pattern, where "*" matches
+// any sequence of characters. Everything else in pattern is matched
+// literally.
+func Wildcard(field, pattern string) Query {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = escapeReserved(part)
+	}
+
+	return Query{expr: fmt.Sprintf("%s:%s", escapeReserved(field), strings.Join(parts, "*"))}
+}
+
+// Exists matches objects which have a value for field.
+func Exists(field string) Query {
+	return Query{expr: fmt.Sprintf("%s:*", escapeReserved(field))}
+}
+
+// Not matches objects which are not matched by q.
+func Not(q Query) Query {
+	if q.IsEmpty() {
+		return q
+	}
+
+	return Query{expr: fmt.Sprintf("not (%s)", q.expr)}
+}
+
+// And matches objects which are matched by all the queries.
+func And(queries ...Query) Query {
+	return join(" and ", queries)
+}
+
+// Or matches objects which are matched by any of the queries.
+func Or(queries ...Query) Query {
+	return join(" or ", queries)
+}
+
+// LabelsQuery matches objects carrying all the given labels.
+func LabelsQuery(labels map[string]interface{}) Query {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	terms := make([]Query, 0, len(keys))
+	for _, k := range keys {
+		terms = append(terms, Term(LabelField(k), fmt.Sprint(labels[k])))
+	}
+
+	return And(terms...)
+}
+
+func join(operator string, queries []Query) Query {
+	var exprs []string
+	for _, q := range queries {
+		if !q.IsEmpty() {
+			exprs = append(exprs, q.expr)
+		}
+	}
+
+	switch len(exprs) {
+	case 0:
+		return Query{}
+	case 1:
+		return Query{expr: exprs[0]}
+	}
+
+	return Query{expr: "(" + strings.Join(exprs, operator) + ")"}
+}
+
+func escapeReserved(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(queryReserved, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func quoteValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+
+	return `"` + value + `"`
+}
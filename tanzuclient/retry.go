@@ -0,0 +1,150 @@
+package tanzuclient
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts made for a request unless
+	// the client is configured otherwise.
+	DefaultMaxAttempts = 5
+	// DefaultMaxRetryWait is the longest delay between two attempts unless
+	// the client is configured otherwise.
+	DefaultMaxRetryWait = 30 * time.Second
+
+	retryBaseWait = time.Second
+)
+
+// sendWithRetry sends the request, retrying transient failures with a
+// jittered exponential backoff. Idempotent requests are retried on transport
+// errors and on 429, 502, 503 and 504 responses. Other requests are only
+// retried when the connection could not be established at all, as the API
+// may otherwise already have acted on them.
+func (c *Client) sendWithRetry(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := c.send(req)
+
+		if attempt >= c.MaxAttempts || !shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		wait, ok := c.retryWait(attempt, res)
+		if !ok {
+			return res, err
+		}
+
+		if res != nil {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+			log.Printf("[DEBUG] %s %s returned status %d, retrying in %s (attempt %d/%d)", req.Method, req.URL.Path, res.StatusCode, wait, attempt, c.MaxAttempts)
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (attempt %d/%d)", req.Method, req.URL.Path, err, wait, attempt, c.MaxAttempts)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		if isIdempotent(req.Method) {
+			return true
+		}
+
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+
+	if !isIdempotent(req.Method) {
+		return false
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// retryWait returns how long to wait before the next attempt. A Retry-After
+// header sent by the API takes precedence over the computed backoff; when it
+// asks for a longer delay than MaxRetryWait, no further attempt is made.
+func (c *Client) retryWait(attempt int, res *http.Response) (time.Duration, bool) {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return wait, wait <= c.MaxRetryWait
+		}
+	}
+
+	backoff := retryBaseWait << uint(attempt-1)
+	if backoff <= 0 || backoff > c.MaxRetryWait {
+		backoff = c.MaxRetryWait
+	}
+
+	// Wait somewhere between half and the full backoff so that parallel
+	// requests failing together do not all come back at the same time.
+	half := int64(backoff / 2)
+	if half <= 0 {
+		return backoff, true
+	}
+
+	return time.Duration(half + rand.Int63n(half+1)), true
+}
+
+// parseRetryAfter understands both forms of the Retry-After header, a number
+// of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
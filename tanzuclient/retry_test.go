@@ -0,0 +1,356 @@
+package tanzuclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testResponse is how the test server answers one attempt of a request.
+type testResponse struct {
+	status     int
+	retryAfter string
+	// drop closes the connection once the request was received, without
+	// answering it.
+	drop bool
+}
+
+func TestSendWithRetry(t *testing.T) {
+	testCases := []struct {
+		name         string
+		method       string
+		responses    []testResponse
+		dialFailures int32
+		requests     int32
+		status       int
+		transportErr bool
+	}{
+		{
+			name:      "success",
+			method:    http.MethodGet,
+			responses: []testResponse{{status: http.StatusOK}},
+			requests:  1,
+		},
+		{
+			name:      "service unavailable",
+			method:    http.MethodGet,
+			responses: []testResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			requests:  2,
+		},
+		{
+			name:      "too many requests with retry after",
+			method:    http.MethodGet,
+			responses: []testResponse{{status: http.StatusTooManyRequests, retryAfter: "0"}, {status: http.StatusOK}},
+			requests:  2,
+		},
+		{
+			name:      "too many requests without retry after",
+			method:    http.MethodDelete,
+			responses: []testResponse{{status: http.StatusTooManyRequests}, {status: http.StatusTooManyRequests}, {status: http.StatusOK}},
+			requests:  3,
+		},
+		{
+			name:      "retry after longer than the max wait",
+			method:    http.MethodGet,
+			responses: []testResponse{{status: http.StatusServiceUnavailable, retryAfter: "3600"}, {status: http.StatusOK}},
+			requests:  1,
+			status:    http.StatusServiceUnavailable,
+		},
+		{
+			name:      "attempts exhausted",
+			method:    http.MethodGet,
+			responses: []testResponse{{status: http.StatusBadGateway}},
+			requests:  3,
+			status:    http.StatusBadGateway,
+		},
+		{
+			name:      "client error",
+			method:    http.MethodGet,
+			responses: []testResponse{{status: http.StatusBadRequest}, {status: http.StatusOK}},
+			requests:  1,
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "connection dropped",
+			method:    http.MethodGet,
+			responses: []testResponse{{drop: true}, {status: http.StatusOK}},
+			requests:  2,
+		},
+		{
+			name:      "body rewound",
+			method:    http.MethodPut,
+			responses: []testResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusGatewayTimeout}, {status: http.StatusOK}},
+			requests:  3,
+		},
+		{
+			name:      "post not replayed after a response",
+			method:    http.MethodPost,
+			responses: []testResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			requests:  1,
+			status:    http.StatusServiceUnavailable,
+		},
+		{
+			name:         "post not replayed after reaching the server",
+			method:       http.MethodPost,
+			responses:    []testResponse{{drop: true}, {status: http.StatusOK}},
+			requests:     1,
+			transportErr: true,
+		},
+		{
+			name:         "post retried when the connection failed",
+			method:       http.MethodPost,
+			responses:    []testResponse{{status: http.StatusOK}},
+			dialFailures: 2,
+			requests:     1,
+		},
+	}
+
+	const body = `{"clusterGroup": {"fullName": {"name": "tf-acc"}}}`
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var bodies []string
+
+			s := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+
+				mu.Lock()
+				bodies = append(bodies, string(b))
+				attempt := len(bodies)
+				mu.Unlock()
+
+				response := tc.responses[len(tc.responses)-1]
+				if attempt <= len(tc.responses) {
+					response = tc.responses[attempt-1]
+				}
+
+				if response.drop {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err != nil {
+						t.Errorf("cannot drop the connection: %s", err)
+						return
+					}
+					conn.Close()
+					return
+				}
+
+				if response.retryAfter != "" {
+					w.Header().Set("Retry-After", response.retryAfter)
+				}
+				w.WriteHeader(response.status)
+				w.Write([]byte(body))
+			})
+			client := newTestClient(t, s)
+			client.MaxAttempts = 3
+
+			// Every attempt gets a connection of its own, so that the
+			// transport does not retry dropped connections by itself.
+			var dials int32
+			dialer := &net.Dialer{}
+			client.http = &http.Client{
+				Transport: &http.Transport{
+					DisableKeepAlives: true,
+					DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+						if atomic.AddInt32(&dials, 1) <= tc.dialFailures {
+							return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+						}
+						return dialer.DialContext(ctx, network, addr)
+					},
+				},
+			}
+
+			var reqBody io.Reader
+			if tc.method == http.MethodPut || tc.method == http.MethodPost {
+				reqBody = bytes.NewBufferString(body)
+			}
+
+			req, err := http.NewRequest(tc.method, s.URL+"/v1alpha1/clustergroups", reqBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res := ClusterGroupJsonObject{}
+			err = client.sendRequest(req, &res)
+
+			switch {
+			case tc.transportErr:
+				if err == nil {
+					t.Errorf("expected a transport error")
+				} else if _, ok := err.(*APIError); ok {
+					t.Errorf("expected a transport error, got %s", err)
+				}
+			case tc.status != 0:
+				if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != tc.status {
+					t.Errorf("expected status %d, got %v", tc.status, err)
+				}
+			default:
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				} else if res.ClusterGroup.FullName == nil || res.ClusterGroup.FullName.Name != "tf-acc" {
+					t.Errorf("unexpected response %+v", res)
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if int32(len(bodies)) != tc.requests {
+				t.Errorf("expected the request to reach the server %d times, got %d", tc.requests, len(bodies))
+			}
+			if d := atomic.LoadInt32(&dials); d != tc.dialFailures+tc.requests {
+				t.Errorf("expected %d connection attempts, got %d", tc.dialFailures+tc.requests, d)
+			}
+			if reqBody != nil {
+				for i, b := range bodies {
+					if b != body {
+						t.Errorf("unexpected body of attempt %d: %q", i+1, b)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	testCases := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min        time.Duration
+		max        time.Duration
+		retry      bool
+	}{
+		{
+			name:    "first attempt",
+			attempt: 1,
+			min:     500 * time.Millisecond,
+			max:     time.Second,
+			retry:   true,
+		},
+		{
+			name:    "third attempt",
+			attempt: 3,
+			min:     2 * time.Second,
+			max:     4 * time.Second,
+			retry:   true,
+		},
+		{
+			name:    "backoff capped",
+			attempt: 10,
+			min:     15 * time.Second,
+			max:     30 * time.Second,
+			retry:   true,
+		},
+		{
+			name:    "backoff overflow",
+			attempt: 100,
+			min:     15 * time.Second,
+			max:     30 * time.Second,
+			retry:   true,
+		},
+		{
+			name:       "retry after seconds",
+			attempt:    1,
+			retryAfter: "20",
+			min:        20 * time.Second,
+			max:        20 * time.Second,
+			retry:      true,
+		},
+		{
+			name:       "retry after date",
+			attempt:    1,
+			retryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat),
+			min:        59 * time.Minute,
+			max:        time.Hour,
+		},
+		{
+			name:       "retry after past date",
+			attempt:    1,
+			retryAfter: "Mon, 02 Jan 2006 15:04:05 GMT",
+			retry:      true,
+		},
+		{
+			name:       "retry after too long",
+			attempt:    1,
+			retryAfter: "31",
+			min:        31 * time.Second,
+			max:        31 * time.Second,
+		},
+		{
+			name:       "invalid retry after",
+			attempt:    2,
+			retryAfter: "soon",
+			min:        time.Second,
+			max:        2 * time.Second,
+			retry:      true,
+		},
+	}
+
+	client := &Client{MaxRetryWait: 30 * time.Second}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tc.retryAfter != "" {
+				res.Header.Set("Retry-After", tc.retryAfter)
+			}
+
+			// The backoff is random, so look at enough draws to catch one out
+			// of bounds.
+			for i := 0; i < 100; i++ {
+				wait, retry := client.retryWait(tc.attempt, res)
+				if retry != tc.retry {
+					t.Fatalf("expected retry to be %t, got %t", tc.retry, retry)
+				}
+				if wait < tc.min || wait > tc.max {
+					t.Fatalf("expected a wait between %s and %s, got %s", tc.min, tc.max, wait)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryWaitJitter(t *testing.T) {
+	client := &Client{MaxRetryWait: 30 * time.Second}
+
+	waits := map[time.Duration]bool{}
+	for i := 0; i < 20; i++ {
+		wait, _ := client.retryWait(3, nil)
+		waits[wait] = true
+	}
+
+	if len(waits) < 2 {
+		t.Errorf("expected the backoff to be jittered, got the same wait 20 times: %v", waits)
+	}
+}
+
+func TestRewindRequest(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPut, "https://tmc.example.com/v1alpha1/clustergroups/tf-acc", strings.NewReader("body"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(req.Body)
+
+	retry, err := rewindRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadAll(retry.Body); string(b) != "body" {
+		t.Errorf("expected the body to be rewound, got %q", b)
+	}
+
+	req.GetBody = nil
+	if _, err := rewindRequest(req); err == nil {
+		t.Errorf("expected an error for a body which cannot be replayed")
+	}
+}
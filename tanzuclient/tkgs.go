@@ -0,0 +1,122 @@
+package tanzuclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// TanzuKubernetesRelease is a Kubernetes version vSphere with Tanzu clusters
+// of a provisioner can run.
+type TanzuKubernetesRelease struct {
+	FullName *FullNameProvisioned `json:"fullName"`
+	Spec     struct {
+		KubernetesVersion string `json:"kubernetesVersion"`
+		Version           string `json:"version"`
+	} `json:"spec"`
+	Status struct {
+		// Compatible is false for releases the supervisor cluster cannot
+		// deploy.
+		Compatible bool `json:"compatible"`
+	} `json:"status"`
+}
+
+type AllTanzuKubernetesReleases struct {
+	Releases []TanzuKubernetesRelease `json:"releases"`
+	pageInfo
+}
+
+func (a *AllTanzuKubernetesReleases) pageLength() int {
+	return len(a.Releases)
+}
+
+// VirtualMachineClass is a size of virtual machine available to the nodes of
+// vSphere with Tanzu clusters of a provisioner.
+type VirtualMachineClass struct {
+	FullName *FullNameProvisioned `json:"fullName"`
+	Spec     struct {
+		HardwareConfig struct {
+			CPUCores string `json:"cpuCores"`
+			Memory   string `json:"memory"`
+		} `json:"hardwareConfig"`
+	} `json:"spec"`
+}
+
+type AllVirtualMachineClasses struct {
+	VirtualMachineClasses []VirtualMachineClass `json:"virtualMachineClasses"`
+	pageInfo
+}
+
+func (a *AllVirtualMachineClasses) pageLength() int {
+	return len(a.VirtualMachineClasses)
+}
+
+// StorageClass is a storage class the volumes of vSphere with Tanzu clusters
+// of a provisioner can be provisioned from.
+type StorageClass struct {
+	FullName *FullNameProvisioned `json:"fullName"`
+}
+
+type AllStorageClasses struct {
+	StorageClasses []StorageClass `json:"storageClasses"`
+	pageInfo
+}
+
+func (a *AllStorageClasses) pageLength() int {
+	return len(a.StorageClasses)
+}
+
+func provisionerURL(baseURL, mgmtClusterName, provisionerName, collection string) string {
+	return fmt.Sprintf("%s/v1alpha1/managementclusters/%s/provisioners/%s/%s", baseURL, url.PathEscape(mgmtClusterName), url.PathEscape(provisionerName), collection)
+}
+
+func (c *Client) GetAllTanzuKubernetesReleases(ctx context.Context, mgmtClusterName, provisionerName string) ([]TanzuKubernetesRelease, error) {
+	releases := []TanzuKubernetesRelease{}
+
+	pages := c.newPager(provisionerURL(c.baseURL, mgmtClusterName, provisionerName, "tanzukubernetesreleases"), nil)
+	for pages.HasNext() {
+		res := AllTanzuKubernetesReleases{}
+
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		releases = append(releases, res.Releases...)
+	}
+
+	return releases, nil
+}
+
+func (c *Client) GetAllVirtualMachineClasses(ctx context.Context, mgmtClusterName, provisionerName string) ([]VirtualMachineClass, error) {
+	classes := []VirtualMachineClass{}
+
+	pages := c.newPager(provisionerURL(c.baseURL, mgmtClusterName, provisionerName, "virtualmachineclasses"), nil)
+	for pages.HasNext() {
+		res := AllVirtualMachineClasses{}
+
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		classes = append(classes, res.VirtualMachineClasses...)
+	}
+
+	return classes, nil
+}
+
+func (c *Client) GetAllStorageClasses(ctx context.Context, mgmtClusterName, provisionerName string) ([]StorageClass, error) {
+	classes := []StorageClass{}
+
+	pages := c.newPager(provisionerURL(c.baseURL, mgmtClusterName, provisionerName, "storageclasses"), nil)
+	for pages.HasNext() {
+		res := AllStorageClasses{}
+
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		classes = append(classes, res.StorageClasses...)
+	}
+
+	return classes, nil
+}
@@ -2,9 +2,11 @@ package tanzuclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type Workspace struct {
@@ -20,12 +22,17 @@ type WorkspaceResponse struct {
 
 type AllWorkspaces struct {
 	Workspaces []Workspace `json:"workspaces"`
+	pageInfo
 }
 
-func (c *Client) GetWorkspace(name string) (*Workspace, error) {
-	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces/%s", c.baseURL, name)
+func (a *AllWorkspaces) pageLength() int {
+	return len(a.Workspaces)
+}
+
+func (c *Client) GetWorkspace(ctx context.Context, name string) (*Workspace, error) {
+	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces/%s", c.baseURL, url.PathEscape(name))
 
-	req, err := http.NewRequest("GET", tmcURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", tmcURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -39,26 +46,29 @@ func (c *Client) GetWorkspace(name string) (*Workspace, error) {
 	return &res.Workspace, nil
 }
 
-func (c *Client) GetAllWorkspaces(labels map[string]interface{}) ([]Workspace, error) {
-	queryString := buildLabelQuery(labels)
+func (c *Client) GetAllWorkspaces(ctx context.Context, query Query) ([]Workspace, error) {
+	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces", c.baseURL)
 
-	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces?query=%s", c.baseURL, queryString)
+	params := url.Values{}
+	params.Set("query", query.String())
 
-	req, err := http.NewRequest("GET", tmcURL, nil)
-	if err != nil {
-		return nil, err
-	}
+	workspaces := []Workspace{}
 
-	res := AllWorkspaces{}
+	pages := c.newPager(tmcURL, params)
+	for pages.HasNext() {
+		res := AllWorkspaces{}
 
-	if err := c.sendRequest(req, &res); err != nil {
-		return nil, err
+		if err := pages.Next(ctx, &res); err != nil {
+			return nil, err
+		}
+
+		workspaces = append(workspaces, res.Workspaces...)
 	}
 
-	return res.Workspaces, nil
+	return workspaces, nil
 }
 
-func (c *Client) CreateWorkspace(name string, description string, labels map[string]interface{}) (*Workspace, error) {
+func (c *Client) CreateWorkspace(ctx context.Context, name string, description string, labels map[string]interface{}) (*Workspace, error) {
 	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces", c.baseURL)
 
 	workspace := &Workspace{
@@ -81,7 +91,7 @@ func (c *Client) CreateWorkspace(name string, description string, labels map[str
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", tmcURL, bytes.NewBuffer(json_data))
+	req, err := http.NewRequestWithContext(ctx, "POST", tmcURL, bytes.NewBuffer(json_data))
 	if err != nil {
 		return nil, err
 	}
@@ -95,10 +105,10 @@ func (c *Client) CreateWorkspace(name string, description string, labels map[str
 	return &res.Workspace, nil
 }
 
-func (c *Client) DeleteWorkspace(name string) error {
-	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces/%s", c.baseURL, name)
+func (c *Client) DeleteWorkspace(ctx context.Context, name string) error {
+	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces/%s", c.baseURL, url.PathEscape(name))
 
-	req, err := http.NewRequest("DELETE", tmcURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", tmcURL, nil)
 	if err != nil {
 		return err
 	}
@@ -112,8 +122,8 @@ func (c *Client) DeleteWorkspace(name string) error {
 	return nil
 }
 
-func (c *Client) UpdateWorkspace(name string, description string, labels map[string]interface{}) (*Workspace, error) {
-	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces/%s", c.baseURL, name)
+func (c *Client) UpdateWorkspace(ctx context.Context, name string, description string, labels map[string]interface{}) (*Workspace, error) {
+	tmcURL := fmt.Sprintf("%s/v1alpha1/workspaces/%s", c.baseURL, url.PathEscape(name))
 
 	workspace := &Workspace{
 		FullName: &FullName{
@@ -135,7 +145,7 @@ func (c *Client) UpdateWorkspace(name string, description string, labels map[str
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", tmcURL, bytes.NewBuffer(json_data))
+	req, err := http.NewRequestWithContext(ctx, "PUT", tmcURL, bytes.NewBuffer(json_data))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,47 @@
+package tmc
+
+import (
+	"fmt"
+	"net"
+)
+
+// validateClusterCIDRs checks that the CIDR blocks of the pods of a cluster
+// do not overlap with the ones of its services.
+func validateClusterCIDRs(pods, services []string) error {
+	for _, p := range pods {
+		podsBlock := parseCIDR(p)
+		if podsBlock == nil {
+			continue
+		}
+
+		for _, s := range services {
+			if servicesBlock := parseCIDR(s); servicesBlock != nil && overlapCIDR(podsBlock, servicesBlock) {
+				return fmt.Errorf("pods CIDR block %s overlaps with services CIDR block %s", p, s)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseCIDR returns the network of a CIDR block, or nil if it is empty or
+// invalid, in which case the validation of the attribute reports it.
+func parseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil
+	}
+	return network
+}
+
+func overlapCIDR(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// containsCIDR reports whether inner is entirely part of outer.
+func containsCIDR(outer, inner *net.IPNet) bool {
+	outerSize, _ := outer.Mask.Size()
+	innerSize, _ := inner.Mask.Size()
+
+	return outer.Contains(inner.IP) && innerSize >= outerSize
+}
@@ -0,0 +1,227 @@
+package tmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// clusterPollInterval is how often the phase of a cluster is checked while
+// waiting for it to settle.
+var clusterPollInterval = 15 * time.Second
+
+// clusterTransitionalPhases are the phases a cluster goes through before it
+// is ready to be used.
+var clusterTransitionalPhases = []string{
+	"",
+	tanzuclient.ClusterPhaseUnspecified,
+	tanzuclient.ClusterPhasePending,
+	tanzuclient.ClusterPhaseProcessing,
+	tanzuclient.ClusterPhaseCreating,
+	tanzuclient.ClusterPhaseAttachComplete,
+	tanzuclient.ClusterPhaseUpdating,
+	tanzuclient.ClusterPhaseUpgrading,
+}
+
+// waitForClusterReady polls the cluster until it is READY, and fails with
+// the message of the failing condition if it ends up in an error phase.
+func waitForClusterReady(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, name string, timeout time.Duration) (*tanzuclient.Cluster, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:      clusterTransitionalPhases,
+		Target:       []string{tanzuclient.ClusterPhaseReady},
+		Refresh:      clusterPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, name, false),
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	cluster, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for cluster %s to become ready: %w", name, err)
+	}
+
+	return cluster.(*tanzuclient.Cluster), nil
+}
+
+// waitForClusterHealthy polls the cluster until it is READY and its agent
+// reports it as HEALTHY, which is how attached clusters settle once the agent
+// has been installed.
+func waitForClusterHealthy(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, name string, timeout time.Duration) (*tanzuclient.Cluster, error) {
+	phaseRefresh := clusterPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, name, false)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: append([]string{tanzuclient.ClusterPhaseReady}, clusterTransitionalPhases...),
+		Target:  []string{tanzuclient.ClusterHealthHealthy},
+		Refresh: func() (interface{}, string, error) {
+			cluster, phase, err := phaseRefresh()
+			if err != nil || cluster == nil || phase != tanzuclient.ClusterPhaseReady {
+				return cluster, phase, err
+			}
+
+			// The agent may take a while to report once the cluster is ready
+			if health := cluster.(*tanzuclient.Cluster).Status.Health; health == tanzuclient.ClusterHealthHealthy {
+				return cluster, health, nil
+			}
+			return cluster, phase, nil
+		},
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	cluster, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for cluster %s to become healthy: %w", name, err)
+	}
+
+	return cluster.(*tanzuclient.Cluster), nil
+}
+
+// waitForClusterDeleted polls the cluster until TMC no longer knows about it.
+func waitForClusterDeleted(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, name string, timeout time.Duration) error {
+	if err := waitForDeletion(ctx, clusterPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, name, true), timeout); err != nil {
+		return fmt.Errorf("error waiting for cluster %s to be deleted: %w", name, err)
+	}
+
+	return nil
+}
+
+// deletionPending is the state of the objects waitForDeletion is waiting for,
+// as long as TMC still knows about them.
+const deletionPending = "DELETION_PENDING"
+
+// waitForDeletion polls an object until TMC no longer knows about it,
+// whatever it reports in the meantime: objects are deleted from their
+// transitional and error phases alike, and may not report a status yet. The
+// refresh function must not fail on the error phases of the object.
+func waitForDeletion(ctx context.Context, refresh resource.StateRefreshFunc, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{deletionPending},
+		Target:  []string{},
+		Refresh: func() (interface{}, string, error) {
+			object, _, err := refresh()
+			if err != nil || object == nil {
+				return nil, "", err
+			}
+			return object, deletionPending, nil
+		},
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// waitForNodePoolReady polls the node pool until it is READY, and fails
+// with the message of the failing condition if it ends up in an error phase.
+func waitForNodePoolReady(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, timeout time.Duration) (*tanzuclient.NodePool, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:      clusterTransitionalPhases,
+		Target:       []string{tanzuclient.ClusterPhaseReady},
+		Refresh:      nodePoolPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, name, false),
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	nodePool, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for node pool %s of cluster %s to become ready: %w", name, clusterName, err)
+	}
+
+	return nodePool.(*tanzuclient.NodePool), nil
+}
+
+// waitForNodePoolDeleted polls the node pool until TMC no longer knows about
+// it.
+func waitForNodePoolDeleted(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, timeout time.Duration) error {
+	if err := waitForDeletion(ctx, nodePoolPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, name, true), timeout); err != nil {
+		return fmt.Errorf("error waiting for node pool %s of cluster %s to be deleted: %w", name, clusterName, err)
+	}
+
+	return nil
+}
+
+// clusterPhaseRefreshFunc reports the phase of a cluster. The error phases
+// fail the wait, unless the cluster is being deleted.
+func clusterPhaseRefreshFunc(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, name string, deleting bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		cluster, err := client.GetCluster(ctx, name, managementCluster, provisionerName)
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if cluster.Status == nil {
+			return cluster, "", nil
+		}
+
+		switch cluster.Status.Phase {
+		case tanzuclient.ClusterPhaseError, tanzuclient.ClusterPhaseUpgradeFailed:
+			if !deleting {
+				return cluster, cluster.Status.Phase, statusError("cluster", cluster.Status.Phase, cluster.Status.Conditions)
+			}
+		}
+
+		return cluster, cluster.Status.Phase, nil
+	}
+}
+
+// nodePoolPhaseRefreshFunc reports the phase of a node pool, which goes
+// through the same phases as the cluster it belongs to. The error phases fail
+// the wait, unless the node pool is being deleted.
+func nodePoolPhaseRefreshFunc(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, deleting bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		nodePool, err := client.GetNodePool(ctx, name, clusterName, managementCluster, provisionerName)
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if nodePool.Status == nil {
+			return nodePool, "", nil
+		}
+
+		switch nodePool.Status.Phase {
+		case tanzuclient.ClusterPhaseError, tanzuclient.ClusterPhaseUpgradeFailed:
+			if !deleting {
+				return nodePool, nodePool.Status.Phase, statusError("node pool", nodePool.Status.Phase, nodePool.Status.Conditions)
+			}
+		}
+
+		return nodePool, nodePool.Status.Phase, nil
+	}
+}
+
+// statusError describes why an object, like a cluster, ended up in an error
+// phase, using the messages of its failing conditions.
+func statusError(object string, phase string, conditions map[string]tanzuclient.Condition) error {
+	types := make([]string, 0, len(conditions))
+	for t := range conditions {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	var messages []string
+	for _, t := range types {
+		condition := conditions[t]
+		if strings.EqualFold(condition.Status, "TRUE") || condition.Message == "" {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", t, condition.Message))
+	}
+
+	if len(messages) == 0 {
+		return fmt.Errorf("%s is in the %s phase", object, phase)
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
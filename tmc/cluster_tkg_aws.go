@@ -0,0 +1,552 @@
+package tmc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// tkgAwsSchema returns the schema of the tkg_aws block of clusters.
+func tkgAwsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Details of Cluster hosted on AWS",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"region": {
+					Type:        schema.TypeString,
+					Description: "Region of the AWS Cluster",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"version": {
+					Type:        schema.TypeString,
+					Description: "Kubernetes version of the AWS Cluster",
+					Required:    true,
+				},
+				"credential_name": {
+					Type:        schema.TypeString,
+					Description: "Provisioner credential used to create the cluster",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"availability_zones": {
+					Type:        schema.TypeList,
+					Description: "Availability zones of the control plane nodes, one for a single node or three for a highly available control plane",
+					Required:    true,
+					ForceNew:    true,
+					MinItems:    1,
+					MaxItems:    3,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"instance_type": {
+					Type:        schema.TypeString,
+					Description: "Instance type used to deploy the control plane node",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"high_availability": {
+					Type:        schema.TypeBool,
+					Description: "Whether to run three control plane nodes instead of one",
+					Optional:    true,
+					Default:     false,
+					ForceNew:    true,
+				},
+				"vpc_id": {
+					Type:          schema.TypeString,
+					Description:   "ID of an existing VPC to deploy the Cluster into",
+					Optional:      true,
+					Computed:      true,
+					ForceNew:      true,
+					ConflictsWith: []string{"tkg_aws.0.vpc_cidrblock"},
+				},
+				"vpc_cidrblock": {
+					Type:         schema.TypeString,
+					Description:  "CIDR block of the VPC created for the Cluster",
+					Optional:     true,
+					Computed:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IsCIDR,
+				},
+				"subnet": {
+					Type:        schema.TypeList,
+					Description: "Subnets of the Cluster's VPC. Subnets without an ID are created along with the Cluster",
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:        schema.TypeString,
+								Description: "ID of the subnet",
+								Optional:    true,
+								Computed:    true,
+								ForceNew:    true,
+							},
+							"availability_zone": {
+								Type:        schema.TypeString,
+								Description: "Availability zone of the subnet",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"cidr_block": {
+								Type:         schema.TypeString,
+								Description:  "CIDR block of the subnet",
+								Optional:     true,
+								Computed:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.IsCIDR,
+							},
+							"is_public": {
+								Type:        schema.TypeBool,
+								Description: "Whether the subnet is routed to an internet gateway",
+								Optional:    true,
+								ForceNew:    true,
+							},
+						},
+					},
+				},
+				"pods_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the pods in the cluster",
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsCIDR,
+					},
+				},
+				"services_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the services in the cluster",
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsCIDR,
+					},
+				},
+				"ssh_key": {
+					Type:        schema.TypeString,
+					Description: "Name of the SSH Keypair used in the AWS Cluster",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"node_pool": {
+					Type:        schema.TypeList,
+					Description: "Pools of worker nodes created along with the Cluster",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Description: "Name of the node pool",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"description": {
+								Type:        schema.TypeString,
+								Description: "Description of the node pool",
+								Optional:    true,
+							},
+							"worker_node_count": {
+								Type:         schema.TypeInt,
+								Description:  "Number of worker nodes in the pool",
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+							},
+							"instance_type": {
+								Type:        schema.TypeString,
+								Description: "Instance type used to deploy the worker nodes",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"availability_zone": {
+								Type:        schema.TypeString,
+								Description: "Availability zone of the worker nodes",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"node_labels": {
+								Type:        schema.TypeMap,
+								Description: "Kubernetes labels of the worker nodes",
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"cloud_labels": {
+								Type:        schema.TypeMap,
+								Description: "AWS tags of the worker node instances",
+								Optional:    true,
+								ForceNew:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// tkgAwsSchemaComputed returns the schema of the tkg_aws block of the cluster
+// data source.
+func tkgAwsSchemaComputed() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Details of Cluster hosted on AWS",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"region": {
+					Type:        schema.TypeString,
+					Description: "Region of the AWS Cluster",
+					Computed:    true,
+				},
+				"version": {
+					Type:        schema.TypeString,
+					Description: "Kubernetes version of the AWS Cluster",
+					Computed:    true,
+				},
+				"credential_name": {
+					Type:        schema.TypeString,
+					Description: "Provisioner credential used to create the cluster",
+					Computed:    true,
+				},
+				"availability_zones": {
+					Type:        schema.TypeList,
+					Description: "Availability zones of the control plane nodes",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"instance_type": {
+					Type:        schema.TypeString,
+					Description: "Instance type used to deploy the control plane node",
+					Computed:    true,
+				},
+				"high_availability": {
+					Type:        schema.TypeBool,
+					Description: "Whether the Cluster runs three control plane nodes instead of one",
+					Computed:    true,
+				},
+				"vpc_id": {
+					Type:        schema.TypeString,
+					Description: "ID of the Cluster's VPC",
+					Computed:    true,
+				},
+				"vpc_cidrblock": {
+					Type:        schema.TypeString,
+					Description: "CIDR block used by the Cluster's VPC",
+					Computed:    true,
+				},
+				"subnet": {
+					Type:        schema.TypeList,
+					Description: "Subnets of the Cluster's VPC",
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:        schema.TypeString,
+								Description: "ID of the subnet",
+								Computed:    true,
+							},
+							"availability_zone": {
+								Type:        schema.TypeString,
+								Description: "Availability zone of the subnet",
+								Computed:    true,
+							},
+							"cidr_block": {
+								Type:        schema.TypeString,
+								Description: "CIDR block of the subnet",
+								Computed:    true,
+							},
+							"is_public": {
+								Type:        schema.TypeBool,
+								Description: "Whether the subnet is routed to an internet gateway",
+								Computed:    true,
+							},
+						},
+					},
+				},
+				"pods_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the pods in the cluster",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"services_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the services in the cluster",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"ssh_key": {
+					Type:        schema.TypeString,
+					Description: "Name of the SSH Keypair used in the AWS Cluster",
+					Computed:    true,
+				},
+				"node_pool": {
+					Type:        schema.TypeList,
+					Description: "Pools of worker nodes created along with the Cluster",
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Description: "Name of the node pool",
+								Computed:    true,
+							},
+							"description": {
+								Type:        schema.TypeString,
+								Description: "Description of the node pool",
+								Computed:    true,
+							},
+							"worker_node_count": {
+								Type:        schema.TypeInt,
+								Description: "Number of worker nodes in the pool",
+								Computed:    true,
+							},
+							"instance_type": {
+								Type:        schema.TypeString,
+								Description: "Instance type used to deploy the worker nodes",
+								Computed:    true,
+							},
+							"availability_zone": {
+								Type:        schema.TypeString,
+								Description: "Availability zone of the worker nodes",
+								Computed:    true,
+							},
+							"node_labels": {
+								Type:        schema.TypeMap,
+								Description: "Kubernetes labels of the worker nodes",
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"cloud_labels": {
+								Type:        schema.TypeMap,
+								Description: "AWS tags of the worker node instances",
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandTkgAws builds the tkgAws spec of a cluster from its tkg_aws block,
+// or returns nil for clusters which are not hosted on AWS.
+func expandTkgAws(data []interface{}) *tanzuclient.AWSCluster {
+	if len(data) == 0 || data[0] == nil {
+		return nil
+	}
+	tkgAws := data[0].(map[string]interface{})
+
+	aws := &tanzuclient.AWSCluster{}
+
+	aws.Distribution.Region = tkgAws["region"].(string)
+	aws.Distribution.Version = tkgAws["version"].(string)
+	aws.Distribution.ProvisionerCredentialName = tkgAws["credential_name"].(string)
+
+	aws.Settings.Security.SshKey = tkgAws["ssh_key"].(string)
+
+	network := &aws.Settings.Network
+	network.Provider.Vpc.ID = tkgAws["vpc_id"].(string)
+	network.Provider.Vpc.CidrBlock = tkgAws["vpc_cidrblock"].(string)
+
+	for _, s := range tkgAws["subnet"].([]interface{}) {
+		subnet := s.(map[string]interface{})
+		network.Provider.Subnets = append(network.Provider.Subnets, tanzuclient.AWSSubnet{
+			ID:               subnet["id"].(string),
+			AvailabilityZone: subnet["availability_zone"].(string),
+			CidrBlock:        subnet["cidr_block"].(string),
+			IsPublic:         subnet["is_public"].(bool),
+		})
+	}
+	for _, cidr := range tkgAws["pods_cidrblocks"].([]interface{}) {
+		network.ClusterNetwork.Pods = append(network.ClusterNetwork.Pods, tanzuclient.CIDRBlock{CidrBlocks: cidr.(string)})
+	}
+	for _, cidr := range tkgAws["services_cidrblocks"].([]interface{}) {
+		network.ClusterNetwork.Services = append(network.ClusterNetwork.Services, tanzuclient.CIDRBlock{CidrBlocks: cidr.(string)})
+	}
+
+	aws.Topology.ControlPlane.AvailabilityZones = expandStringList(tkgAws["availability_zones"].([]interface{}))
+	aws.Topology.ControlPlane.InstanceType = tkgAws["instance_type"].(string)
+	aws.Topology.ControlPlane.HighAvailability = tkgAws["high_availability"].(bool)
+
+	for _, p := range tkgAws["node_pool"].([]interface{}) {
+		pool := p.(map[string]interface{})
+		availabilityZone := pool["availability_zone"].(string)
+
+		nodePool := tanzuclient.AWSNodePool{
+			Info: tanzuclient.NodePoolInfo{
+				Name:        pool["name"].(string),
+				Description: pool["description"].(string),
+			},
+		}
+		nodePool.Spec.WorkerNodeCount = strconv.Itoa(pool["worker_node_count"].(int))
+		nodePool.Spec.NodeLabels = expandStringMap(pool["node_labels"].(map[string]interface{}))
+		nodePool.Spec.CloudLabels = expandStringMap(pool["cloud_labels"].(map[string]interface{}))
+		nodePool.Spec.TkgAws = tanzuclient.AWSNodeSpec{
+			AvailabilityZone: availabilityZone,
+			InstanceType:     pool["instance_type"].(string),
+			NodePlacement:    []tanzuclient.AWSNodePlacement{{AvailabilityZone: availabilityZone}},
+			Version:          aws.Distribution.Version,
+		}
+
+		aws.Topology.NodePools = append(aws.Topology.NodePools, nodePool)
+	}
+
+	return aws
+}
+
+func flattenAwsData(data *tanzuclient.Cluster) map[string]interface{} {
+	aws := make(map[string]interface{})
+
+	if data.Spec == nil || data.Spec.TkgAws == nil {
+		return aws
+	}
+	tkgAws := data.Spec.TkgAws
+	network := tkgAws.Settings.Network
+
+	podsCidrs := make([]interface{}, 0, len(network.ClusterNetwork.Pods))
+	for _, pods := range network.ClusterNetwork.Pods {
+		podsCidrs = append(podsCidrs, pods.CidrBlocks)
+	}
+
+	servicesCidrs := make([]interface{}, 0, len(network.ClusterNetwork.Services))
+	for _, services := range network.ClusterNetwork.Services {
+		servicesCidrs = append(servicesCidrs, services.CidrBlocks)
+	}
+
+	subnets := make([]interface{}, 0, len(network.Provider.Subnets))
+	for _, subnet := range network.Provider.Subnets {
+		subnets = append(subnets, map[string]interface{}{
+			"id":                subnet.ID,
+			"availability_zone": subnet.AvailabilityZone,
+			"cidr_block":        subnet.CidrBlock,
+			"is_public":         subnet.IsPublic,
+		})
+	}
+
+	nodePools := make([]interface{}, 0, len(tkgAws.Topology.NodePools))
+	for _, pool := range tkgAws.Topology.NodePools {
+		workerNodeCount, _ := strconv.Atoi(pool.Spec.WorkerNodeCount)
+
+		nodePools = append(nodePools, map[string]interface{}{
+			"name":              pool.Info.Name,
+			"description":       pool.Info.Description,
+			"worker_node_count": workerNodeCount,
+			"instance_type":     pool.Spec.TkgAws.InstanceType,
+			"availability_zone": pool.Spec.TkgAws.AvailabilityZone,
+			"node_labels":       pool.Spec.NodeLabels,
+			"cloud_labels":      pool.Spec.CloudLabels,
+		})
+	}
+
+	aws["availability_zones"] = tkgAws.Topology.ControlPlane.AvailabilityZones
+	aws["instance_type"] = tkgAws.Topology.ControlPlane.InstanceType
+	aws["high_availability"] = tkgAws.Topology.ControlPlane.HighAvailability
+	aws["vpc_id"] = network.Provider.Vpc.ID
+	aws["vpc_cidrblock"] = network.Provider.Vpc.CidrBlock
+	aws["subnet"] = subnets
+	aws["region"] = tkgAws.Distribution.Region
+	aws["credential_name"] = tkgAws.Distribution.ProvisionerCredentialName
+	aws["version"] = tkgAws.Distribution.Version
+	aws["ssh_key"] = tkgAws.Settings.Security.SshKey
+	aws["pods_cidrblocks"] = podsCidrs
+	aws["services_cidrblocks"] = servicesCidrs
+	aws["node_pool"] = nodePools
+
+	return aws
+}
+
+// validateTkgAws checks the consistency of the availability zones and CIDR
+// blocks of a tkg_aws block, which the API would only reject once the
+// provisioning of the cluster is under way. Values which are not known yet
+// are skipped.
+func validateTkgAws(data []interface{}) error {
+	if len(data) == 0 || data[0] == nil {
+		return nil
+	}
+	tkgAws := data[0].(map[string]interface{})
+
+	region := tkgAws["region"].(string)
+	inRegion := func(availabilityZone string) error {
+		if region != "" && availabilityZone != "" && !strings.HasPrefix(availabilityZone, region) {
+			return fmt.Errorf("tkg_aws: availability zone %s is not in region %s", availabilityZone, region)
+		}
+		return nil
+	}
+
+	availabilityZones := expandStringList(tkgAws["availability_zones"].([]interface{}))
+	controlPlaneZones := make(map[string]bool)
+	for _, az := range availabilityZones {
+		if err := inRegion(az); err != nil {
+			return err
+		}
+		if controlPlaneZones[az] {
+			return fmt.Errorf("tkg_aws: availability zone %s is listed more than once", az)
+		}
+		controlPlaneZones[az] = true
+	}
+
+	if tkgAws["high_availability"].(bool) {
+		if len(availabilityZones) != 3 {
+			return fmt.Errorf("tkg_aws: a highly available control plane needs 3 availability zones, got %d", len(availabilityZones))
+		}
+	} else if len(availabilityZones) != 1 {
+		return fmt.Errorf("tkg_aws: a single control plane node needs exactly 1 availability zone, got %d, set high_availability to spread it over 3", len(availabilityZones))
+	}
+
+	for _, p := range tkgAws["node_pool"].([]interface{}) {
+		pool := p.(map[string]interface{})
+		az := pool["availability_zone"].(string)
+
+		if err := inRegion(az); err != nil {
+			return err
+		}
+		if az != "" && !controlPlaneZones[az] {
+			return fmt.Errorf("tkg_aws: node pool %s is in availability zone %s, which is not one of the availability zones of the cluster", pool["name"], az)
+		}
+	}
+
+	vpc := parseCIDR(tkgAws["vpc_cidrblock"].(string))
+
+	for _, s := range tkgAws["subnet"].([]interface{}) {
+		subnet := s.(map[string]interface{})
+
+		if err := inRegion(subnet["availability_zone"].(string)); err != nil {
+			return err
+		}
+
+		cidr := subnet["cidr_block"].(string)
+		if block := parseCIDR(cidr); vpc != nil && block != nil && !containsCIDR(vpc, block) {
+			return fmt.Errorf("tkg_aws: subnet CIDR block %s is not within the VPC CIDR block %s", cidr, vpc)
+		}
+	}
+
+	pods := expandStringList(tkgAws["pods_cidrblocks"].([]interface{}))
+	services := expandStringList(tkgAws["services_cidrblocks"].([]interface{}))
+
+	if err := validateClusterCIDRs(pods, services); err != nil {
+		return fmt.Errorf("tkg_aws: %s", err)
+	}
+
+	for _, c := range append(pods, services...) {
+		if block := parseCIDR(c); vpc != nil && block != nil && overlapCIDR(vpc, block) {
+			return fmt.Errorf("tkg_aws: CIDR block %s overlaps with the VPC CIDR block %s", c, vpc)
+		}
+	}
+
+	return nil
+}
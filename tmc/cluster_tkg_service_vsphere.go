@@ -0,0 +1,474 @@
+package tmc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// tkgsVolumeSchema returns the schema of the volumes mounted on the nodes of
+// vSphere with Tanzu clusters.
+func tkgsVolumeSchema(computed bool) *schema.Schema {
+	if computed {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Description: "Volumes mounted on the nodes",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the volume",
+						Computed:    true,
+					},
+					"mount_path": {
+						Type:        schema.TypeString,
+						Description: "Path the volume is mounted on",
+						Computed:    true,
+					},
+					"capacity": {
+						Type:        schema.TypeInt,
+						Description: "Capacity of the volume in GiB",
+						Computed:    true,
+					},
+					"storage_class": {
+						Type:        schema.TypeString,
+						Description: "Storage class the volume is provisioned from",
+						Computed:    true,
+					},
+				},
+			},
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Volumes mounted on the nodes",
+		Optional:    true,
+		ForceNew:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Description: "Name of the volume",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"mount_path": {
+					Type:        schema.TypeString,
+					Description: "Path the volume is mounted on",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"capacity": {
+					Type:         schema.TypeInt,
+					Description:  "Capacity of the volume in GiB",
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"storage_class": {
+					Type:        schema.TypeString,
+					Description: "Storage class the volume is provisioned from, the one of the nodes by default",
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+				},
+			},
+		},
+	}
+}
+
+// tkgServiceVsphereSchema returns the schema of the tkg_service_vsphere block
+// of clusters.
+func tkgServiceVsphereSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"tkg_aws", "tkg_vsphere"},
+		Description:   "Details of Cluster provisioned by a vSphere with Tanzu supervisor cluster",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"version": {
+					Type:        schema.TypeString,
+					Description: "Tanzu Kubernetes release of the Cluster, as listed by the tmc_tkgs_options data source",
+					Required:    true,
+				},
+				"storage_classes": {
+					Type:        schema.TypeList,
+					Description: "Storage classes available to the persistent volumes of the Cluster",
+					Optional:    true,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"default_storage_class": {
+					Type:        schema.TypeString,
+					Description: "Storage class of the persistent volumes which do not request one",
+					Optional:    true,
+					Computed:    true,
+				},
+				"pods_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the pods in the cluster",
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsCIDR,
+					},
+				},
+				"services_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the services in the cluster",
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsCIDR,
+					},
+				},
+				"control_plane": {
+					Type:        schema.TypeList,
+					Description: "Control plane nodes of the Cluster",
+					Required:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"class": {
+								Type:        schema.TypeString,
+								Description: "Virtual machine class of the control plane nodes",
+								Required:    true,
+							},
+							"storage_class": {
+								Type:        schema.TypeString,
+								Description: "Storage class of the disks of the control plane nodes",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"replicas": {
+								Type:         schema.TypeInt,
+								Description:  "Number of control plane nodes, 1 or 3 for a highly available control plane",
+								Optional:     true,
+								Default:      1,
+								ForceNew:     true,
+								ValidateFunc: validation.IntInSlice([]int{1, 3}),
+							},
+							"volume": tkgsVolumeSchema(false),
+						},
+					},
+				},
+				"node_pool": {
+					Type:        schema.TypeList,
+					Description: "Pools of worker nodes created along with the Cluster",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Description: "Name of the node pool",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"description": {
+								Type:        schema.TypeString,
+								Description: "Description of the node pool",
+								Optional:    true,
+							},
+							"worker_node_count": {
+								Type:         schema.TypeInt,
+								Description:  "Number of worker nodes in the pool",
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+							},
+							"class": {
+								Type:        schema.TypeString,
+								Description: "Virtual machine class of the worker nodes",
+								Required:    true,
+							},
+							"storage_class": {
+								Type:        schema.TypeString,
+								Description: "Storage class of the disks of the worker nodes",
+								Required:    true,
+								ForceNew:    true,
+							},
+							"node_labels": {
+								Type:        schema.TypeMap,
+								Description: "Kubernetes labels of the worker nodes",
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"volume": tkgsVolumeSchema(false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// tkgServiceVsphereSchemaComputed returns the schema of the
+// tkg_service_vsphere block of the cluster data source.
+func tkgServiceVsphereSchemaComputed() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Details of Cluster provisioned by a vSphere with Tanzu supervisor cluster",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"version": {
+					Type:        schema.TypeString,
+					Description: "Tanzu Kubernetes release of the Cluster",
+					Computed:    true,
+				},
+				"storage_classes": {
+					Type:        schema.TypeList,
+					Description: "Storage classes available to the persistent volumes of the Cluster",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"default_storage_class": {
+					Type:        schema.TypeString,
+					Description: "Storage class of the persistent volumes which do not request one",
+					Computed:    true,
+				},
+				"pods_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the pods in the cluster",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"services_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the services in the cluster",
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"control_plane": {
+					Type:        schema.TypeList,
+					Description: "Control plane nodes of the Cluster",
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"class": {
+								Type:        schema.TypeString,
+								Description: "Virtual machine class of the control plane nodes",
+								Computed:    true,
+							},
+							"storage_class": {
+								Type:        schema.TypeString,
+								Description: "Storage class of the disks of the control plane nodes",
+								Computed:    true,
+							},
+							"replicas": {
+								Type:        schema.TypeInt,
+								Description: "Number of control plane nodes",
+								Computed:    true,
+							},
+							"volume": tkgsVolumeSchema(true),
+						},
+					},
+				},
+				"node_pool": {
+					Type:        schema.TypeList,
+					Description: "Pools of worker nodes created along with the Cluster",
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Description: "Name of the node pool",
+								Computed:    true,
+							},
+							"description": {
+								Type:        schema.TypeString,
+								Description: "Description of the node pool",
+								Computed:    true,
+							},
+							"worker_node_count": {
+								Type:        schema.TypeInt,
+								Description: "Number of worker nodes in the pool",
+								Computed:    true,
+							},
+							"class": {
+								Type:        schema.TypeString,
+								Description: "Virtual machine class of the worker nodes",
+								Computed:    true,
+							},
+							"storage_class": {
+								Type:        schema.TypeString,
+								Description: "Storage class of the disks of the worker nodes",
+								Computed:    true,
+							},
+							"node_labels": {
+								Type:        schema.TypeMap,
+								Description: "Kubernetes labels of the worker nodes",
+								Computed:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"volume": tkgsVolumeSchema(true),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandTkgServiceVsphere builds the tkgServiceVsphere spec of a cluster from
+// its tkg_service_vsphere block, or returns nil for clusters which are not
+// provisioned by a supervisor cluster.
+func expandTkgServiceVsphere(data []interface{}) *tanzuclient.TkgServiceVsphere {
+	if len(data) == 0 || data[0] == nil {
+		return nil
+	}
+	tkgs := data[0].(map[string]interface{})
+
+	spec := &tanzuclient.TkgServiceVsphere{}
+
+	spec.Distribution.Version = tkgs["version"].(string)
+
+	spec.Settings.Storage.Classes = expandStringList(tkgs["storage_classes"].([]interface{}))
+	spec.Settings.Storage.DefaultClass = tkgs["default_storage_class"].(string)
+	spec.Settings.Network.Pods.CidrBlocks = expandStringList(tkgs["pods_cidrblocks"].([]interface{}))
+	spec.Settings.Network.Services.CidrBlocks = expandStringList(tkgs["services_cidrblocks"].([]interface{}))
+
+	if controlPlanes := tkgs["control_plane"].([]interface{}); len(controlPlanes) > 0 && controlPlanes[0] != nil {
+		controlPlane := controlPlanes[0].(map[string]interface{})
+
+		spec.Topology.ControlPlane.TKGSNodeSpec = expandTKGSNodeSpec(controlPlane)
+		spec.Topology.ControlPlane.HighAvailability = controlPlane["replicas"].(int) == 3
+	}
+
+	for _, p := range tkgs["node_pool"].([]interface{}) {
+		pool := p.(map[string]interface{})
+
+		nodePool := tanzuclient.TKGSNodePool{
+			Info: tanzuclient.NodePoolInfo{
+				Name:        pool["name"].(string),
+				Description: pool["description"].(string),
+			},
+		}
+		nodePool.Spec.WorkerNodeCount = strconv.Itoa(pool["worker_node_count"].(int))
+		nodePool.Spec.NodeLabels = expandStringMap(pool["node_labels"].(map[string]interface{}))
+		nodePool.Spec.TkgServiceVsphere = expandTKGSNodeSpec(pool)
+
+		spec.Topology.NodePools = append(spec.Topology.NodePools, nodePool)
+	}
+
+	return spec
+}
+
+func expandTKGSNodeSpec(data map[string]interface{}) tanzuclient.TKGSNodeSpec {
+	nodeSpec := tanzuclient.TKGSNodeSpec{
+		Class:        data["class"].(string),
+		StorageClass: data["storage_class"].(string),
+	}
+
+	for _, v := range data["volume"].([]interface{}) {
+		volume := v.(map[string]interface{})
+
+		nodeSpec.Volumes = append(nodeSpec.Volumes, tanzuclient.TKGSVolume{
+			Name:         volume["name"].(string),
+			MountPath:    volume["mount_path"].(string),
+			Capacity:     float64(volume["capacity"].(int)),
+			StorageClass: volume["storage_class"].(string),
+		})
+	}
+
+	return nodeSpec
+}
+
+func flattenTkgServiceVsphere(data *tanzuclient.Cluster) map[string]interface{} {
+	tkgs := make(map[string]interface{})
+
+	if data.Spec == nil || data.Spec.TkgServiceVsphere == nil {
+		return tkgs
+	}
+	spec := data.Spec.TkgServiceVsphere
+
+	replicas := 1
+	if spec.Topology.ControlPlane.HighAvailability {
+		replicas = 3
+	}
+
+	controlPlane := flattenTKGSNodeSpec(spec.Topology.ControlPlane.TKGSNodeSpec)
+	controlPlane["replicas"] = replicas
+
+	nodePools := make([]interface{}, 0, len(spec.Topology.NodePools))
+	for _, pool := range spec.Topology.NodePools {
+		workerNodeCount, _ := strconv.Atoi(pool.Spec.WorkerNodeCount)
+
+		nodePool := flattenTKGSNodeSpec(pool.Spec.TkgServiceVsphere)
+		nodePool["name"] = pool.Info.Name
+		nodePool["description"] = pool.Info.Description
+		nodePool["worker_node_count"] = workerNodeCount
+		nodePool["node_labels"] = pool.Spec.NodeLabels
+
+		nodePools = append(nodePools, nodePool)
+	}
+
+	tkgs["version"] = spec.Distribution.Version
+	tkgs["storage_classes"] = spec.Settings.Storage.Classes
+	tkgs["default_storage_class"] = spec.Settings.Storage.DefaultClass
+	tkgs["pods_cidrblocks"] = spec.Settings.Network.Pods.CidrBlocks
+	tkgs["services_cidrblocks"] = spec.Settings.Network.Services.CidrBlocks
+	tkgs["control_plane"] = []interface{}{controlPlane}
+	tkgs["node_pool"] = nodePools
+
+	return tkgs
+}
+
+func flattenTKGSNodeSpec(nodeSpec tanzuclient.TKGSNodeSpec) map[string]interface{} {
+	volumes := make([]interface{}, 0, len(nodeSpec.Volumes))
+	for _, volume := range nodeSpec.Volumes {
+		volumes = append(volumes, map[string]interface{}{
+			"name":          volume.Name,
+			"mount_path":    volume.MountPath,
+			"capacity":      int(volume.Capacity),
+			"storage_class": volume.StorageClass,
+		})
+	}
+
+	return map[string]interface{}{
+		"class":         nodeSpec.Class,
+		"storage_class": nodeSpec.StorageClass,
+		"volume":        volumes,
+	}
+}
+
+// validateTkgServiceVsphere checks that the CIDR blocks of a
+// tkg_service_vsphere block do not overlap, and that its default storage
+// class is one of the storage classes made available to the cluster.
+func validateTkgServiceVsphere(data []interface{}) error {
+	if len(data) == 0 || data[0] == nil {
+		return nil
+	}
+	tkgs := data[0].(map[string]interface{})
+
+	pods := expandStringList(tkgs["pods_cidrblocks"].([]interface{}))
+	services := expandStringList(tkgs["services_cidrblocks"].([]interface{}))
+
+	if err := validateClusterCIDRs(pods, services); err != nil {
+		return fmt.Errorf("tkg_service_vsphere: %s", err)
+	}
+
+	defaultClass := tkgs["default_storage_class"].(string)
+	storageClasses := expandStringList(tkgs["storage_classes"].([]interface{}))
+	if defaultClass == "" || len(storageClasses) == 0 {
+		return nil
+	}
+
+	for _, class := range storageClasses {
+		if class == defaultClass {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("tkg_service_vsphere: default storage class %s is not one of the storage_classes of the cluster", defaultClass)
+}
@@ -0,0 +1,389 @@
+package tmc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// vsphereVMConfigSchema returns the attributes sizing the virtual machines of
+// a cluster on vSphere.
+func vsphereVMConfigSchema(computed bool) map[string]*schema.Schema {
+	attributes := map[string]*schema.Schema{
+		"cpu": {
+			Type:        schema.TypeInt,
+			Description: "Number of CPUs of the virtual machines",
+		},
+		"memory_mib": {
+			Type:        schema.TypeInt,
+			Description: "Memory of the virtual machines in MiB",
+		},
+		"disk_gib": {
+			Type:        schema.TypeInt,
+			Description: "Size of the disk of the virtual machines in GiB",
+		},
+	}
+
+	for _, attribute := range attributes {
+		if computed {
+			attribute.Computed = true
+		} else {
+			attribute.Required = true
+			attribute.ValidateFunc = validation.IntAtLeast(1)
+		}
+	}
+
+	return attributes
+}
+
+// tkgVsphereSchema returns the schema of the tkg_vsphere block of clusters.
+func tkgVsphereSchema() *schema.Schema {
+	controlPlane := vsphereVMConfigSchema(false)
+	controlPlane["high_availability"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether to run three control plane nodes instead of one",
+		Optional:    true,
+		Default:     false,
+		ForceNew:    true,
+	}
+
+	nodePool := vsphereVMConfigSchema(false)
+	nodePool["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Name of the node pool",
+		Required:    true,
+		ForceNew:    true,
+	}
+	nodePool["description"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Description of the node pool",
+		Optional:    true,
+	}
+	nodePool["worker_node_count"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Description:  "Number of worker nodes in the pool",
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	nodePool["node_labels"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Kubernetes labels of the worker nodes",
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"tkg_aws"},
+		Description:   "Details of Cluster hosted on vSphere",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"version": {
+					Type:        schema.TypeString,
+					Description: "TKG version of the vSphere Cluster",
+					Required:    true,
+				},
+				"datacenter": {
+					Type:        schema.TypeString,
+					Description: "Path of the datacenter the Cluster is deployed into",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"datastore": {
+					Type:        schema.TypeString,
+					Description: "Path of the datastore holding the disks of the Cluster",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"folder": {
+					Type:        schema.TypeString,
+					Description: "Path of the folder holding the virtual machines of the Cluster",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"resource_pool": {
+					Type:        schema.TypeString,
+					Description: "Path of the resource pool the virtual machines of the Cluster run in",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"workspace_network": {
+					Type:        schema.TypeString,
+					Description: "Path of the network the virtual machines of the Cluster are attached to",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"control_plane_endpoint": {
+					Type:         schema.TypeString,
+					Description:  "Virtual IP address of the API server of the Cluster",
+					Optional:     true,
+					Computed:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IsIPAddress,
+				},
+				"ssh_key": {
+					Type:        schema.TypeString,
+					Description: "Public SSH key authorized on the nodes of the Cluster",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"pods_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the pods in the cluster",
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsCIDR,
+					},
+				},
+				"services_cidrblocks": {
+					Type:        schema.TypeList,
+					Description: "CIDR blocks allocated to the services in the cluster",
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsCIDR,
+					},
+				},
+				"control_plane": {
+					Type:        schema.TypeList,
+					Description: "Size of the control plane nodes",
+					Required:    true,
+					MaxItems:    1,
+					Elem:        &schema.Resource{Schema: controlPlane},
+				},
+				"node_pool": {
+					Type:        schema.TypeList,
+					Description: "Pools of worker nodes created along with the Cluster",
+					Optional:    true,
+					Elem:        &schema.Resource{Schema: nodePool},
+				},
+			},
+		},
+	}
+}
+
+// tkgVsphereSchemaComputed returns the schema of the tkg_vsphere block of the
+// cluster data source.
+func tkgVsphereSchemaComputed() *schema.Schema {
+	controlPlane := vsphereVMConfigSchema(true)
+	controlPlane["high_availability"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether the Cluster runs three control plane nodes instead of one",
+		Computed:    true,
+	}
+
+	nodePool := vsphereVMConfigSchema(true)
+	nodePool["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Name of the node pool",
+		Computed:    true,
+	}
+	nodePool["description"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Description of the node pool",
+		Computed:    true,
+	}
+	nodePool["worker_node_count"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "Number of worker nodes in the pool",
+		Computed:    true,
+	}
+	nodePool["node_labels"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Kubernetes labels of the worker nodes",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+
+	attributes := map[string]*schema.Schema{}
+	for name, description := range map[string]string{
+		"version":                "TKG version of the vSphere Cluster",
+		"datacenter":             "Path of the datacenter the Cluster is deployed into",
+		"datastore":              "Path of the datastore holding the disks of the Cluster",
+		"folder":                 "Path of the folder holding the virtual machines of the Cluster",
+		"resource_pool":          "Path of the resource pool the virtual machines of the Cluster run in",
+		"workspace_network":      "Path of the network the virtual machines of the Cluster are attached to",
+		"control_plane_endpoint": "Virtual IP address of the API server of the Cluster",
+		"ssh_key":                "Public SSH key authorized on the nodes of the Cluster",
+	} {
+		attributes[name] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: description,
+			Computed:    true,
+		}
+	}
+	attributes["pods_cidrblocks"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "CIDR blocks allocated to the pods in the cluster",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	attributes["services_cidrblocks"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "CIDR blocks allocated to the services in the cluster",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	attributes["control_plane"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Size of the control plane nodes",
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: controlPlane},
+	}
+	attributes["node_pool"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Pools of worker nodes created along with the Cluster",
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: nodePool},
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Details of Cluster hosted on vSphere",
+		Elem:        &schema.Resource{Schema: attributes},
+	}
+}
+
+// expandTkgVsphere builds the tkgVsphere spec of a cluster from its
+// tkg_vsphere block, or returns nil for clusters which are not hosted on
+// vSphere.
+func expandTkgVsphere(data []interface{}) *tanzuclient.TkgVsphere {
+	if len(data) == 0 || data[0] == nil {
+		return nil
+	}
+	tkgVsphere := data[0].(map[string]interface{})
+
+	vsphere := &tanzuclient.TkgVsphere{}
+
+	vsphere.Distribution.Version = tkgVsphere["version"].(string)
+	vsphere.Distribution.Workspace = tanzuclient.VsphereWorkspace{
+		Datacenter:       tkgVsphere["datacenter"].(string),
+		Datastore:        tkgVsphere["datastore"].(string),
+		Folder:           tkgVsphere["folder"].(string),
+		ResourcePool:     tkgVsphere["resource_pool"].(string),
+		WorkspaceNetwork: tkgVsphere["workspace_network"].(string),
+	}
+
+	vsphere.Settings.Security.SshKey = tkgVsphere["ssh_key"].(string)
+	vsphere.Settings.Network = tanzuclient.VsphereNetwork{
+		Pods:                 tanzuclient.VsphereCIDRBlocks{CidrBlocks: expandStringList(tkgVsphere["pods_cidrblocks"].([]interface{}))},
+		Services:             tanzuclient.VsphereCIDRBlocks{CidrBlocks: expandStringList(tkgVsphere["services_cidrblocks"].([]interface{}))},
+		ControlPlaneEndpoint: tkgVsphere["control_plane_endpoint"].(string),
+	}
+
+	if controlPlanes := tkgVsphere["control_plane"].([]interface{}); len(controlPlanes) > 0 && controlPlanes[0] != nil {
+		controlPlane := controlPlanes[0].(map[string]interface{})
+
+		vsphere.Topology.ControlPlane.VMConfig = expandVsphereVMConfig(controlPlane)
+		vsphere.Topology.ControlPlane.HighAvailability = controlPlane["high_availability"].(bool)
+	}
+
+	for _, p := range tkgVsphere["node_pool"].([]interface{}) {
+		pool := p.(map[string]interface{})
+
+		nodePool := tanzuclient.VsphereNodePool{
+			Info: tanzuclient.NodePoolInfo{
+				Name:        pool["name"].(string),
+				Description: pool["description"].(string),
+			},
+		}
+		nodePool.Spec.WorkerNodeCount = strconv.Itoa(pool["worker_node_count"].(int))
+		nodePool.Spec.NodeLabels = expandStringMap(pool["node_labels"].(map[string]interface{}))
+		nodePool.Spec.TkgVsphere.VMConfig = expandVsphereVMConfig(pool)
+
+		vsphere.Topology.NodePools = append(vsphere.Topology.NodePools, nodePool)
+	}
+
+	return vsphere
+}
+
+func expandVsphereVMConfig(data map[string]interface{}) tanzuclient.VsphereVMConfig {
+	return tanzuclient.VsphereVMConfig{
+		CPU:       strconv.Itoa(data["cpu"].(int)),
+		MemoryMiB: strconv.Itoa(data["memory_mib"].(int)),
+		DiskGiB:   strconv.Itoa(data["disk_gib"].(int)),
+	}
+}
+
+func flattenTkgVsphere(data *tanzuclient.Cluster) map[string]interface{} {
+	vsphere := make(map[string]interface{})
+
+	if data.Spec == nil || data.Spec.TkgVsphere == nil {
+		return vsphere
+	}
+	tkgVsphere := data.Spec.TkgVsphere
+	workspace := tkgVsphere.Distribution.Workspace
+	network := tkgVsphere.Settings.Network
+
+	controlPlane := flattenVsphereVMConfig(tkgVsphere.Topology.ControlPlane.VMConfig)
+	controlPlane["high_availability"] = tkgVsphere.Topology.ControlPlane.HighAvailability
+
+	nodePools := make([]interface{}, 0, len(tkgVsphere.Topology.NodePools))
+	for _, pool := range tkgVsphere.Topology.NodePools {
+		workerNodeCount, _ := strconv.Atoi(pool.Spec.WorkerNodeCount)
+
+		nodePool := flattenVsphereVMConfig(pool.Spec.TkgVsphere.VMConfig)
+		nodePool["name"] = pool.Info.Name
+		nodePool["description"] = pool.Info.Description
+		nodePool["worker_node_count"] = workerNodeCount
+		nodePool["node_labels"] = pool.Spec.NodeLabels
+
+		nodePools = append(nodePools, nodePool)
+	}
+
+	vsphere["version"] = tkgVsphere.Distribution.Version
+	vsphere["datacenter"] = workspace.Datacenter
+	vsphere["datastore"] = workspace.Datastore
+	vsphere["folder"] = workspace.Folder
+	vsphere["resource_pool"] = workspace.ResourcePool
+	vsphere["workspace_network"] = workspace.WorkspaceNetwork
+	vsphere["control_plane_endpoint"] = network.ControlPlaneEndpoint
+	vsphere["ssh_key"] = tkgVsphere.Settings.Security.SshKey
+	vsphere["pods_cidrblocks"] = network.Pods.CidrBlocks
+	vsphere["services_cidrblocks"] = network.Services.CidrBlocks
+	vsphere["control_plane"] = []interface{}{controlPlane}
+	vsphere["node_pool"] = nodePools
+
+	return vsphere
+}
+
+func flattenVsphereVMConfig(vmConfig tanzuclient.VsphereVMConfig) map[string]interface{} {
+	cpu, _ := strconv.Atoi(vmConfig.CPU)
+	memory, _ := strconv.Atoi(vmConfig.MemoryMiB)
+	disk, _ := strconv.Atoi(vmConfig.DiskGiB)
+
+	return map[string]interface{}{
+		"cpu":        cpu,
+		"memory_mib": memory,
+		"disk_gib":   disk,
+	}
+}
+
+// validateTkgVsphere checks that the CIDR blocks of a tkg_vsphere block do
+// not overlap.
+func validateTkgVsphere(data []interface{}) error {
+	if len(data) == 0 || data[0] == nil {
+		return nil
+	}
+	tkgVsphere := data[0].(map[string]interface{})
+
+	pods := expandStringList(tkgVsphere["pods_cidrblocks"].([]interface{}))
+	services := expandStringList(tkgVsphere["services_cidrblocks"].([]interface{}))
+
+	if err := validateClusterCIDRs(pods, services); err != nil {
+		return fmt.Errorf("tkg_vsphere: %s", err)
+	}
+
+	return nil
+}
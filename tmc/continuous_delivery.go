@@ -0,0 +1,174 @@
+package tmc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// fluxNamespace is the namespace TMC applies the Git repositories and
+// Kustomizations to by default.
+const fluxNamespace = "tanzu-continuousdelivery-resources"
+
+// fluxScopeKind is a kind of object continuous delivery is enabled on.
+type fluxScopeKind struct {
+	// prefix is the first part of the IDs of the objects of the kind.
+	prefix string
+	// attributes identify the object, in the order of the parts of the IDs.
+	attributes []string
+	scope      func(values []string) tanzuclient.FluxScope
+}
+
+var fluxScopeKinds = []fluxScopeKind{
+	{
+		prefix:     "cluster",
+		attributes: []string{"management_cluster", "provisioner_name", "cluster_name"},
+		scope: func(values []string) tanzuclient.FluxScope {
+			return tanzuclient.ClusterFluxScope(values[0], values[1], values[2])
+		},
+	},
+	{
+		prefix:     "cluster_group",
+		attributes: []string{"cluster_group_name"},
+		scope: func(values []string) tanzuclient.FluxScope {
+			return tanzuclient.ClusterGroupFluxScope(values[0])
+		},
+	},
+}
+
+// idFormat returns the format of the IDs of the objects of the kind, which
+// end with the given attributes of the objects themselves.
+func (k fluxScopeKind) idFormat(attributes ...string) string {
+	return buildID(append(append([]string{k.prefix}, k.attributes...), attributes...)...)
+}
+
+func (k fluxScopeKind) values(d *schema.ResourceData) []string {
+	values := make([]string, 0, len(k.attributes))
+	for _, attribute := range k.attributes {
+		values = append(values, d.Get(attribute).(string))
+	}
+
+	return values
+}
+
+// fluxScopeSchema returns the arguments selecting the object continuous
+// delivery is enabled on, exactly one of a cluster group or a cluster.
+func fluxScopeSchema() map[string]*schema.Schema {
+	scopes := []string{"cluster_group_name", "cluster_name"}
+	cluster := []string{"management_cluster", "provisioner_name", "cluster_name"}
+
+	return map[string]*schema.Schema{
+		"cluster_group_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: scopes,
+			Description:  "Name of the cluster group, to apply to all of its clusters",
+		},
+		"management_cluster": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: cluster,
+			Description:  "Name of the management cluster of the Cluster",
+		},
+		"provisioner_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: cluster,
+			Description:  "Name of the provisioner of the Cluster",
+		},
+		"cluster_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: scopes,
+			RequiredWith: cluster,
+			Description:  "Name of the Cluster, to only apply to it",
+		},
+	}
+}
+
+// fluxIntervalSchema returns the argument setting how often Flux reconciles
+// an object.
+func fluxIntervalSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "5m",
+		ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+			if _, err := time.ParseDuration(i.(string)); err != nil {
+				return nil, []error{fmt.Errorf("expected %s to be a duration like 5m: %s", k, err)}
+			}
+			return nil, nil
+		},
+		DiffSuppressFunc: suppressEquivalentDuration,
+		Description:      description,
+	}
+}
+
+// fluxScopeKindOf returns the kind of object the resource applies to.
+func fluxScopeKindOf(d *schema.ResourceData) fluxScopeKind {
+	for _, kind := range fluxScopeKinds {
+		if d.Get(kind.attributes[len(kind.attributes)-1]).(string) != "" {
+			return kind
+		}
+	}
+
+	// The schema requires one of the objects to be set.
+	return fluxScopeKinds[len(fluxScopeKinds)-1]
+}
+
+// fluxScope returns the object the resource applies to.
+func fluxScope(d *schema.ResourceData) tanzuclient.FluxScope {
+	kind := fluxScopeKindOf(d)
+
+	return kind.scope(kind.values(d))
+}
+
+// fluxID returns the ID of the resource, made of the kind of object it
+// applies to, the names identifying the object and the given attributes of
+// the resource.
+func fluxID(d *schema.ResourceData, attributes ...string) string {
+	kind := fluxScopeKindOf(d)
+
+	parts := append([]string{kind.prefix}, kind.values(d)...)
+	for _, attribute := range attributes {
+		parts = append(parts, d.Get(attribute).(string))
+	}
+
+	return buildID(parts...)
+}
+
+// importFluxID sets the arguments identifying the resource from its ID, which
+// ends with the given attributes of the resource.
+func importFluxID(d *schema.ResourceData, attributes ...string) error {
+	prefix := strings.SplitN(d.Id(), "/", 2)[0]
+
+	formats := make([]string, 0, len(fluxScopeKinds))
+	for _, kind := range fluxScopeKinds {
+		formats = append(formats, kind.idFormat(attributes...))
+		if kind.prefix != prefix {
+			continue
+		}
+
+		parts, err := parseID(d.Id(), kind.idFormat(attributes...))
+		if err != nil {
+			return err
+		}
+		for i, attribute := range kind.attributes {
+			d.Set(attribute, parts[i+1])
+		}
+		for i, attribute := range attributes {
+			d.Set(attribute, parts[len(kind.attributes)+i+1])
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unexpected ID %q, expected one of %s", d.Id(), strings.Join(formats, ", "))
+}
@@ -0,0 +1,170 @@
+package tmc
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// backupScheduleLabel is the label Velero puts on the backups taken by a
+// schedule.
+const backupScheduleLabel = "velero.io/schedule-name"
+
+func dataSourceTmcBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTmcBackupsRead,
+		Schema: map[string]*schema.Schema{
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Cluster the backups were taken of",
+			},
+			"schedule_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the backups taken by this backup schedule",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the backups of the Cluster",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Backups of the Cluster",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the backup",
+						},
+						"uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique ID of the backup",
+						},
+						"schedule_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the backup schedule which took the backup, empty for backups taken on demand",
+						},
+						"backup_location_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the backup location the backup is kept in",
+						},
+						"phase": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Phase of the backup, like InProgress, Completed or Failed",
+						},
+						"start_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the backup started, in RFC 3339 format",
+						},
+						"completion_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the backup completed, in RFC 3339 format",
+						},
+						"expiration": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the backup is deleted, in RFC 3339 format",
+						},
+						"errors": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of errors encountered during the backup",
+						},
+						"warnings": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of warnings reported during the backup",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTmcBackupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	managementClusterName := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	scheduleName := d.Get("schedule_name").(string)
+
+	query := tanzuclient.Query{}
+	if scheduleName != "" {
+		query = tanzuclient.Term(tanzuclient.LabelField(backupScheduleLabel), scheduleName)
+	}
+
+	res, err := client.GetAllBackups(ctx, clusterName, managementClusterName, provisionerName, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := make([]interface{}, len(res))
+	backups := make([]interface{}, len(res))
+
+	for i, backup := range res {
+		names[i] = backup.FullName.Name
+
+		item := map[string]interface{}{
+			"name": backup.FullName.Name,
+			"uid":  backup.Meta.UID,
+		}
+		if schedule, ok := backup.Meta.Labels[backupScheduleLabel].(string); ok {
+			item["schedule_name"] = schedule
+		}
+		if backup.Spec != nil {
+			item["backup_location_name"] = backup.Spec.StorageLocation
+		}
+		if backup.Status != nil {
+			item["phase"] = backup.Status.Phase
+			item["start_time"] = backup.Status.StartTimestamp
+			item["completion_time"] = backup.Status.CompletionTimestamp
+			item["expiration"] = backup.Status.Expiration
+			item["errors"] = backup.Status.Errors
+			item["warnings"] = backup.Status.Warnings
+		}
+		backups[i] = item
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("backups", backups); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := buildID(managementClusterName, provisionerName, clusterName)
+	if scheduleName != "" {
+		id = buildID(id, scheduleName)
+	}
+	d.SetId(id)
+	return diags
+}
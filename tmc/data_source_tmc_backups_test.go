@@ -0,0 +1,59 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcBackups(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcDataProtectionConfig(server, true),
+			},
+			{
+				PreConfig: func() {
+					server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-daily-20210701020000", "tf-acc-daily", "Completed")
+					server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-daily-20210702020000", "tf-acc-daily", "Failed")
+					server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-manual", "", "Completed")
+				},
+				Config: testAccResourceTmcDataProtectionConfig(server, true) + `
+data "tmc_backups" "all" {
+  management_cluster = tmc_data_protection.test.management_cluster
+  provisioner_name   = tmc_data_protection.test.provisioner_name
+  cluster_name       = tmc_data_protection.test.cluster_name
+}
+
+data "tmc_backups" "daily" {
+  management_cluster = tmc_data_protection.test.management_cluster
+  provisioner_name   = tmc_data_protection.test.provisioner_name
+  cluster_name       = tmc_data_protection.test.cluster_name
+  schedule_name      = "tf-acc-daily"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_backups.all", "id", "aws-hosted/tf-acc/tf-acc-cluster"),
+					resource.TestCheckResourceAttr("data.tmc_backups.all", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.tmc_backups.all", "names.2", "tf-acc-manual"),
+					resource.TestCheckResourceAttr("data.tmc_backups.all", "backups.2.schedule_name", ""),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "id", "aws-hosted/tf-acc/tf-acc-cluster/tf-acc-daily"),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "backups.0.name", "tf-acc-daily-20210701020000"),
+					resource.TestCheckResourceAttrSet("data.tmc_backups.daily", "backups.0.uid"),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "backups.0.schedule_name", "tf-acc-daily"),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "backups.0.backup_location_name", "default"),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "backups.0.phase", "Completed"),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "backups.0.start_time", "2021-07-01T02:00:00Z"),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "backups.0.expiration", "2021-07-31T02:00:00Z"),
+					resource.TestCheckResourceAttr("data.tmc_backups.daily", "backups.1.phase", "Failed"),
+				),
+			},
+		},
+	})
+}
@@ -48,65 +48,20 @@ func dataSourceCluster() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
-			"labels": labelsSchemaComputed(),
-			"tkg_aws": {
-				Type:        schema.TypeList,
+			"phase": {
+				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Details of Cluster hosted on AWS",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"region": {
-							Type:        schema.TypeString,
-							Description: "Region of the AWS Cluster",
-							Computed:    true,
-						},
-						"version": {
-							Type:        schema.TypeString,
-							Description: "Provisioner credential used to create the cluster",
-							Computed:    true,
-						},
-						"credential_name": {
-							Type:        schema.TypeString,
-							Description: "Kubernetes version of the AWS Cluster",
-							Computed:    true,
-						},
-						"availability_zones": {
-							Type:        schema.TypeList,
-							Description: "Availability zones of the control plane node",
-							Computed:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"instance_type": {
-							Type:        schema.TypeString,
-							Description: "Instance type used to deploy the control plane node",
-							Computed:    true,
-						},
-						"vpc_cidrblock": {
-							Type:        schema.TypeString,
-							Description: "CIDR block used by the Cluster's VPC",
-							Computed:    true,
-						},
-						"ssh_key": {
-							Type:        schema.TypeString,
-							Description: "Name of the SSH Keypair used in the AWS Cluster",
-							Computed:    true,
-						},
-						// "pods_cidrblocks": {
-						// 	Type:        schema.TypeList,
-						// 	Description: "CIDR blocks allocated to the pods in the cluster",
-						// 	Computed:    true,
-						// 	Elem: &schema.Resource{
-						// 		Schema: map[string]*schema.Schema{
-						// 			"cidr_blocks": {
-						// 				Type:     schema.TypeString,
-						// 				Computed: true,
-						// 			},
-						// 		},
-						// 	},
-						// },
-					},
-				},
+				Description: "Lifecycle phase of the Cluster",
 			},
+			"health": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health of the Cluster as reported by its agent",
+			},
+			"labels":              labelsSchemaComputed(),
+			"tkg_aws":             tkgAwsSchemaComputed(),
+			"tkg_vsphere":         tkgVsphereSchemaComputed(),
+			"tkg_service_vsphere": tkgServiceVsphereSchemaComputed(),
 		},
 	}
 }
@@ -120,14 +75,23 @@ func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	var diags diag.Diagnostics
 
-	cluster, err := client.GetCluster(clusterName, managementClusterName, provisionerName)
+	cluster, err := client.GetCluster(ctx, clusterName, managementClusterName, provisionerName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.Set("description", cluster.Meta.Description)
-	d.Set("cluster_group_name", cluster.Spec.ClusterGroupName)
-	d.Set("installer_link", cluster.Status.InstallerLink)
+
+	if cluster.Spec != nil {
+		d.Set("cluster_group_name", cluster.Spec.ClusterGroupName)
+	}
+
+	// Clusters being registered may not report a status yet
+	if cluster.Status != nil {
+		d.Set("installer_link", cluster.Status.InstallerLink)
+		d.Set("phase", cluster.Status.Phase)
+		d.Set("health", cluster.Status.Health)
+	}
 
 	if err := d.Set("labels", cluster.Meta.Labels); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -140,9 +104,10 @@ func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	tkgAws := make([]interface{}, 0)
 
-	awsData := flattenAwsData(cluster)
-
-	tkgAws = append(tkgAws, awsData)
+	// Clusters which are not hosted on AWS have no tkg_aws block
+	if awsData := flattenAwsData(cluster); len(awsData) > 0 {
+		tkgAws = append(tkgAws, awsData)
+	}
 
 	if err := d.Set("tkg_aws", tkgAws); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -152,33 +117,40 @@ func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta int
 		})
 		return diags
 	}
-	d.SetId(string(cluster.Meta.UID))
 
-	return diags
-}
+	tkgVsphere := make([]interface{}, 0)
+
+	// Clusters which are not hosted on vSphere have no tkg_vsphere block
+	if vsphereData := flattenTkgVsphere(cluster); len(vsphereData) > 0 {
+		tkgVsphere = append(tkgVsphere, vsphereData)
+	}
+
+	if err := d.Set("tkg_vsphere", tkgVsphere); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read cluster",
+			Detail:   fmt.Sprintf("Error setting spec for resource %s: %s", d.Get("name"), err),
+		})
+		return diags
+	}
 
-func flattenAwsData(data *tanzuclient.Cluster) map[string]interface{} {
-	aws := make(map[string]interface{})
+	tkgServiceVsphere := make([]interface{}, 0)
 
-	// pods_cidrs := make([]interface{}, 0)
-	// pods_cidrs = append(pods_cidrs, data.Spec.TkgAws.Settings.Network.ClusterNetwork.Pods)
+	// Clusters which are not provisioned by a supervisor cluster have no
+	// tkg_service_vsphere block
+	if tkgsData := flattenTkgServiceVsphere(cluster); len(tkgsData) > 0 {
+		tkgServiceVsphere = append(tkgServiceVsphere, tkgsData)
+	}
 
-	// aws["availability_zones"] = data.Spec.TkgAws.Topology.ControlPlane.AvailabilityZones
-	// aws["instance_type"] = data.Spec.TkgAws.Topology.ControlPlane.InstanceType
-	// aws["vpc_cidrblock"] = data.Spec.TkgAws.Settings.Network.Provider.Vpc.CidrBlock
-	// aws["region"] = data.Spec.TkgAws.Distribution.Region
-	// aws["credential_name"] = data.Spec.TkgAws.Distribution.ProvisionerCredentialName
-	// aws["version"] = data.Spec.TkgAws.Distribution.Version
-	// aws["ssh_key"] = data.Spec.TkgAws.Settings.Security.SshKey
-	// aws["pods_cidrblocks"] = pods_cidrs
+	if err := d.Set("tkg_service_vsphere", tkgServiceVsphere); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read cluster",
+			Detail:   fmt.Sprintf("Error setting spec for resource %s: %s", d.Get("name"), err),
+		})
+		return diags
+	}
+	d.SetId(string(cluster.Meta.UID))
 
-	return aws
+	return diags
 }
-
-// func sliceToStrMap(elements []string) map[string]string {
-// 	elementMap := make(map[string]string)
-// 	for _, s := range elements {
-// 		elementMap[s] = s
-// 	}
-// 	return elementMap
-// }
@@ -38,7 +38,7 @@ func dataSourceClusterGroupRead(ctx context.Context, d *schema.ResourceData, met
 
 	var diags diag.Diagnostics
 
-	clusterGroup, err := client.GetClusterGroup(d.Get("name").(string))
+	clusterGroup, err := client.GetClusterGroup(ctx, d.Get("name").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,40 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceClusterGroup(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster_group" "test" {
+  name        = "tf-acc-cluster-group"
+  description = "read by the data source"
+
+  labels = {
+    env = "test"
+  }
+}
+
+data "tmc_cluster_group" "test" {
+  name = tmc_cluster_group.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.tmc_cluster_group.test", "id", "tmc_cluster_group.test", "uid"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_group.test", "description", "read by the data source"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_group.test", "labels.env", "test"),
+				),
+			},
+		},
+	})
+}
@@ -14,6 +14,7 @@ func dataSourceClusterGroups() *schema.Resource {
 		ReadContext: dataSourceClusterGroupsRead,
 		Schema: map[string]*schema.Schema{
 			"labels": labelsSchema(),
+			"filter": filterSchema(),
 			"ids": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -35,9 +36,12 @@ func dataSourceClusterGroupsRead(ctx context.Context, d *schema.ResourceData, me
 
 	var diags diag.Diagnostics
 
-	labels := d.Get("labels").(map[string]interface{})
+	query, err := buildSearchQuery(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	res, err := client.GetAllClusterGroups(labels)
+	res, err := client.GetAllClusterGroups(ctx, query)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,50 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceClusterGroups(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster_group" "test" {
+  count = 3
+  name  = "tf-acc-cluster-group-${count.index}"
+
+  labels = {
+    region = count.index == 0 ? "eu-west-1" : "us-east-1"
+  }
+}
+
+data "tmc_cluster_groups" "all" {
+  depends_on = [tmc_cluster_group.test]
+}
+
+data "tmc_cluster_groups" "test" {
+  depends_on = [tmc_cluster_group.test]
+
+  filter {
+    label  = "region"
+    values = ["eu-west-1", "eu-central-1"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_cluster_groups.all", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_groups.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_groups.test", "names.0", "tf-acc-cluster-group-0"),
+					resource.TestCheckResourceAttrPair("data.tmc_cluster_groups.test", "ids.0", "tmc_cluster_group.test.0", "uid"),
+				),
+			},
+		},
+	})
+}
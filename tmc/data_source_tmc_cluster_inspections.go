@@ -0,0 +1,158 @@
+package tmc
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// inspectionSpecFields are the fields of the spec of the inspections of each
+// type.
+var inspectionSpecFields = map[string]string{
+	tanzuclient.InspectionTypeCIS:         "spec.cisSpec",
+	tanzuclient.InspectionTypeConformance: "spec.conformanceSpec",
+	tanzuclient.InspectionTypeLite:        "spec.liteSpec",
+}
+
+func dataSourceTmcClusterInspections() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTmcClusterInspectionsRead,
+		Schema: map[string]*schema.Schema{
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Cluster the inspections were run against",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(inspectionTypes, false),
+				Description:  "Only list the inspections of this type: cis, conformance or lite",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the inspections of the Cluster",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"inspections": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Inspections of the Cluster",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the inspection",
+						},
+						"uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique ID of the inspection",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the inspection",
+						},
+						"creation_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the inspection was launched, in RFC 3339 format",
+						},
+						"phase": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Phase of the inspection, like RUNNING, COMPLETE or ERROR",
+						},
+						"passed": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of checks the Cluster passed",
+						},
+						"failed": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of checks the Cluster failed",
+						},
+						"warned": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of checks which need a manual review",
+						},
+						"report_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URL to download the detailed results of the inspection from",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTmcClusterInspectionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	managementClusterName := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	inspectionType := d.Get("type").(string)
+
+	query := tanzuclient.Query{}
+	if inspectionType != "" {
+		query = tanzuclient.Exists(inspectionSpecFields[inspectionType])
+	}
+
+	res, err := client.GetAllInspections(ctx, clusterName, managementClusterName, provisionerName, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := make([]interface{}, len(res))
+	inspections := make([]interface{}, len(res))
+
+	for i, inspection := range res {
+		names[i] = inspection.FullName.Name
+
+		item := flattenInspectionStatus(inspection.Status)
+		item["name"] = inspection.FullName.Name
+		item["uid"] = inspection.Meta.UID
+		item["type"] = inspection.Spec.Type()
+		item["creation_time"] = inspection.Meta.CreationTime
+		inspections[i] = item
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("inspections", inspections); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := buildID(managementClusterName, provisionerName, clusterName)
+	if inspectionType != "" {
+		id = buildID(id, inspectionType)
+	}
+	d.SetId(id)
+	return diags
+}
@@ -0,0 +1,67 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcClusterInspections(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.SetInspectionResult("tf-acc-lite", "", 5, 1, 0)
+
+	config := testAccResourceTmcClusterInspectionConfig(server, "tf-acc-cis", "cis", true) + `
+resource "tmc_cluster_inspection" "lite" {
+  name               = "tf-acc-lite"
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+  type               = "lite"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				Config: config + `
+data "tmc_cluster_inspections" "all" {
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+}
+
+data "tmc_cluster_inspections" "lite" {
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+  type               = "lite"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.all", "id", "aws-hosted/tf-acc/tf-acc-cluster"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.all", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.all", "names.0", "tf-acc-cis"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.all", "inspections.0.type", "cis"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.all", "inspections.0.passed", "42"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.lite", "id", "aws-hosted/tf-acc/tf-acc-cluster/lite"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.lite", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.lite", "inspections.0.name", "tf-acc-lite"),
+					resource.TestCheckResourceAttrSet("data.tmc_cluster_inspections.lite", "inspections.0.uid"),
+					resource.TestCheckResourceAttrSet("data.tmc_cluster_inspections.lite", "inspections.0.creation_time"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.lite", "inspections.0.type", "lite"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.lite", "inspections.0.phase", "COMPLETE"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.lite", "inspections.0.passed", "5"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.lite", "inspections.0.failed", "1"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_inspections.lite", "inspections.0.report_url", server.URL+"/inspection-results/tf-acc-lite.tar.gz"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,127 @@
+package tmc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func dataSourceTmcClusterNodePools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTmcClusterNodePoolsRead,
+		Schema: map[string]*schema.Schema{
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Cluster the node pools belong to",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the node pools of the Cluster",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"nodepools": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Node pools of the Cluster",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the node pool",
+						},
+						"uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique ID of the node pool",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the node pool",
+						},
+						"worker_node_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of worker nodes in the pool",
+						},
+						"node_labels": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Kubernetes labels of the worker nodes",
+						},
+						"phase": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Lifecycle phase of the node pool",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTmcClusterNodePoolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	managementClusterName := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	res, err := client.GetAllNodePools(ctx, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := make([]interface{}, len(res))
+	nodePools := make([]interface{}, len(res))
+
+	for i, nodePool := range res {
+		names[i] = nodePool.FullName.Name
+
+		item := map[string]interface{}{
+			"name":        nodePool.FullName.Name,
+			"uid":         nodePool.Meta.UID,
+			"description": nodePool.Meta.Description,
+		}
+		if nodePool.Spec != nil {
+			item["worker_node_count"], _ = strconv.Atoi(nodePool.Spec.WorkerNodeCount)
+			item["node_labels"] = nodePool.Spec.NodeLabels
+		}
+		if nodePool.Status != nil {
+			item["phase"] = nodePool.Status.Phase
+		}
+		nodePools[i] = item
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("nodepools", nodePools); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildID(managementClusterName, provisionerName, clusterName))
+	return diags
+}
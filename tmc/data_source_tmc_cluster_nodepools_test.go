@@ -0,0 +1,39 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcClusterNodePools(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterNodePoolConfig(server, 2, "") + `
+data "tmc_cluster_nodepools" "test" {
+  management_cluster = tmc_cluster_nodepool.test.management_cluster
+  provisioner_name   = tmc_cluster_nodepool.test.provisioner_name
+  cluster_name       = tmc_cluster_nodepool.test.cluster_name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_cluster_nodepools.test", "id", "vsphere-tkg/default/tf-acc-vsphere"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_nodepools.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_nodepools.test", "names.0", "tf-acc-pool"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_nodepools.test", "nodepools.0.name", "tf-acc-pool"),
+					resource.TestCheckResourceAttrPair("data.tmc_cluster_nodepools.test", "nodepools.0.uid", "tmc_cluster_nodepool.test", "uid"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_nodepools.test", "nodepools.0.description", "Workers"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_nodepools.test", "nodepools.0.worker_node_count", "2"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_nodepools.test", "nodepools.0.node_labels.role", "worker"),
+					resource.TestCheckResourceAttr("data.tmc_cluster_nodepools.test", "nodepools.0.phase", "READY"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceCluster(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-cluster"
+  description        = "read by the data source"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "tf-acc"
+
+  labels = {
+    env = "test"
+  }
+}
+
+data "tmc_cluster" "test" {
+  name               = tmc_cluster.test.name
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.tmc_cluster.test", "id", "tmc_cluster.test", "uid"),
+					resource.TestCheckResourceAttrPair("data.tmc_cluster.test", "installer_link", "tmc_cluster.test", "installer_link"),
+					resource.TestCheckResourceAttr("data.tmc_cluster.test", "description", "read by the data source"),
+					resource.TestCheckResourceAttr("data.tmc_cluster.test", "cluster_group_name", "default"),
+					resource.TestCheckResourceAttr("data.tmc_cluster.test", "labels.env", "test"),
+				),
+			},
+		},
+	})
+}
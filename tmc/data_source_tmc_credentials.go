@@ -0,0 +1,113 @@
+package tmc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func dataSourceTmcCredentials() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTmcCredentialsRead,
+		Schema: map[string]*schema.Schema{
+			"capability": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the credentials with this capability, like MANAGED_K8S_PROVIDER or DATA_PROTECTION",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the credentials of the organization",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"credentials": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Credentials of the organization",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the credential",
+						},
+						"uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Unique ID of the credential",
+						},
+						"capability": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "What TMC uses the credential for",
+						},
+						"provider_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Provider of the account the credential gives access to, like AWS_EC2 or AZURE_AKS",
+						},
+						"phase": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Phase of the credential, VALID once TMC could access the account with it",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTmcCredentialsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	query := tanzuclient.Query{}
+	if capability := d.Get("capability").(string); capability != "" {
+		query = tanzuclient.Term("spec.capability", capability)
+	}
+
+	res, err := client.GetAllCredentials(ctx, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := make([]interface{}, len(res))
+	credentials := make([]interface{}, len(res))
+
+	for i, credential := range res {
+		names[i] = credential.FullName.Name
+
+		item := map[string]interface{}{
+			"name": credential.FullName.Name,
+			"uid":  credential.Meta.UID,
+		}
+		if credential.Spec != nil {
+			item["capability"] = credential.Spec.Capability
+			if credential.Spec.Meta != nil {
+				item["provider_name"] = credential.Spec.Meta.Provider
+			}
+		}
+		if credential.Status != nil {
+			item["phase"] = credential.Status.Phase
+		}
+		credentials[i] = item
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("credentials", credentials); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(time.Now().String())
+
+	return diags
+}
@@ -0,0 +1,57 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcCredentials(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_credential" "aws" {
+  name = "tf-acc-aws"
+
+  aws_iam_role {
+    role_arn = "arn:aws:iam::123456789012:role/tf-acc-tmc"
+  }
+}
+
+resource "tmc_credential" "backups" {
+  name = "tf-acc-backups"
+
+  s3 {
+    access_key_id     = "tf-acc-key"
+    secret_access_key = "tf-acc-secret"
+  }
+}
+
+data "tmc_credentials" "all" {
+  depends_on = [tmc_credential.aws, tmc_credential.backups]
+}
+
+data "tmc_credentials" "data_protection" {
+  capability = "DATA_PROTECTION"
+  depends_on = [tmc_credential.aws, tmc_credential.backups]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_credentials.all", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.tmc_credentials.data_protection", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_credentials.data_protection", "names.0", "tf-acc-backups"),
+					resource.TestCheckResourceAttr("data.tmc_credentials.data_protection", "credentials.0.capability", "DATA_PROTECTION"),
+					resource.TestCheckResourceAttr("data.tmc_credentials.data_protection", "credentials.0.provider_name", "GENERIC_S3"),
+					resource.TestCheckResourceAttr("data.tmc_credentials.data_protection", "credentials.0.phase", "VALID"),
+					resource.TestCheckResourceAttrPair("data.tmc_credentials.data_protection", "credentials.0.uid", "tmc_credential.backups", "uid"),
+				),
+			},
+		},
+	})
+}
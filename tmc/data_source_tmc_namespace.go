@@ -0,0 +1,84 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func dataSourceTmcNamespace() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTmcNamespaceRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the Namespace",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Namespace",
+			},
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Cluster the Namespace belongs to",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the Namespace",
+			},
+			"workspace_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the Tanzu Workspace the Namespace belongs to",
+			},
+			"labels": labelsSchemaComputed(),
+		},
+	}
+}
+
+func dataSourceTmcNamespaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	namespace, err := client.GetNamespace(ctx, d.Get("name").(string), d.Get("cluster_name").(string), d.Get("management_cluster").(string), d.Get("provisioner_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("description", namespace.Meta.Description)
+
+	if namespace.Spec != nil {
+		d.Set("workspace_name", namespace.Spec.WorkspaceName)
+	}
+
+	if err := d.Set("labels", namespace.Meta.Labels); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read namespace",
+			Detail:   fmt.Sprintf("Error setting labels for resource %s: %s", d.Get("name"), err),
+		})
+		return diags
+	}
+	d.SetId(namespace.Meta.UID)
+
+	return diags
+}
@@ -0,0 +1,35 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcNamespace(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcNamespaceConfig(server, "tmc_workspace.first", false) + `
+data "tmc_namespace" "test" {
+  name               = tmc_namespace.test.name
+  management_cluster = tmc_namespace.test.management_cluster
+  provisioner_name   = tmc_namespace.test.provisioner_name
+  cluster_name       = tmc_namespace.test.cluster_name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.tmc_namespace.test", "id", "tmc_namespace.test", "uid"),
+					resource.TestCheckResourceAttr("data.tmc_namespace.test", "description", "Namespace of the team"),
+					resource.TestCheckResourceAttr("data.tmc_namespace.test", "workspace_name", "tf-acc-first"),
+					resource.TestCheckResourceAttr("data.tmc_namespace.test", "labels.team", "test"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package tmc
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func dataSourceTmcNamespaces() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTmcNamespacesRead,
+		Schema: map[string]*schema.Schema{
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Cluster the Namespaces belong to",
+			},
+			"workspace_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the Tanzu Workspace the returned Namespaces have to belong to",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the Namespaces of the Cluster",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "UID of the Namespaces of the Cluster",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"labels": labelsSchema(),
+			"filter": filterSchema(),
+		},
+	}
+}
+
+func dataSourceTmcNamespacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	managementClusterName := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	query, err := buildSearchQuery(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if workspaceName := d.Get("workspace_name").(string); workspaceName != "" {
+		query = tanzuclient.And(query, tanzuclient.Term("spec.workspaceName", workspaceName))
+	}
+
+	res, err := client.GetAllNamespaces(ctx, clusterName, managementClusterName, provisionerName, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespaceNames := make([]interface{}, len(res))
+	namespaceIds := make([]interface{}, len(res))
+
+	for i, namespace := range res {
+		namespaceNames[i] = namespace.FullName.Name
+		namespaceIds[i] = namespace.Meta.UID
+	}
+
+	if err := d.Set("names", namespaceNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ids", namespaceIds); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildID(managementClusterName, provisionerName, clusterName))
+	return diags
+}
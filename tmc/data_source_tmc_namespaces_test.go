@@ -0,0 +1,70 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcNamespaces(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	config := testAccResourceTmcNamespaceConfig(server, "tmc_workspace.first", false) + `
+resource "tmc_namespace" "other" {
+  name               = "tf-acc-other"
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+  workspace_name     = tmc_workspace.second.name
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+data "tmc_namespaces" "all" {
+  management_cluster = tmc_namespace.test.management_cluster
+  provisioner_name   = tmc_namespace.test.provisioner_name
+  cluster_name       = tmc_namespace.test.cluster_name
+
+  depends_on = [tmc_namespace.other]
+}
+
+data "tmc_namespaces" "workspace" {
+  management_cluster = tmc_namespace.test.management_cluster
+  provisioner_name   = tmc_namespace.test.provisioner_name
+  cluster_name       = tmc_namespace.test.cluster_name
+  workspace_name     = tmc_workspace.second.name
+
+  depends_on = [tmc_namespace.other]
+}
+
+data "tmc_namespaces" "labels" {
+  management_cluster = tmc_namespace.test.management_cluster
+  provisioner_name   = tmc_namespace.test.provisioner_name
+  cluster_name       = tmc_namespace.test.cluster_name
+
+  labels = {
+    team = "test"
+  }
+
+  depends_on = [tmc_namespace.other]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_namespaces.all", "id", "aws-hosted/tf-acc/tf-acc-cluster"),
+					resource.TestCheckResourceAttr("data.tmc_namespaces.all", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.tmc_namespaces.workspace", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_namespaces.workspace", "names.0", "tf-acc-other"),
+					resource.TestCheckResourceAttrPair("data.tmc_namespaces.workspace", "ids.0", "tmc_namespace.other", "uid"),
+					resource.TestCheckResourceAttr("data.tmc_namespaces.labels", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_namespaces.labels", "names.0", "tf-acc-namespace"),
+				),
+			},
+		},
+	})
+}
@@ -40,7 +40,7 @@ func dataSourceTmcProvisionerRead(ctx context.Context, d *schema.ResourceData, m
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	provisioner, err := client.GetProvisioner(d.Get("management_cluster_name").(string), d.Get("name").(string))
+	provisioner, err := client.GetProvisioner(ctx, d.Get("management_cluster_name").(string), d.Get("name").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,33 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcProvisioner(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddProvisioner("aws-hosted", "tf-acc", map[string]string{"env": "test"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "tmc_provisioner" "test" {
+  management_cluster_name = "aws-hosted"
+  name                    = "tf-acc"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.tmc_provisioner.test", "id"),
+					resource.TestCheckResourceAttr("data.tmc_provisioner.test", "labels.env", "test"),
+				),
+			},
+		},
+	})
+}
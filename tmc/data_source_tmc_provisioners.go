@@ -27,12 +27,12 @@ func dataSourceTmcProvisioners() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"management_cluster_name": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeString,
 				Required:    true,
 				Description: "Management Cluster Name of the Tanzu Provisioners",
-				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"labels": labelsSchema(),
+			"filter": filterSchema(),
 		},
 	}
 }
@@ -43,10 +43,14 @@ func dataSourceTmcProvisionersRead(ctx context.Context, d *schema.ResourceData,
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	labels := d.Get("labels").(map[string]interface{})
 	mgmtClusterName := d.Get("management_cluster_name").(string)
 
-	res, err := client.GetAllProvisioners(mgmtClusterName, labels)
+	query, err := buildSearchQuery(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := client.GetAllProvisioners(ctx, mgmtClusterName, query)
 	if err != nil {
 		return diag.FromErr(err)
 	}
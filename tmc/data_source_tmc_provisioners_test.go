@@ -0,0 +1,50 @@
+package tmc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcProvisioners(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	// More provisioners than fit on a single page of results
+	for i := 0; i < 250; i++ {
+		env := "test"
+		if i%5 == 0 {
+			env = "prod"
+		}
+		server.AddProvisioner("aws-hosted", fmt.Sprintf("tf-acc-%03d", i), map[string]string{"env": env})
+	}
+	server.AddProvisioner("vsphere", "tf-acc-other", nil)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "tmc_provisioners" "all" {
+  management_cluster_name = "aws-hosted"
+}
+
+data "tmc_provisioners" "prod" {
+  management_cluster_name = "aws-hosted"
+
+  labels = {
+    env = "prod"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_provisioners.all", "names.#", "250"),
+					resource.TestCheckResourceAttr("data.tmc_provisioners.all", "names.249", "tf-acc-249"),
+					resource.TestCheckResourceAttr("data.tmc_provisioners.prod", "names.#", "50"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,128 @@
+package tmc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func dataSourceTmcTkgsOptions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTmcTkgsOptionsRead,
+		Schema: map[string]*schema.Schema{
+			"management_cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the vSphere with Tanzu Management Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Tanzu Provisioner, which is the vSphere namespace clusters are provisioned in",
+			},
+			"vm_classes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Virtual machine classes available to the nodes of the clusters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the virtual machine class",
+						},
+						"cpu_cores": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of CPU cores of the virtual machines",
+						},
+						"memory": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Memory of the virtual machines, as a Kubernetes quantity",
+						},
+					},
+				},
+			},
+			"storage_classes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Storage classes available to the nodes and volumes of the clusters",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tkr_versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Tanzu Kubernetes releases the clusters can be created with",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceTmcTkgsOptionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	mgmtClusterName := d.Get("management_cluster_name").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	vmClasses, err := client.GetAllVirtualMachineClasses(ctx, mgmtClusterName, provisionerName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	storageClasses, err := client.GetAllStorageClasses(ctx, mgmtClusterName, provisionerName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	releases, err := client.GetAllTanzuKubernetesReleases(ctx, mgmtClusterName, provisionerName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vmClassList := make([]interface{}, len(vmClasses))
+	for i, class := range vmClasses {
+		cpuCores, _ := strconv.Atoi(class.Spec.HardwareConfig.CPUCores)
+
+		vmClassList[i] = map[string]interface{}{
+			"name":      class.FullName.Name,
+			"cpu_cores": cpuCores,
+			"memory":    class.Spec.HardwareConfig.Memory,
+		}
+	}
+
+	storageClassNames := make([]interface{}, len(storageClasses))
+	for i, class := range storageClasses {
+		storageClassNames[i] = class.FullName.Name
+	}
+
+	// Releases the supervisor cluster is too old to deploy are left out
+	tkrVersions := make([]interface{}, 0, len(releases))
+	for _, release := range releases {
+		if release.Status.Compatible {
+			tkrVersions = append(tkrVersions, release.Spec.Version)
+		}
+	}
+
+	if err := d.Set("vm_classes", vmClassList); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("storage_classes", storageClassNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tkr_versions", tkrVersions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildID(mgmtClusterName, provisionerName))
+
+	return diags
+}
@@ -0,0 +1,44 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcTkgsOptions(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddVirtualMachineClass("supervisor", "tf-acc-namespace", "best-effort-small", 2, "4Gi")
+	server.AddStorageClass("supervisor", "tf-acc-namespace", "vsan-default-storage-policy")
+	server.AddTanzuKubernetesRelease("supervisor", "tf-acc-namespace", "v1.20.7+vmware.1-tkg.1.7fb9067", true)
+	server.AddTanzuKubernetesRelease("supervisor", "tf-acc-namespace", "v1.22.9+vmware.1-tkg.1.cc71bc8", false)
+	server.AddStorageClass("supervisor", "other-namespace", "gold")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "tmc_tkgs_options" "test" {
+  management_cluster_name = "supervisor"
+  provisioner_name        = "tf-acc-namespace"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "id", "supervisor/tf-acc-namespace"),
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "vm_classes.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "vm_classes.0.name", "best-effort-small"),
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "vm_classes.0.cpu_cores", "2"),
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "vm_classes.0.memory", "4Gi"),
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "storage_classes.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "storage_classes.0", "vsan-default-storage-policy"),
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "tkr_versions.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_tkgs_options.test", "tkr_versions.0", "v1.20.7+vmware.1-tkg.1.7fb9067"),
+				),
+			},
+		},
+	})
+}
@@ -39,7 +39,7 @@ func dataSourceTmcWorkspaceRead(ctx context.Context, d *schema.ResourceData, met
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	workspace, err := client.GetWorkspace(d.Get("name").(string))
+	workspace, err := client.GetWorkspace(ctx, d.Get("name").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,40 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcWorkspace(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_workspace" "test" {
+  name        = "tf-acc-workspace"
+  description = "read by the data source"
+
+  labels = {
+    env = "test"
+  }
+}
+
+data "tmc_workspace" "test" {
+  name = tmc_workspace.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.tmc_workspace.test", "id", "tmc_workspace.test", "uid"),
+					resource.TestCheckResourceAttr("data.tmc_workspace.test", "description", "read by the data source"),
+					resource.TestCheckResourceAttr("data.tmc_workspace.test", "labels.env", "test"),
+				),
+			},
+		},
+	})
+}
@@ -26,6 +26,7 @@ func dataSourceTmcWorkspaces() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"labels": labelsSchema(),
+			"filter": filterSchema(),
 		},
 	}
 }
@@ -36,9 +37,12 @@ func dataSourceTmcWorkspacesRead(ctx context.Context, d *schema.ResourceData, me
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	labels := d.Get("labels").(map[string]interface{})
+	query, err := buildSearchQuery(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	res, err := client.GetAllWorkspaces(labels)
+	res, err := client.GetAllWorkspaces(ctx, query)
 	if err != nil {
 		return diag.FromErr(err)
 	}
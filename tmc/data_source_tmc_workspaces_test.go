@@ -0,0 +1,82 @@
+package tmc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccDataSourceTmcWorkspaces(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_workspace" "test" {
+  count = 3
+  name  = "tf-acc-workspace-${count.index}"
+
+  labels = {
+    env  = count.index == 0 ? "prod" : "test"
+    team = "platform & tools"
+  }
+}
+
+resource "tmc_workspace" "unlabelled" {
+  name = "tf-acc-workspace-unlabelled"
+}
+
+data "tmc_workspaces" "all" {
+  depends_on = [tmc_workspace.test, tmc_workspace.unlabelled]
+}
+
+data "tmc_workspaces" "test" {
+  depends_on = [tmc_workspace.test, tmc_workspace.unlabelled]
+
+  labels = {
+    team = "platform & tools"
+  }
+
+  filter {
+    label    = "env"
+    operator = "not_equals"
+    values   = ["prod"]
+  }
+}
+
+data "tmc_workspaces" "unlabelled" {
+  depends_on = [tmc_workspace.test, tmc_workspace.unlabelled]
+
+  filter {
+    label    = "team"
+    operator = "not_exists"
+  }
+}
+
+data "tmc_workspaces" "wildcard" {
+  depends_on = [tmc_workspace.test, tmc_workspace.unlabelled]
+
+  filter {
+    label    = "team"
+    operator = "wildcard"
+    values   = ["platform*"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tmc_workspaces.all", "names.#", "4"),
+					resource.TestCheckResourceAttr("data.tmc_workspaces.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.tmc_workspaces.test", "names.0", "tf-acc-workspace-1"),
+					resource.TestCheckResourceAttr("data.tmc_workspaces.test", "names.1", "tf-acc-workspace-2"),
+					resource.TestCheckResourceAttr("data.tmc_workspaces.unlabelled", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.tmc_workspaces.unlabelled", "names.0", "tf-acc-workspace-unlabelled"),
+					resource.TestCheckResourceAttr("data.tmc_workspaces.wildcard", "ids.#", "3"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package tmc
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+const (
+	filterOperatorEquals    = "equals"
+	filterOperatorNotEquals = "not_equals"
+	filterOperatorWildcard  = "wildcard"
+	filterOperatorExists    = "exists"
+	filterOperatorNotExists = "not_exists"
+)
+
+// filterSchema returns the schema to use for label filters of list data
+// sources. All the filters have to match for an object to be returned.
+func filterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Label filter the returned objects have to match",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"label": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Key of the label to filter on",
+				},
+				"values": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Values of the label, any of which matches the filter",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"operator": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  filterOperatorEquals,
+					ValidateFunc: validation.StringInSlice([]string{
+						filterOperatorEquals,
+						filterOperatorNotEquals,
+						filterOperatorWildcard,
+						filterOperatorExists,
+						filterOperatorNotExists,
+					}, false),
+					Description: "How the label is compared to the values, one of equals, not_equals, wildcard, exists or not_exists",
+				},
+			},
+		},
+	}
+}
+
+// buildSearchQuery combines the labels and filter arguments of a list data
+// source into a single search query.
+func buildSearchQuery(d *schema.ResourceData) (tanzuclient.Query, error) {
+	queries := []tanzuclient.Query{
+		tanzuclient.LabelsQuery(d.Get("labels").(map[string]interface{})),
+	}
+
+	for _, f := range d.Get("filter").([]interface{}) {
+		filter := f.(map[string]interface{})
+
+		label := filter["label"].(string)
+		operator := filter["operator"].(string)
+		field := tanzuclient.LabelField(label)
+
+		var values []tanzuclient.Query
+		for _, v := range filter["values"].([]interface{}) {
+			value, _ := v.(string)
+
+			switch operator {
+			case filterOperatorWildcard:
+				values = append(values, tanzuclient.Wildcard(field, value))
+			default:
+				values = append(values, tanzuclient.Term(field, value))
+			}
+		}
+
+		switch operator {
+		case filterOperatorExists:
+			queries = append(queries, tanzuclient.Exists(field))
+			continue
+		case filterOperatorNotExists:
+			queries = append(queries, tanzuclient.Not(tanzuclient.Exists(field)))
+			continue
+		}
+
+		if len(values) == 0 {
+			return tanzuclient.Query{}, fmt.Errorf("filter on label %s requires at least one value with the %s operator", label, operator)
+		}
+
+		if operator == filterOperatorNotEquals {
+			queries = append(queries, tanzuclient.Not(tanzuclient.Or(values...)))
+		} else {
+			queries = append(queries, tanzuclient.Or(values...))
+		}
+	}
+
+	return tanzuclient.And(queries...), nil
+}
@@ -0,0 +1,215 @@
+package tmc
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// iamScopeAttribute is an argument identifying the object of an access
+// policy.
+type iamScopeAttribute struct {
+	name        string
+	description string
+}
+
+// iamScope describes a kind of object access policies are attached to. The
+// IAM resources of every kind of object share their implementation.
+type iamScope struct {
+	// object is the kind of object, as used in descriptions.
+	object string
+	// attributes identify the object, in the order of the parts of the
+	// resource IDs.
+	attributes []iamScopeAttribute
+	scope      func(values []string) tanzuclient.IAMScope
+}
+
+var (
+	organizationIAMScope = iamScope{
+		object: "organization",
+		scope: func([]string) tanzuclient.IAMScope {
+			return tanzuclient.OrganizationIAMScope()
+		},
+	}
+	clusterGroupIAMScope = iamScope{
+		object: "cluster group",
+		attributes: []iamScopeAttribute{
+			{"cluster_group_name", "Name of the cluster group"},
+		},
+		scope: func(values []string) tanzuclient.IAMScope {
+			return tanzuclient.ClusterGroupIAMScope(values[0])
+		},
+	}
+	workspaceIAMScope = iamScope{
+		object: "Tanzu Workspace",
+		attributes: []iamScopeAttribute{
+			{"workspace_name", "Name of the Tanzu Workspace"},
+		},
+		scope: func(values []string) tanzuclient.IAMScope {
+			return tanzuclient.WorkspaceIAMScope(values[0])
+		},
+	}
+	clusterIAMScope = iamScope{
+		object: "Cluster",
+		attributes: []iamScopeAttribute{
+			{"management_cluster", "Name of the management cluster of the Cluster"},
+			{"provisioner_name", "Name of the provisioner of the Cluster"},
+			{"cluster_name", "Name of the Cluster"},
+		},
+		scope: func(values []string) tanzuclient.IAMScope {
+			return tanzuclient.ClusterIAMScope(values[0], values[1], values[2])
+		},
+	}
+	namespaceIAMScope = iamScope{
+		object: "Namespace",
+		attributes: []iamScopeAttribute{
+			{"management_cluster", "Name of the management cluster of the Cluster"},
+			{"provisioner_name", "Name of the provisioner of the Cluster"},
+			{"cluster_name", "Name of the Cluster the Namespace belongs to"},
+			{"namespace_name", "Name of the Namespace"},
+		},
+		scope: func(values []string) tanzuclient.IAMScope {
+			return tanzuclient.NamespaceIAMScope(values[0], values[1], values[2], values[3])
+		},
+	}
+)
+
+// schema returns the arguments identifying the object.
+func (s iamScope) schema() map[string]*schema.Schema {
+	attributes := map[string]*schema.Schema{}
+
+	for _, attribute := range s.attributes {
+		attributes[attribute.name] = &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: attribute.description,
+		}
+	}
+
+	return attributes
+}
+
+// idFormat returns the format of the IDs of the resources of the scope,
+// followed by the given parts.
+func (s iamScope) idFormat(parts ...string) string {
+	names := []string{"organization"}
+	if len(s.attributes) > 0 {
+		names = names[:0]
+		for _, attribute := range s.attributes {
+			names = append(names, attribute.name)
+		}
+	}
+
+	return buildID(append(names, parts...)...)
+}
+
+// values returns the values of the arguments identifying the object.
+func (s iamScope) values(d *schema.ResourceData) []string {
+	values := make([]string, 0, len(s.attributes))
+	for _, attribute := range s.attributes {
+		values = append(values, d.Get(attribute.name).(string))
+	}
+
+	return values
+}
+
+// idParts returns the parts of the ID of the resources of the scope, which
+// is a single "organization" part for the organization.
+func (s iamScope) idParts(d *schema.ResourceData) []string {
+	if len(s.attributes) == 0 {
+		return []string{"organization"}
+	}
+
+	return s.values(d)
+}
+
+// importID sets the arguments identifying the object from the ID of a
+// resource of the scope, and returns the remaining parts of the ID. The last
+// of them may contain slashes, as the names of users and groups can.
+func (s iamScope) importID(d *schema.ResourceData, parts ...string) ([]string, error) {
+	parse := parseID
+	if len(parts) > 0 {
+		parse = parseIDWithTail
+	}
+
+	values, err := parse(d.Id(), s.idFormat(parts...))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.attributes) == 0 {
+		return values[1:], nil
+	}
+
+	for i, attribute := range s.attributes {
+		d.Set(attribute.name, values[i])
+	}
+
+	return values[len(s.attributes):], nil
+}
+
+// iamSubjectKinds maps the kinds of subjects of role bindings to the
+// attributes listing them.
+var iamSubjectKinds = map[string]string{
+	tanzuclient.SubjectKindUser:  "users",
+	tanzuclient.SubjectKindGroup: "groups",
+}
+
+func expandRoleBindings(data []interface{}) []tanzuclient.RoleBinding {
+	roleBindings := make([]tanzuclient.RoleBinding, 0, len(data))
+
+	for _, b := range data {
+		binding := b.(map[string]interface{})
+
+		roleBinding := tanzuclient.RoleBinding{
+			Role: binding["role"].(string),
+		}
+		for _, kind := range []string{tanzuclient.SubjectKindUser, tanzuclient.SubjectKindGroup} {
+			names := expandStringList(binding[iamSubjectKinds[kind]].(*schema.Set).List())
+			sort.Strings(names)
+
+			for _, name := range names {
+				roleBinding.Subjects = append(roleBinding.Subjects, tanzuclient.Subject{Name: name, Kind: kind})
+			}
+		}
+
+		roleBindings = append(roleBindings, roleBinding)
+	}
+
+	return roleBindings
+}
+
+func flattenRoleBindings(roleBindings []tanzuclient.RoleBinding) []interface{} {
+	// TMC may split the subjects of a role over several bindings
+	byRole := map[string]map[string][]interface{}{}
+	var roles []string
+
+	for _, roleBinding := range roleBindings {
+		subjects, ok := byRole[roleBinding.Role]
+		if !ok {
+			subjects = map[string][]interface{}{"users": {}, "groups": {}}
+			byRole[roleBinding.Role] = subjects
+			roles = append(roles, roleBinding.Role)
+		}
+
+		for _, subject := range roleBinding.Subjects {
+			if attribute, ok := iamSubjectKinds[strings.ToUpper(subject.Kind)]; ok {
+				subjects[attribute] = append(subjects[attribute], subject.Name)
+			}
+		}
+	}
+
+	bindings := make([]interface{}, 0, len(roles))
+	for _, role := range roles {
+		bindings = append(bindings, map[string]interface{}{
+			"role":   role,
+			"users":  byRole[role]["users"],
+			"groups": byRole[role]["groups"],
+		})
+	}
+
+	return bindings
+}
@@ -0,0 +1,39 @@
+package tmc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// buildID joins the parts identifying an object into a resource ID.
+func buildID(parts ...string) string {
+	return strings.Join(parts, "/")
+}
+
+// parseID splits a resource ID built by buildID back into its parts. format
+// describes the expected ID in error messages, e.g. "management_cluster/name".
+func parseID(id string, format string) ([]string, error) {
+	return splitID(id, format, strings.Split(id, "/"))
+}
+
+// parseIDWithTail is parseID for IDs whose last part may contain slashes
+// itself, like the names of the users and groups of role bindings.
+func parseIDWithTail(id string, format string) ([]string, error) {
+	return splitID(id, format, strings.SplitN(id, "/", strings.Count(format, "/")+1))
+}
+
+func splitID(id string, format string, parts []string) ([]string, error) {
+	expected := strings.Count(format, "/") + 1
+
+	if len(parts) != expected {
+		return nil, fmt.Errorf("unexpected ID %q, expected %s", id, format)
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("unexpected ID %q, expected %s", id, format)
+		}
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,255 @@
+package tmc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// policyScopeKind is a kind of object policies are attached to.
+type policyScopeKind struct {
+	// prefix is the first part of the IDs of the policies of the kind.
+	prefix string
+	// attributes identify the object, in the order of the parts of the IDs.
+	attributes []string
+	scope      func(values []string) tanzuclient.PolicyScope
+}
+
+// policyScopeKinds are ordered from the most specific kind of object, since
+// the arguments of namespaces include the ones of clusters.
+var policyScopeKinds = []policyScopeKind{
+	{
+		prefix:     "namespace",
+		attributes: []string{"management_cluster", "provisioner_name", "cluster_name", "namespace_name"},
+		scope: func(values []string) tanzuclient.PolicyScope {
+			return tanzuclient.NamespacePolicyScope(values[0], values[1], values[2], values[3])
+		},
+	},
+	{
+		prefix:     "cluster",
+		attributes: []string{"management_cluster", "provisioner_name", "cluster_name"},
+		scope: func(values []string) tanzuclient.PolicyScope {
+			return tanzuclient.ClusterPolicyScope(values[0], values[1], values[2])
+		},
+	},
+	{
+		prefix:     "workspace",
+		attributes: []string{"workspace_name"},
+		scope: func(values []string) tanzuclient.PolicyScope {
+			return tanzuclient.WorkspacePolicyScope(values[0])
+		},
+	},
+	{
+		prefix:     "cluster_group",
+		attributes: []string{"cluster_group_name"},
+		scope: func(values []string) tanzuclient.PolicyScope {
+			return tanzuclient.ClusterGroupPolicyScope(values[0])
+		},
+	},
+}
+
+// idFormat returns the format of the IDs of the policies of the kind.
+func (k policyScopeKind) idFormat() string {
+	return buildID(append(append([]string{k.prefix}, k.attributes...), "name")...)
+}
+
+func (k policyScopeKind) values(d *schema.ResourceData) []string {
+	values := make([]string, 0, len(k.attributes))
+	for _, attribute := range k.attributes {
+		values = append(values, d.Get(attribute).(string))
+	}
+
+	return values
+}
+
+// policyScopeSchema returns the arguments selecting the object a policy is
+// attached to, exactly one of a cluster group, a workspace, a cluster or a
+// namespace of a cluster.
+func policyScopeSchema() map[string]*schema.Schema {
+	scopes := []string{"cluster_group_name", "workspace_name", "cluster_name"}
+	cluster := []string{"management_cluster", "provisioner_name", "cluster_name"}
+
+	return map[string]*schema.Schema{
+		"cluster_group_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: scopes,
+			Description:  "Name of the cluster group the policy is attached to",
+		},
+		"workspace_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: scopes,
+			Description:  "Name of the Tanzu Workspace the policy is attached to",
+		},
+		"management_cluster": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: cluster,
+			Description:  "Name of the management cluster of the Cluster the policy is attached to",
+		},
+		"provisioner_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: cluster,
+			Description:  "Name of the provisioner of the Cluster the policy is attached to",
+		},
+		"cluster_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: scopes,
+			RequiredWith: cluster,
+			Description:  "Name of the Cluster the policy is attached to",
+		},
+		"namespace_name": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"cluster_group_name", "workspace_name"},
+			Description:   "Name of the Namespace of the Cluster the policy is attached to",
+		},
+	}
+}
+
+// policyScopeKindOf returns the kind of object the policy is attached to.
+func policyScopeKindOf(d *schema.ResourceData) policyScopeKind {
+	for _, kind := range policyScopeKinds {
+		if d.Get(kind.attributes[len(kind.attributes)-1]).(string) != "" {
+			return kind
+		}
+	}
+
+	// The schema requires one of the objects to be set.
+	return policyScopeKinds[len(policyScopeKinds)-1]
+}
+
+// policyScope returns the object the policy is attached to.
+func policyScope(d *schema.ResourceData) tanzuclient.PolicyScope {
+	kind := policyScopeKindOf(d)
+
+	return kind.scope(kind.values(d))
+}
+
+// policyID returns the ID of the policy, made of the kind of object it is
+// attached to, the names identifying the object and its own name.
+func policyID(d *schema.ResourceData) string {
+	kind := policyScopeKindOf(d)
+
+	parts := append([]string{kind.prefix}, kind.values(d)...)
+
+	return buildID(append(parts, d.Get("name").(string))...)
+}
+
+// importPolicyID sets the arguments identifying the policy from its ID.
+func importPolicyID(d *schema.ResourceData) error {
+	prefix := strings.SplitN(d.Id(), "/", 2)[0]
+
+	formats := make([]string, 0, len(policyScopeKinds))
+	for _, kind := range policyScopeKinds {
+		formats = append(formats, kind.idFormat())
+		if kind.prefix != prefix {
+			continue
+		}
+
+		parts, err := parseID(d.Id(), kind.idFormat())
+		if err != nil {
+			return err
+		}
+		for i, attribute := range kind.attributes {
+			d.Set(attribute, parts[i+1])
+		}
+		d.Set("name", parts[len(parts)-1])
+
+		return nil
+	}
+
+	return fmt.Errorf("unexpected ID %q, expected one of %s", d.Id(), strings.Join(formats, ", "))
+}
+
+func namespaceSelectorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Restricts the namespaces the policy applies to, by their labels",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"match_expression": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Description: "Requirements on the labels of the namespaces, which all have to be met",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"key": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Label key the requirement applies to",
+							},
+							"operator": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringInSlice([]string{"In", "NotIn", "Exists", "DoesNotExist"}, false),
+								Description:  "Relationship of the key to the values, one of In, NotIn, Exists and DoesNotExist",
+							},
+							"values": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Description: "Values of the label, for the In and NotIn operators",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandNamespaceSelector(data []interface{}) *tanzuclient.NamespaceSelector {
+	if len(data) == 0 || data[0] == nil {
+		return nil
+	}
+
+	selector := &tanzuclient.NamespaceSelector{}
+	for _, e := range data[0].(map[string]interface{})["match_expression"].([]interface{}) {
+		expression := e.(map[string]interface{})
+
+		selector.MatchExpressions = append(selector.MatchExpressions, tanzuclient.LabelSelectorRequirement{
+			Key:      expression["key"].(string),
+			Operator: expression["operator"].(string),
+			Values:   expandStringList(expression["values"].([]interface{})),
+		})
+	}
+
+	return selector
+}
+
+func flattenNamespaceSelector(selector *tanzuclient.NamespaceSelector) []interface{} {
+	if selector == nil || len(selector.MatchExpressions) == 0 {
+		return nil
+	}
+
+	expressions := make([]interface{}, 0, len(selector.MatchExpressions))
+	for _, expression := range selector.MatchExpressions {
+		expressions = append(expressions, map[string]interface{}{
+			"key":      expression.Key,
+			"operator": expression.Operator,
+			"values":   expression.Values,
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"match_expression": expressions,
+		},
+	}
+}
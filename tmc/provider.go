@@ -2,9 +2,11 @@ package tmc
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
 )
 
@@ -27,24 +29,77 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("TMC_ORG_URL", nil),
 				Description: descriptions["org_url"],
 			},
+			"csp_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TMC_CSP_URL", tanzuclient.DefaultCSPURL),
+				Description: descriptions["csp_url"],
+			},
+			"max_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TMC_MAX_ATTEMPTS", tanzuclient.DefaultMaxAttempts),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  descriptions["max_attempts"],
+			},
+			"max_retry_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TMC_MAX_RETRY_WAIT", int(tanzuclient.DefaultMaxRetryWait/time.Second)),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  descriptions["max_retry_wait"],
+			},
 		},
 
 		// List of Data sources supported by the provider
 		DataSourcesMap: map[string]*schema.Resource{
-			"tmc_workspace":      dataSourceTmcWorkspace(),
-			"tmc_workspaces":     dataSourceTmcWorkspaces(),
-			"tmc_cluster_group":  dataSourceClusterGroup(),
-			"tmc_cluster_groups": dataSourceClusterGroups(),
-			"tmc_cluster":        dataSourceCluster(),
-			"tmc_provisioners":   dataSourceTmcProvisioners(),
-			"tmc_provisioner":    dataSourceTmcProvisioner(),
+			"tmc_workspace":           dataSourceTmcWorkspace(),
+			"tmc_workspaces":          dataSourceTmcWorkspaces(),
+			"tmc_cluster_group":       dataSourceClusterGroup(),
+			"tmc_cluster_groups":      dataSourceClusterGroups(),
+			"tmc_cluster":             dataSourceCluster(),
+			"tmc_provisioners":        dataSourceTmcProvisioners(),
+			"tmc_provisioner":         dataSourceTmcProvisioner(),
+			"tmc_tkgs_options":        dataSourceTmcTkgsOptions(),
+			"tmc_cluster_nodepools":   dataSourceTmcClusterNodePools(),
+			"tmc_namespace":           dataSourceTmcNamespace(),
+			"tmc_namespaces":          dataSourceTmcNamespaces(),
+			"tmc_backups":             dataSourceTmcBackups(),
+			"tmc_credentials":         dataSourceTmcCredentials(),
+			"tmc_cluster_inspections": dataSourceTmcClusterInspections(),
 		},
 
 		// List of Resources supported by the provider
 		ResourcesMap: map[string]*schema.Resource{
-			"tmc_workspace":     resourceTmcWorkspace(),
-			"tmc_cluster_group": resourceClusterGroup(),
-			"tmc_cluster":       resourceTmcCluster(),
+			"tmc_workspace":                resourceTmcWorkspace(),
+			"tmc_cluster_group":            resourceClusterGroup(),
+			"tmc_cluster":                  resourceTmcCluster(),
+			"tmc_cluster_attachment":       resourceTmcClusterAttachment(),
+			"tmc_cluster_nodepool":         resourceTmcClusterNodePool(),
+			"tmc_namespace":                resourceTmcNamespace(),
+			"tmc_organization_iam_policy":  resourceTmcIAMPolicy(organizationIAMScope),
+			"tmc_organization_iam_member":  resourceTmcIAMMember(organizationIAMScope),
+			"tmc_cluster_group_iam_policy": resourceTmcIAMPolicy(clusterGroupIAMScope),
+			"tmc_cluster_group_iam_member": resourceTmcIAMMember(clusterGroupIAMScope),
+			"tmc_workspace_iam_policy":     resourceTmcIAMPolicy(workspaceIAMScope),
+			"tmc_workspace_iam_member":     resourceTmcIAMMember(workspaceIAMScope),
+			"tmc_cluster_iam_policy":       resourceTmcIAMPolicy(clusterIAMScope),
+			"tmc_cluster_iam_member":       resourceTmcIAMMember(clusterIAMScope),
+			"tmc_namespace_iam_policy":     resourceTmcIAMPolicy(namespaceIAMScope),
+			"tmc_namespace_iam_member":     resourceTmcIAMMember(namespaceIAMScope),
+			"tmc_policy":                   resourceTmcPolicy(),
+			"tmc_policy_template":          resourceTmcPolicyTemplate(),
+			"tmc_custom_policy":            resourceTmcCustomPolicy(),
+			"tmc_data_protection":          resourceTmcDataProtection(),
+			"tmc_backup_location":          resourceTmcBackupLocation(),
+			"tmc_backup_schedule":          resourceTmcBackupSchedule(),
+			"tmc_backup_restore":           resourceTmcBackupRestore(),
+			"tmc_credential":               resourceTmcCredential(),
+			"tmc_cluster_integration":      resourceTmcClusterIntegration(),
+			"tmc_cluster_inspection":       resourceTmcClusterInspection(),
+			"tmc_continuous_delivery":      resourceTmcContinuousDelivery(),
+			"tmc_git_repository":           resourceTmcGitRepository(),
+			"tmc_kustomization":            resourceTmcKustomization(),
 		},
 	}
 
@@ -63,27 +118,37 @@ func init() {
 			"defaults to the environment variable TMC_API_TOKEN",
 		"org_url": "VMware Cloud Console Service URL unique to your organization. If not set,\n" +
 			"defaults to the environment variable TMC_ORG_URL",
+		"csp_url": "Base URL of the VMware Cloud Services Console used to authorize the API_TOKEN. If not set,\n" +
+			"defaults to the environment variable TMC_CSP_URL or https://console.cloud.vmware.com",
+		"max_attempts": "Number of times a request to Tanzu Mission Control is attempted before a transient failure\n" +
+			"is reported. If not set, defaults to the environment variable TMC_MAX_ATTEMPTS or 5",
+		"max_retry_wait": "Maximum number of seconds to wait between two attempts of the same request. If not set,\n" +
+			"defaults to the environment variable TMC_MAX_RETRY_WAIT or 30",
 	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Provider) (interface{}, diag.Diagnostics) {
 	apiToken := d.Get("api_token").(string)
 	orgURL := d.Get("org_url").(string)
+	cspURL := d.Get("csp_url").(string)
 	var err error
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
 	if (apiToken != "") && (orgURL != "") {
-		client, err := tanzuclient.NewClient(&orgURL, &apiToken)
+		client, err := tanzuclient.NewClient(ctx, &orgURL, &cspURL, &apiToken)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
+		client.MaxAttempts = d.Get("max_attempts").(int)
+		client.MaxRetryWait = time.Duration(d.Get("max_retry_wait").(int)) * time.Second
+
 		return client, diags
 	}
 
-	client, err := tanzuclient.NewClient(nil, nil)
+	client, err := tanzuclient.NewClient(ctx, nil, nil, nil)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -0,0 +1,59 @@
+package tmc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+// testAccProviderFactories run the provider in-process for acceptance
+// tests, which point it at a fake TMC API with testAccProviderConfig.
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"tmc": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+func init() {
+	// The fake API moves objects to their next phase on every poll
+	clusterPollInterval = 10 * time.Millisecond
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testAccProviderConfig returns the provider block authenticating against
+// the fake TMC API.
+func testAccProviderConfig(server *tmcfake.Server) string {
+	return fmt.Sprintf(`
+provider "tmc" {
+  org_url   = %q
+  csp_url   = %q
+  api_token = %q
+}
+`, server.URL, server.URL, server.APIToken)
+}
+
+// testAccDestroyInPhaseSteps creates the resources of the config, then
+// destroys them once setPhase moved the object under test to a phase the fake
+// API keeps reporting for a poll after it is deleted, like an error phase or
+// no status at all.
+func testAccDestroyInPhaseSteps(config string, setPhase func()) []resource.TestStep {
+	return []resource.TestStep{
+		{
+			Config: config,
+		},
+		{
+			PreConfig: setPhase,
+			Config:    config,
+			Destroy:   true,
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package tmc
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/opa/ast"
+)
+
+// checkRego parses the Rego source of a Gatekeeper template, so syntax errors
+// are reported before TMC is called, and checks that it defines the violation
+// rule Gatekeeper reports the results of.
+func checkRego(source string) error {
+	module, err := ast.ParseModule("template.rego", source)
+	if err != nil {
+		return err
+	}
+	if module == nil {
+		return fmt.Errorf("missing package declaration")
+	}
+
+	for _, rule := range module.Rules {
+		if rule.Head.Name.Equal(ast.Var("violation")) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("missing violation rule, Gatekeeper reports the results of the violation rule of the template")
+}
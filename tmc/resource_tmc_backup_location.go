@@ -0,0 +1,346 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// backupLocationTargets are the blocks describing the object storage of a
+// backup location, one per provider.
+var backupLocationTargets = []string{"s3", "azure"}
+
+func resourceTmcBackupLocation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcBackupLocationRead,
+		CreateContext: resourceTmcBackupLocationCreate,
+		UpdateContext: resourceTmcBackupLocationUpdate,
+		DeleteContext: resourceTmcBackupLocationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the backup location",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the backup location",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the backup location",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the backup location",
+			},
+			"credential_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the TMC credential giving access to the object storage",
+			},
+			"assigned_cluster_groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the cluster groups whose clusters can back up to the location",
+			},
+			"s3": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ForceNew:     true,
+				ExactlyOneOf: backupLocationTargets,
+				Description:  "Amazon S3 bucket, or bucket of an S3 compatible storage, the backups are kept in",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Name of the bucket",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "",
+							Description: "Region of the bucket",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "URL of an S3 compatible storage, like MinIO",
+						},
+						"public_url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "URL of the storage reachable from outside the clusters, used to download backup logs",
+						},
+						"force_path_style": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the bucket is addressed in the path of the URL rather than in its host, which S3 compatible storage usually expects",
+						},
+					},
+				},
+			},
+			"azure": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ForceNew:     true,
+				ExactlyOneOf: backupLocationTargets,
+				Description:  "Azure Blob Storage container the backups are kept in",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"container": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Name of the blob container",
+						},
+						"resource_group": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Resource group of the storage account",
+						},
+						"storage_account": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Storage account holding the container",
+						},
+						"subscription_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "",
+							Description: "Subscription of the storage account, when it is not the one of the credential",
+						},
+					},
+				},
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Phase of the backup location, READY once TMC could reach the object storage",
+			},
+		},
+	}
+}
+
+func resourceTmcBackupLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	location, err := client.GetBackupLocation(ctx, d.Id())
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Backup location %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", location.FullName.Name)
+	d.Set("uid", location.Meta.UID)
+	d.Set("description", location.Meta.Description)
+
+	if location.Status != nil {
+		d.Set("phase", location.Status.Phase)
+	}
+
+	for attribute, value := range flattenBackupLocationSpec(location.Spec) {
+		if err := d.Set(attribute, value); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read backup location",
+				Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", attribute, d.Id(), err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTmcBackupLocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	locationName := d.Get("name").(string)
+
+	_, err := client.CreateBackupLocation(ctx, locationName, d.Get("description").(string), expandBackupLocationSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create backup location",
+			Detail:   fmt.Sprintf("Cannot create the backup location %s: %s", locationName, err),
+		})
+		return diags
+	}
+
+	d.SetId(locationName)
+
+	return resourceTmcBackupLocationRead(ctx, d, meta)
+}
+
+func resourceTmcBackupLocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	_, err := client.UpdateBackupLocation(ctx, d.Id(), d.Get("description").(string), expandBackupLocationSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update backup location",
+			Detail:   fmt.Sprintf("Cannot update the backup location %s with the new values: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	return resourceTmcBackupLocationRead(ctx, d, meta)
+}
+
+func resourceTmcBackupLocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	err := client.DeleteBackupLocation(ctx, d.Id())
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete backup location",
+			Detail:   fmt.Sprintf("Cannot delete the backup location %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func expandBackupLocationSpec(d *schema.ResourceData) *tanzuclient.BackupLocationSpec {
+	spec := &tanzuclient.BackupLocationSpec{
+		Credential: &tanzuclient.CredentialReference{
+			Name: d.Get("credential_name").(string),
+		},
+		Config: &tanzuclient.BackupLocationConfig{},
+	}
+
+	for _, group := range expandSortedStringSet(d.Get("assigned_cluster_groups").(*schema.Set)) {
+		spec.AssignedGroups = append(spec.AssignedGroups, tanzuclient.BackupLocationAssignedGroup{
+			ClusterGroup: &tanzuclient.BackupLocationClusterGroup{Name: group},
+		})
+	}
+
+	if s3 := d.Get("s3").([]interface{}); len(s3) > 0 && s3[0] != nil {
+		target := s3[0].(map[string]interface{})
+
+		spec.TargetProvider = tanzuclient.BackupTargetProviderAWS
+		spec.Bucket = target["bucket"].(string)
+		spec.Region = target["region"].(string)
+		spec.Config.AWSConfig = &tanzuclient.BackupLocationAWSConfig{
+			S3ForcePathStyle: target["force_path_style"].(bool),
+			S3URL:            target["url"].(string),
+			PublicURL:        target["public_url"].(string),
+		}
+	}
+
+	if azure := d.Get("azure").([]interface{}); len(azure) > 0 && azure[0] != nil {
+		target := azure[0].(map[string]interface{})
+
+		spec.TargetProvider = tanzuclient.BackupTargetProviderAzure
+		spec.Bucket = target["container"].(string)
+		spec.Config.AzureConfig = &tanzuclient.BackupLocationAzureConfig{
+			ResourceGroup:  target["resource_group"].(string),
+			StorageAccount: target["storage_account"].(string),
+			SubscriptionID: target["subscription_id"].(string),
+		}
+	}
+
+	return spec
+}
+
+// flattenBackupLocationSpec returns the values of the attributes describing
+// the backup location, by attribute name.
+func flattenBackupLocationSpec(spec *tanzuclient.BackupLocationSpec) map[string]interface{} {
+	data := map[string]interface{}{
+		"credential_name":         "",
+		"assigned_cluster_groups": []interface{}{},
+		"s3":                      []interface{}{},
+		"azure":                   []interface{}{},
+	}
+	if spec == nil {
+		return data
+	}
+
+	if spec.Credential != nil {
+		data["credential_name"] = spec.Credential.Name
+	}
+
+	groups := make([]interface{}, 0, len(spec.AssignedGroups))
+	for _, group := range spec.AssignedGroups {
+		if group.ClusterGroup != nil {
+			groups = append(groups, group.ClusterGroup.Name)
+		}
+	}
+	data["assigned_cluster_groups"] = groups
+
+	config := spec.Config
+	if config == nil {
+		config = &tanzuclient.BackupLocationConfig{}
+	}
+
+	switch spec.TargetProvider {
+	case tanzuclient.BackupTargetProviderAWS:
+		target := map[string]interface{}{
+			"bucket": spec.Bucket,
+			"region": spec.Region,
+		}
+		if config.AWSConfig != nil {
+			target["url"] = config.AWSConfig.S3URL
+			target["public_url"] = config.AWSConfig.PublicURL
+			target["force_path_style"] = config.AWSConfig.S3ForcePathStyle
+		}
+		data["s3"] = []interface{}{target}
+	case tanzuclient.BackupTargetProviderAzure:
+		target := map[string]interface{}{
+			"container": spec.Bucket,
+		}
+		if config.AzureConfig != nil {
+			target["resource_group"] = config.AzureConfig.ResourceGroup
+			target["storage_account"] = config.AzureConfig.StorageAccount
+			target["subscription_id"] = config.AzureConfig.SubscriptionID
+		}
+		data["azure"] = []interface{}{target}
+	}
+
+	return data
+}
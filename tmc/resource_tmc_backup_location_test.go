@@ -0,0 +1,124 @@
+package tmc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcBackupLocation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcBackupLocationDestroy(server, "tf-acc-location"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcBackupLocationConfig(server, "tf-acc-aws", `["tf-acc-group"]`, `
+  s3 {
+    bucket = "tf-acc-backups"
+    region = "us-west-2"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "id", "tf-acc-location"),
+					resource.TestCheckResourceAttrSet("tmc_backup_location.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "credential_name", "tf-acc-aws"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "assigned_cluster_groups.#", "1"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "s3.0.bucket", "tf-acc-backups"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "s3.0.force_path_style", "false"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "azure.#", "0"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "phase", "READY"),
+					testAccCheckTmcBackupLocationSpec(server, "tf-acc-location", "AWS", "tf-acc-backups", []string{"tf-acc-group"}),
+				),
+			},
+			{
+				Config: testAccResourceTmcBackupLocationConfig(server, "tf-acc-minio", `["tf-acc-group", "default"]`, `
+  s3 {
+    bucket           = "tf-acc-backups"
+    region           = "us-west-2"
+    url              = "https://minio.example.com"
+    force_path_style = true
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "credential_name", "tf-acc-minio"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "assigned_cluster_groups.#", "2"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "s3.0.url", "https://minio.example.com"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "s3.0.force_path_style", "true"),
+					testAccCheckTmcBackupLocationSpec(server, "tf-acc-location", "AWS", "tf-acc-backups", []string{"default", "tf-acc-group"}),
+				),
+			},
+			{
+				ResourceName:      "tmc_backup_location.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Moving the backups to another provider replaces the location
+				Config: testAccResourceTmcBackupLocationConfig(server, "tf-acc-azure", `[]`, `
+  azure {
+    container       = "tf-acc-backups"
+    resource_group  = "tf-acc"
+    storage_account = "tfaccbackups"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "s3.#", "0"),
+					resource.TestCheckResourceAttr("tmc_backup_location.test", "azure.0.storage_account", "tfaccbackups"),
+					testAccCheckTmcBackupLocationSpec(server, "tf-acc-location", "AZURE", "tf-acc-backups", nil),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcBackupLocationConfig(server *tmcfake.Server, credential, groups, target string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_backup_location" "test" {
+  name                    = "tf-acc-location"
+  credential_name         = %q
+  assigned_cluster_groups = %s
+%s}
+`, credential, groups, target)
+}
+
+func testAccCheckTmcBackupLocationSpec(server *tmcfake.Server, name, provider, bucket string, groups []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		location, ok := server.BackupLocation(name)
+		if !ok {
+			return fmt.Errorf("backup location %s was not created", name)
+		}
+
+		spec := location["spec"].(tmcfake.Object)
+		if spec["targetProvider"] != provider || spec["bucket"] != bucket {
+			return fmt.Errorf("expected backup location %s to target %s bucket %s, got %v", name, provider, bucket, spec)
+		}
+
+		var assigned []string
+		assignedGroups, _ := spec["assignedGroups"].([]interface{})
+		for _, group := range assignedGroups {
+			clusterGroup := group.(tmcfake.Object)["clustergroup"].(tmcfake.Object)
+			assigned = append(assigned, fmt.Sprint(clusterGroup["name"]))
+		}
+		if !reflect.DeepEqual(assigned, groups) {
+			return fmt.Errorf("expected backup location %s to be assigned to %v, got %v", name, groups, assigned)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTmcBackupLocationDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.BackupLocation(name); ok {
+			return fmt.Errorf("backup location %s still exists", name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,329 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// backupRestoreIDFormat is the format of the IDs of restores, which are also
+// used to import them.
+const backupRestoreIDFormat = "management_cluster/provisioner_name/cluster_name/name"
+
+func resourceTmcBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcBackupRestoreRead,
+		CreateContext: resourceTmcBackupRestoreCreate,
+		DeleteContext: resourceTmcBackupRestoreDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcBackupRestoreImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the restore in the management_cluster/provisioner_name/cluster_name/name format",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the restore",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the restore",
+			},
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Cluster to restore the backup onto, which must have data protection enabled",
+			},
+			"backup_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the backup to restore",
+			},
+			"included_namespaces": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Namespaces to restore, all the namespaces of the backup when left out",
+			},
+			"excluded_namespaces": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Namespaces not to restore",
+			},
+			"included_resources": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Kinds of resources to restore, like deployments or configmaps, all the kinds of the backup when left out",
+			},
+			"excluded_resources": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Kinds of resources not to restore",
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Phase of the restore, Completed or PartiallyFailed once it is done",
+			},
+			"warnings": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of warnings reported during the restore",
+			},
+			"errors": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of errors encountered during the restore",
+			},
+		},
+	}
+}
+
+func resourceTmcBackupRestoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	parts, err := parseID(d.Id(), backupRestoreIDFormat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managementClusterName, provisionerName, clusterName, restoreName := parts[0], parts[1], parts[2], parts[3]
+
+	restore, err := client.GetRestore(ctx, restoreName, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Restore %s of cluster %s not found, removing from state", restoreName, clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", restore.FullName.Name)
+	d.Set("management_cluster", restore.FullName.ManagementClusterName)
+	d.Set("provisioner_name", restore.FullName.ProvisionerName)
+	d.Set("cluster_name", restore.FullName.ClusterName)
+	d.Set("uid", restore.Meta.UID)
+
+	if restore.Spec != nil {
+		d.Set("backup_name", restore.Spec.BackupName)
+
+		for attribute, value := range map[string][]string{
+			"included_namespaces": restore.Spec.IncludedNamespaces,
+			"excluded_namespaces": restore.Spec.ExcludedNamespaces,
+			"included_resources":  restore.Spec.IncludedResources,
+			"excluded_resources":  restore.Spec.ExcludedResources,
+		} {
+			if err := d.Set(attribute, value); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Failed to read restore",
+					Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", attribute, d.Id(), err),
+				})
+				return diags
+			}
+		}
+	}
+
+	if restore.Status != nil {
+		d.Set("phase", restore.Status.Phase)
+		d.Set("warnings", restore.Status.Warnings)
+		d.Set("errors", restore.Status.Errors)
+	}
+
+	return diags
+}
+
+func resourceTmcBackupRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	restoreName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	spec := &tanzuclient.RestoreSpec{
+		BackupName:         d.Get("backup_name").(string),
+		IncludedNamespaces: expandSortedStringSet(d.Get("included_namespaces").(*schema.Set)),
+		ExcludedNamespaces: expandSortedStringSet(d.Get("excluded_namespaces").(*schema.Set)),
+		IncludedResources:  expandSortedStringSet(d.Get("included_resources").(*schema.Set)),
+		ExcludedResources:  expandSortedStringSet(d.Get("excluded_resources").(*schema.Set)),
+	}
+
+	_, err := client.CreateRestore(ctx, restoreName, clusterName, managementCluster, provisionerName, spec)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create restore",
+			Detail:   fmt.Sprintf("Cannot restore the backup %s onto cluster %s: %s", spec.BackupName, clusterName, err),
+		})
+		return diags
+	}
+
+	d.SetId(buildID(managementCluster, provisionerName, clusterName, restoreName))
+
+	restore, err := waitForRestoreDone(ctx, client, managementCluster, provisionerName, clusterName, restoreName, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Some objects could not be restored, which is worth a look but does not
+	// make the restore unusable.
+	if restore.Status.Phase == tanzuclient.RestorePhasePartiallyFailed {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Restore partially failed",
+			Detail:   fmt.Sprintf("The restore %s of cluster %s completed with %d errors and %d warnings, see its logs in TMC", restoreName, clusterName, restore.Status.Errors, restore.Status.Warnings),
+		})
+	}
+
+	return append(diags, resourceTmcBackupRestoreRead(ctx, d, meta)...)
+}
+
+// resourceTmcBackupRestoreDelete only deletes the record of the restore, the
+// restored objects are left in the cluster.
+func resourceTmcBackupRestoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	restoreName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	err := client.DeleteRestore(ctx, restoreName, clusterName, managementCluster, provisionerName)
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete restore",
+			Detail:   fmt.Sprintf("Cannot delete the restore %s of cluster %s: %s", restoreName, clusterName, err),
+		})
+		return diags
+	}
+
+	if err := waitForRestoreDeleted(ctx, client, managementCluster, provisionerName, clusterName, restoreName, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTmcBackupRestoreImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseID(d.Id(), backupRestoreIDFormat); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForRestoreDone polls the restore until Velero is done with it, and
+// fails with the reason reported by Velero if the restore failed.
+func waitForRestoreDone(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, timeout time.Duration) (*tanzuclient.Restore, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			"",
+			tanzuclient.RestorePhaseNew,
+			tanzuclient.RestorePhaseInProgress,
+		},
+		Target: []string{
+			tanzuclient.RestorePhaseCompleted,
+			tanzuclient.RestorePhasePartiallyFailed,
+		},
+		Refresh:      restorePhaseRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, name, false),
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	restore, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for restore %s of cluster %s to complete: %w", name, clusterName, err)
+	}
+
+	return restore.(*tanzuclient.Restore), nil
+}
+
+// waitForRestoreDeleted polls the restore until TMC no longer knows about it.
+func waitForRestoreDeleted(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, timeout time.Duration) error {
+	if err := waitForDeletion(ctx, restorePhaseRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, name, true), timeout); err != nil {
+		return fmt.Errorf("error waiting for restore %s of cluster %s to be deleted: %w", name, clusterName, err)
+	}
+
+	return nil
+}
+
+// restorePhaseRefreshFunc reports the phase of a restore. The Failed and
+// FailedValidation phases fail the wait, unless the restore is being deleted.
+func restorePhaseRefreshFunc(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, deleting bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		restore, err := client.GetRestore(ctx, name, clusterName, managementCluster, provisionerName)
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if restore.Status == nil {
+			return restore, "", nil
+		}
+
+		switch restore.Status.Phase {
+		case tanzuclient.RestorePhaseFailed, tanzuclient.RestorePhaseFailedValidation:
+			if deleting {
+				break
+			}
+			reason := restore.Status.FailureReason
+			if reason == "" {
+				reason = fmt.Sprintf("%d errors", restore.Status.Errors)
+			}
+			return restore, restore.Status.Phase, fmt.Errorf("restore ended in phase %s: %s", restore.Status.Phase, reason)
+		}
+
+		return restore, restore.Status.Phase, nil
+	}
+}
@@ -0,0 +1,204 @@
+package tmc
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcBackupRestore(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup", "", "Completed")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcBackupRestoreDestroy(server, "tf-acc-restore"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcBackupRestoreConfig(server, "tf-acc-backup"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "id", "aws-hosted/tf-acc/tf-acc-cluster/tf-acc-restore"),
+					resource.TestCheckResourceAttrSet("tmc_backup_restore.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "backup_name", "tf-acc-backup"),
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "included_namespaces.#", "2"),
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "excluded_resources.#", "1"),
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "phase", "Completed"),
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "warnings", "0"),
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "errors", "0"),
+					func(s *terraform.State) error {
+						restore, ok := server.Restore("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-restore")
+						if !ok {
+							return fmt.Errorf("restore tf-acc-restore was not created")
+						}
+						spec := restore["spec"].(tmcfake.Object)
+						if fmt.Sprint(spec["includedNamespaces"]) != "[team-a team-b]" || fmt.Sprint(spec["excludedResources"]) != "[secrets]" {
+							return fmt.Errorf("unexpected spec of restore tf-acc-restore: %v", spec)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "tmc_backup_restore.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcBackupRestorePartiallyFailed(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup", "", "Completed")
+	server.SetRestoreResult("tf-acc-backup", "PartiallyFailed", "", 3, 1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcBackupRestoreConfig(server, "tf-acc-backup"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "phase", "PartiallyFailed"),
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "warnings", "3"),
+					resource.TestCheckResourceAttr("tmc_backup_restore.test", "errors", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcBackupRestoreFailed(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup", "", "Completed")
+	server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-running", "", "InProgress")
+	server.SetRestoreResult("tf-acc-backup", "Failed", "error downloading the backup", 0, 0)
+
+	testCases := []struct {
+		name     string
+		backup   string
+		expected string
+	}{
+		{
+			name:     "restore failed",
+			backup:   "tf-acc-backup",
+			expected: `restore ended in phase Failed: error downloading the backup`,
+		},
+		{
+			name:     "backup not completed",
+			backup:   "tf-acc-running",
+			expected: `restore ended in phase FailedValidation: backup tf-acc-running is in phase InProgress`,
+		},
+		{
+			name:     "backup not found",
+			backup:   "tf-acc-missing",
+			expected: `restore ended in phase FailedValidation: backup tf-acc-missing not found`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccResourceTmcBackupRestoreConfig(server, tc.backup),
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(tc.expected)),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcBackupRestoreDestroyFailed(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup", "", "Completed")
+	server.SetRestoreResult("tf-acc-backup", "Failed", "error downloading the backup", 0, 0)
+
+	config := testAccResourceTmcBackupRestoreConfig(server, "tf-acc-backup")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcBackupRestoreDestroy(server, "tf-acc-restore"),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`restore ended in phase Failed`),
+			},
+			{
+				Config:  config,
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcBackupRestoreDestroyWithoutStatus(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup", "", "Completed")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcBackupRestoreDestroy(server, "tf-acc-restore"),
+		Steps: testAccDestroyInPhaseSteps(testAccResourceTmcBackupRestoreConfig(server, "tf-acc-backup"), func() {
+			server.SetRestorePhase("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-restore", "")
+		}),
+	})
+}
+
+func TestAccResourceTmcBackupRestoreImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup", "", "Completed")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcBackupRestoreConfig(server, "tf-acc-backup"),
+				ResourceName:  "tmc_backup_restore.test",
+				ImportState:   true,
+				ImportStateId: "tf-acc-cluster/tf-acc-restore",
+				ExpectError:   regexp.MustCompile(`expected management_cluster/provisioner_name/cluster_name/name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcBackupRestoreConfig(server *tmcfake.Server, backup string) string {
+	return testAccResourceTmcDataProtectionConfig(server, false) + fmt.Sprintf(`
+resource "tmc_backup_restore" "test" {
+  name                = "tf-acc-restore"
+  management_cluster  = tmc_data_protection.test.management_cluster
+  provisioner_name    = tmc_data_protection.test.provisioner_name
+  cluster_name        = tmc_data_protection.test.cluster_name
+  backup_name         = %q
+  included_namespaces = ["team-b", "team-a"]
+  excluded_resources  = ["secrets"]
+}
+`, backup)
+}
+
+func testAccCheckTmcBackupRestoreDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Restore("aws-hosted", "tf-acc", "tf-acc-cluster", name); ok {
+			return fmt.Errorf("restore %s still exists", name)
+		}
+		return nil
+	}
+}
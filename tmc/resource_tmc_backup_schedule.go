@@ -0,0 +1,320 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// backupScheduleIDFormat is the format of the IDs of backup schedules, which
+// are also used to import them.
+const backupScheduleIDFormat = "management_cluster/provisioner_name/cluster_name/name"
+
+// cronSchedule matches the five fields of a cron expression, or one of the
+// shorthands like @daily Velero understands.
+var cronSchedule = regexp.MustCompile(`^(@(yearly|annually|monthly|weekly|daily|midnight|hourly)|@every \S+|\S+( \S+){4})$`)
+
+func resourceTmcBackupSchedule() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcBackupScheduleRead,
+		CreateContext: resourceTmcBackupScheduleCreate,
+		UpdateContext: resourceTmcBackupScheduleUpdate,
+		DeleteContext: resourceTmcBackupScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcBackupScheduleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the backup schedule in the management_cluster/provisioner_name/cluster_name/name format",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the backup schedule",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the backup schedule",
+			},
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Cluster to back up, which must have data protection enabled",
+			},
+			"schedule": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(cronSchedule, "must be a cron expression like \"0 2 * * *\" or a shorthand like @daily"),
+				Description:  "Cron expression of when backups are taken, in UTC",
+			},
+			"included_namespaces": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Namespaces to back up, all of them when left out",
+			},
+			"excluded_namespaces": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Namespaces not to back up",
+			},
+			"ttl": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "720h0m0s",
+				ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+					if _, err := time.ParseDuration(i.(string)); err != nil {
+						return nil, []error{fmt.Errorf("expected %s to be a duration like 720h: %s", k, err)}
+					}
+					return nil, nil
+				},
+				DiffSuppressFunc: suppressEquivalentDuration,
+				Description:      "How long backups are kept before they are deleted, like 720h for 30 days",
+			},
+			"snapshot_volumes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the persistent volumes are backed up along with the Kubernetes objects",
+			},
+			"backup_location_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Name of the backup location the backups are kept in, the default location of the Cluster when left out",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether taking backups is paused",
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Phase of the backup schedule",
+			},
+		},
+	}
+}
+
+func resourceTmcBackupScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	parts, err := parseID(d.Id(), backupScheduleIDFormat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managementClusterName, provisionerName, clusterName, scheduleName := parts[0], parts[1], parts[2], parts[3]
+
+	schedule, err := client.GetBackupSchedule(ctx, scheduleName, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Backup schedule %s of cluster %s not found, removing from state", scheduleName, clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", schedule.FullName.Name)
+	d.Set("management_cluster", schedule.FullName.ManagementClusterName)
+	d.Set("provisioner_name", schedule.FullName.ProvisionerName)
+	d.Set("cluster_name", schedule.FullName.ClusterName)
+	d.Set("uid", schedule.Meta.UID)
+
+	if schedule.Status != nil {
+		d.Set("phase", schedule.Status.Phase)
+	}
+
+	for attribute, value := range flattenBackupScheduleSpec(schedule.Spec) {
+		if err := d.Set(attribute, value); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read backup schedule",
+				Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", attribute, d.Id(), err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTmcBackupScheduleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	scheduleName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	_, err := client.CreateBackupSchedule(ctx, scheduleName, clusterName, managementCluster, provisionerName, expandBackupScheduleSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create backup schedule",
+			Detail:   fmt.Sprintf("Cannot create the backup schedule %s of cluster %s: %s", scheduleName, clusterName, err),
+		})
+		return diags
+	}
+
+	d.SetId(buildID(managementCluster, provisionerName, clusterName, scheduleName))
+
+	return resourceTmcBackupScheduleRead(ctx, d, meta)
+}
+
+func resourceTmcBackupScheduleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	scheduleName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	_, err := client.UpdateBackupSchedule(ctx, scheduleName, clusterName, managementCluster, provisionerName, expandBackupScheduleSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update backup schedule",
+			Detail:   fmt.Sprintf("Cannot update the backup schedule %s of cluster %s with the new values: %s", scheduleName, clusterName, err),
+		})
+		return diags
+	}
+
+	return resourceTmcBackupScheduleRead(ctx, d, meta)
+}
+
+func resourceTmcBackupScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	scheduleName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	err := client.DeleteBackupSchedule(ctx, scheduleName, clusterName, managementCluster, provisionerName)
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete backup schedule",
+			Detail:   fmt.Sprintf("Cannot delete the backup schedule %s of cluster %s: %s", scheduleName, clusterName, err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTmcBackupScheduleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseID(d.Id(), backupScheduleIDFormat); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandBackupScheduleSpec(d *schema.ResourceData) *tanzuclient.BackupScheduleSpec {
+	snapshotVolumes := d.Get("snapshot_volumes").(bool)
+
+	return &tanzuclient.BackupScheduleSpec{
+		Schedule: &tanzuclient.BackupScheduleRate{
+			Rate: d.Get("schedule").(string),
+		},
+		Template: &tanzuclient.BackupTemplate{
+			IncludedNamespaces: expandSortedStringSet(d.Get("included_namespaces").(*schema.Set)),
+			ExcludedNamespaces: expandSortedStringSet(d.Get("excluded_namespaces").(*schema.Set)),
+			BackupTTL:          d.Get("ttl").(string),
+			SnapshotVolumes:    &snapshotVolumes,
+			StorageLocation:    d.Get("backup_location_name").(string),
+		},
+		Paused: d.Get("paused").(bool),
+	}
+}
+
+// flattenBackupScheduleSpec returns the values of the attributes describing
+// the backup schedule, by attribute name.
+func flattenBackupScheduleSpec(spec *tanzuclient.BackupScheduleSpec) map[string]interface{} {
+	data := map[string]interface{}{
+		"schedule":             "",
+		"included_namespaces":  []string{},
+		"excluded_namespaces":  []string{},
+		"ttl":                  "",
+		"snapshot_volumes":     true,
+		"backup_location_name": "",
+		"paused":               false,
+	}
+	if spec == nil {
+		return data
+	}
+
+	data["paused"] = spec.Paused
+	if spec.Schedule != nil {
+		data["schedule"] = spec.Schedule.Rate
+	}
+
+	if template := spec.Template; template != nil {
+		data["included_namespaces"] = template.IncludedNamespaces
+		data["excluded_namespaces"] = template.ExcludedNamespaces
+		data["ttl"] = template.BackupTTL
+		data["backup_location_name"] = template.StorageLocation
+		if template.SnapshotVolumes != nil {
+			data["snapshot_volumes"] = *template.SnapshotVolumes
+		}
+	}
+
+	return data
+}
+
+// suppressEquivalentDuration ignores differences between durations written
+// differently, like 720h and 720h0m0s.
+func suppressEquivalentDuration(k, old, new string, d *schema.ResourceData) bool {
+	oldDuration, err := time.ParseDuration(old)
+	if err != nil {
+		return false
+	}
+	newDuration, err := time.ParseDuration(new)
+	if err != nil {
+		return false
+	}
+
+	return oldDuration == newDuration
+}
@@ -0,0 +1,163 @@
+package tmc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcBackupSchedule(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcBackupScheduleDestroy(server, "aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-daily"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcBackupScheduleConfig(server, "0 2 * * *", `
+  included_namespaces = ["team-a", "team-b"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "id", "aws-hosted/tf-acc/tf-acc-cluster/tf-acc-daily"),
+					resource.TestCheckResourceAttrSet("tmc_backup_schedule.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "schedule", "0 2 * * *"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "included_namespaces.#", "2"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "ttl", "720h0m0s"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "snapshot_volumes", "true"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "paused", "false"),
+					testAccCheckTmcBackupScheduleSpec(server, "tf-acc-daily", "0 2 * * *", "720h0m0s", []interface{}{"team-a", "team-b"}, nil, true),
+				),
+			},
+			{
+				Config: testAccResourceTmcBackupScheduleConfig(server, "@weekly", `
+  excluded_namespaces = ["kube-system"]
+  ttl                 = "168h"
+  snapshot_volumes    = false
+  paused              = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "schedule", "@weekly"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "included_namespaces.#", "0"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "excluded_namespaces.#", "1"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "ttl", "168h"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "snapshot_volumes", "false"),
+					resource.TestCheckResourceAttr("tmc_backup_schedule.test", "paused", "true"),
+					testAccCheckTmcBackupScheduleSpec(server, "tf-acc-daily", "@weekly", "168h", nil, []interface{}{"kube-system"}, false),
+				),
+			},
+			{
+				// The same TTL written differently does not show a diff
+				Config: testAccResourceTmcBackupScheduleConfig(server, "@weekly", `
+  excluded_namespaces = ["kube-system"]
+  ttl                 = "168h0m0s"
+  snapshot_volumes    = false
+  paused              = true
+`),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "tmc_backup_schedule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcBackupScheduleValidation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		schedule string
+		extra    string
+		expected string
+	}{
+		{
+			name:     "cron expression with missing fields",
+			schedule: "0 2 * *",
+			expected: `must be a cron expression`,
+		},
+		{
+			name:     "unknown shorthand",
+			schedule: "@sometimes",
+			expected: `must be a cron expression`,
+		},
+		{
+			name:     "invalid TTL",
+			schedule: "@daily",
+			extra:    `  ttl = "30 days"`,
+			expected: `expected ttl to be a duration like 720h`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccResourceTmcBackupScheduleConfig(server, tc.schedule, tc.extra+"\n"),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(tc.expected)),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccResourceTmcBackupScheduleConfig(server *tmcfake.Server, schedule string, extra string) string {
+	return testAccResourceTmcDataProtectionConfig(server, false) + fmt.Sprintf(`
+resource "tmc_backup_schedule" "test" {
+  name               = "tf-acc-daily"
+  management_cluster = tmc_data_protection.test.management_cluster
+  provisioner_name   = tmc_data_protection.test.provisioner_name
+  cluster_name       = tmc_data_protection.test.cluster_name
+  schedule           = %q
+%s}
+`, schedule, extra)
+}
+
+func testAccCheckTmcBackupScheduleSpec(server *tmcfake.Server, name, rate, ttl string, included, excluded []interface{}, snapshotVolumes bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		schedule, ok := server.BackupSchedule("aws-hosted", "tf-acc", "tf-acc-cluster", name)
+		if !ok {
+			return fmt.Errorf("backup schedule %s was not created", name)
+		}
+
+		spec := schedule["spec"].(tmcfake.Object)
+		if spec["schedule"].(tmcfake.Object)["rate"] != rate {
+			return fmt.Errorf("expected backup schedule %s to run on %s, got %v", name, rate, spec["schedule"])
+		}
+
+		template := spec["template"].(tmcfake.Object)
+		if template["backupTtl"] != ttl || template["snapshotVolumes"] != snapshotVolumes {
+			return fmt.Errorf("unexpected template of backup schedule %s: %v", name, template)
+		}
+		for field, expected := range map[string][]interface{}{"includedNamespaces": included, "excludedNamespaces": excluded} {
+			actual, _ := template[field].([]interface{})
+			if len(actual) != len(expected) || (len(expected) > 0 && !reflect.DeepEqual(actual, expected)) {
+				return fmt.Errorf("expected %s of backup schedule %s to be %v, got %v", field, name, expected, actual)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTmcBackupScheduleDestroy(server *tmcfake.Server, managementCluster, provisioner, cluster, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.BackupSchedule(managementCluster, provisioner, cluster, name); ok {
+			return fmt.Errorf("backup schedule %s still exists", name)
+		}
+		return nil
+	}
+}
@@ -3,20 +3,48 @@ package tmc
 import (
 	"context"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
 )
 
+// clusterIDFormat is the format of the IDs of clusters, which are also used
+// to import them.
+const clusterIDFormat = "management_cluster/provisioner_name/name"
+
 func resourceTmcCluster() *schema.Resource {
 	return &schema.Resource{
 		ReadContext:   resourceTmcClusterRead,
 		CreateContext: resourceTmcClusterCreate,
 		UpdateContext: resourceTmcClusterUpdate,
 		DeleteContext: resourceTmcClusterDelete,
+		CustomizeDiff: resourceTmcClusterCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcClusterImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceTmcClusterV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceTmcClusterStateUpgradeV0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Cluster in the management_cluster/provisioner_name/name format",
+			},
+			"uid": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Unique ID of the Cluster",
@@ -24,6 +52,7 @@ func resourceTmcCluster() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the Cluster",
 			},
 			"description": {
@@ -36,11 +65,13 @@ func resourceTmcCluster() *schema.Resource {
 			"management_cluster": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the management cluster used",
 			},
 			"provisioner_name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the provisioner",
 			},
 			"cluster_group_name": {
@@ -54,65 +85,20 @@ func resourceTmcCluster() *schema.Resource {
 				Computed:    true,
 				Description: "The link to install the agent",
 			},
-			"labels": labelsSchema(),
-			"tkg_aws": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "Details of Cluster hosted on AWS",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"region": {
-							Type:        schema.TypeString,
-							Description: "Region of the AWS Cluster",
-							Optional:    true,
-						},
-						"version": {
-							Type:        schema.TypeString,
-							Description: "Provisioner credential used to create the cluster",
-							Optional:    true,
-						},
-						"credential_name": {
-							Type:        schema.TypeString,
-							Description: "Kubernetes version of the AWS Cluster",
-							Optional:    true,
-						},
-						"availability_zones": {
-							Type:        schema.TypeList,
-							Description: "Availability zones of the control plane node",
-							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"instance_type": {
-							Type:        schema.TypeString,
-							Description: "Instance type used to deploy the control plane node",
-							Optional:    true,
-						},
-						"vpc_cidrblock": {
-							Type:        schema.TypeString,
-							Description: "CIDR block used by the Cluster's VPC",
-							Optional:    true,
-						},
-						"ssh_key": {
-							Type:        schema.TypeString,
-							Description: "Name of the SSH Keypair used in the AWS Cluster",
-							Optional:    true,
-						},
-						// "pods_cidrblocks": {
-						// 	Type:        schema.TypeList,
-						// 	Description: "CIDR blocks allocated to the pods in the cluster",
-						// 	Optional:    true,
-						// 	Elem: &schema.Resource{
-						// 		Schema: map[string]*schema.Schema{
-						// 			"cidr_blocks": {
-						// 				Type:     schema.TypeString,
-						// 				Computed: true,
-						// 			},
-						// 		},
-						// 	},
-						// },
-					},
-				},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Lifecycle phase of the Cluster",
 			},
+			"health": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health of the Cluster as reported by its agent",
+			},
+			"labels":              labelsSchema(),
+			"tkg_aws":             tkgAwsSchema(),
+			"tkg_vsphere":         tkgVsphereSchema(),
+			"tkg_service_vsphere": tkgServiceVsphereSchema(),
 		},
 	}
 }
@@ -120,20 +106,40 @@ func resourceTmcCluster() *schema.Resource {
 func resourceTmcClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*tanzuclient.Client)
 
-	clusterName := d.Get("name").(string)
-	managementClusterName := d.Get("management_cluster").(string)
-	provisionerName := d.Get("provisioner_name").(string)
-
 	var diags diag.Diagnostics
 
-	cluster, err := client.GetCluster(clusterName, managementClusterName, provisionerName)
+	parts, err := parseID(d.Id(), clusterIDFormat)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	managementClusterName, provisionerName, clusterName := parts[0], parts[1], parts[2]
 
+	cluster, err := client.GetCluster(ctx, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Cluster %s not found, removing from state", clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", cluster.FullName.Name)
+	d.Set("management_cluster", cluster.FullName.ManagementClusterName)
+	d.Set("provisioner_name", cluster.FullName.ProvisionerName)
+	d.Set("uid", cluster.Meta.UID)
 	d.Set("description", cluster.Meta.Description)
-	d.Set("cluster_group_name", cluster.Spec.ClusterGroupName)
-	d.Set("installer_link", cluster.Status.InstallerLink)
+
+	if cluster.Spec != nil {
+		d.Set("cluster_group_name", cluster.Spec.ClusterGroupName)
+	}
+
+	// Clusters being registered may not report a status yet
+	if cluster.Status != nil {
+		d.Set("installer_link", cluster.Status.InstallerLink)
+		d.Set("phase", cluster.Status.Phase)
+		d.Set("health", cluster.Status.Health)
+	}
 
 	if err := d.Set("labels", cluster.Meta.Labels); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -146,9 +152,10 @@ func resourceTmcClusterRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	tkgAws := make([]interface{}, 0)
 
-	awsData := flattenAwsData(cluster)
-
-	tkgAws = append(tkgAws, awsData)
+	// Clusters which are not hosted on AWS have no tkg_aws block
+	if awsData := flattenAwsData(cluster); len(awsData) > 0 {
+		tkgAws = append(tkgAws, awsData)
+	}
 
 	if err := d.Set("tkg_aws", tkgAws); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -158,7 +165,39 @@ func resourceTmcClusterRead(ctx context.Context, d *schema.ResourceData, meta in
 		})
 		return diags
 	}
-	d.SetId(string(cluster.Meta.UID))
+
+	tkgVsphere := make([]interface{}, 0)
+
+	// Clusters which are not hosted on vSphere have no tkg_vsphere block
+	if vsphereData := flattenTkgVsphere(cluster); len(vsphereData) > 0 {
+		tkgVsphere = append(tkgVsphere, vsphereData)
+	}
+
+	if err := d.Set("tkg_vsphere", tkgVsphere); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read cluster",
+			Detail:   fmt.Sprintf("Error setting spec for resource %s: %s", d.Get("name"), err),
+		})
+		return diags
+	}
+
+	tkgServiceVsphere := make([]interface{}, 0)
+
+	// Clusters which are not provisioned by a supervisor cluster have no
+	// tkg_service_vsphere block
+	if tkgsData := flattenTkgServiceVsphere(cluster); len(tkgsData) > 0 {
+		tkgServiceVsphere = append(tkgServiceVsphere, tkgsData)
+	}
+
+	if err := d.Set("tkg_service_vsphere", tkgServiceVsphere); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read cluster",
+			Detail:   fmt.Sprintf("Error setting spec for resource %s: %s", d.Get("name"), err),
+		})
+		return diags
+	}
 
 	return diags
 }
@@ -170,15 +209,26 @@ func resourceTmcClusterCreate(ctx context.Context, d *schema.ResourceData, meta
 	description := d.Get("description").(string)
 	managementCluster := d.Get("management_cluster").(string)
 	provisionerName := d.Get("provisioner_name").(string)
-	clusterGroupName := d.Get("cluster_group_name").(string)
 	labels := d.Get("labels").(map[string]interface{})
 
-	cluster, err := client.CreateCluster(clusterName, description, managementCluster, provisionerName, clusterGroupName, labels)
+	spec := &tanzuclient.ClusterSpec{
+		ClusterGroupName:  d.Get("cluster_group_name").(string),
+		TkgAws:            expandTkgAws(d.Get("tkg_aws").([]interface{})),
+		TkgVsphere:        expandTkgVsphere(d.Get("tkg_vsphere").([]interface{})),
+		TkgServiceVsphere: expandTkgServiceVsphere(d.Get("tkg_service_vsphere").([]interface{})),
+	}
+
+	_, err := client.CreateCluster(ctx, clusterName, description, managementCluster, provisionerName, spec, labels)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(string(cluster.Meta.UID))
+	d.SetId(buildID(managementCluster, provisionerName, clusterName))
+
+	// Resources depending on the cluster need it to be up and running
+	if _, err := waitForClusterReady(ctx, client, managementCluster, provisionerName, clusterName, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceTmcClusterRead(ctx, d, meta)
 }
@@ -193,12 +243,18 @@ func resourceTmcClusterUpdate(ctx context.Context, d *schema.ResourceData, meta
 	managementCluster := d.Get("management_cluster").(string)
 	provisionerName := d.Get("provisioner_name").(string)
 
-	if d.HasChange("description") || d.HasChange("labels") || d.HasChange("cluster_group_name") {
+	if d.HasChanges("description", "labels", "cluster_group_name", "tkg_aws", "tkg_vsphere", "tkg_service_vsphere") {
 		description := d.Get("description").(string)
 		labels := d.Get("labels").(map[string]interface{})
-		clusterGroupName := d.Get("cluster_group_name").(string)
 
-		_, err := client.UpdateCluster(clusterName, description, managementCluster, provisionerName, clusterGroupName, labels)
+		spec := &tanzuclient.ClusterSpec{
+			ClusterGroupName:  d.Get("cluster_group_name").(string),
+			TkgAws:            expandTkgAws(d.Get("tkg_aws").([]interface{})),
+			TkgVsphere:        expandTkgVsphere(d.Get("tkg_vsphere").([]interface{})),
+			TkgServiceVsphere: expandTkgServiceVsphere(d.Get("tkg_service_vsphere").([]interface{})),
+		}
+
+		_, err := client.UpdateCluster(ctx, clusterName, description, managementCluster, provisionerName, spec, labels)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -207,6 +263,10 @@ func resourceTmcClusterUpdate(ctx context.Context, d *schema.ResourceData, meta
 			})
 			return diags
 		}
+
+		if _, err := waitForClusterReady(ctx, client, managementCluster, provisionerName, clusterName, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceTmcClusterRead(ctx, d, meta)
@@ -218,17 +278,133 @@ func resourceTmcClusterDelete(ctx context.Context, d *schema.ResourceData, meta
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	err := client.DeleteCluster(d.Get("name").(string), d.Get("management_cluster").(string), d.Get("provisioner_name").(string))
-	if err != nil {
+	clusterName := d.Get("name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	err := client.DeleteCluster(ctx, clusterName, managementCluster, provisionerName)
+	if err != nil && !tanzuclient.IsNotFound(err) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Failed to delete cluster",
-			Detail:   fmt.Sprintf("Cannot delete given cluster %s: %s", d.Get("name").(string), err),
+			Detail:   fmt.Sprintf("Cannot delete given cluster %s: %s", clusterName, err),
 		})
 		return diags
 	}
 
+	if err := waitForClusterDeleted(ctx, client, managementCluster, provisionerName, clusterName, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 
 	return diags
 }
+
+func resourceTmcClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateTkgAws(d.Get("tkg_aws").([]interface{})); err != nil {
+		return err
+	}
+
+	if err := validateTkgVsphere(d.Get("tkg_vsphere").([]interface{})); err != nil {
+		return err
+	}
+
+	return validateTkgServiceVsphere(d.Get("tkg_service_vsphere").([]interface{}))
+}
+
+func resourceTmcClusterImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseID(d.Id(), clusterIDFormat); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceTmcClusterV0 is the schema of clusters whose ID was the UID of the
+// cluster rather than its full name.
+func resourceTmcClusterV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"management_cluster": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"provisioner_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cluster_group_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+			},
+			"installer_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"labels": labelsSchema(),
+			"tkg_aws": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"credential_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"availability_zones": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"instance_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"vpc_cidrblock": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ssh_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTmcClusterStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	rawState["uid"] = rawState["id"]
+	rawState["id"] = buildID(
+		fmt.Sprint(rawState["management_cluster"]),
+		fmt.Sprint(rawState["provisioner_name"]),
+		fmt.Sprint(rawState["name"]),
+	)
+
+	return rawState, nil
+}
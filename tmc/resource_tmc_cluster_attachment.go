@@ -0,0 +1,299 @@
+package tmc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func resourceTmcClusterAttachment() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcClusterAttachmentRead,
+		CreateContext: resourceTmcClusterAttachmentCreate,
+		UpdateContext: resourceTmcClusterAttachmentUpdate,
+		DeleteContext: resourceTmcClusterAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the attached Cluster, which is its name",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the attached Cluster",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Cluster in TMC",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the Cluster",
+			},
+			"cluster_group_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "Name of the cluster group",
+			},
+			"labels": labelsSchema(),
+			"kubeconfig_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"kubeconfig_raw"},
+				Description:   "Path of a kubeconfig file giving access to the Cluster. When set, the agent is installed on the Cluster with kubectl",
+			},
+			"kubeconfig_raw": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"kubeconfig_file"},
+				Description:   "Content of a kubeconfig giving access to the Cluster. When set, the agent is installed on the Cluster with kubectl",
+			},
+			"installer_link": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The link to the manifest installing the agent, to apply when no kubeconfig is given",
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Lifecycle phase of the Cluster",
+			},
+			"health": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health of the Cluster as reported by its agent",
+			},
+		},
+	}
+}
+
+func resourceTmcClusterAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	clusterName := d.Id()
+
+	cluster, err := client.GetCluster(ctx, clusterName, tanzuclient.AttachedManagementClusterName, tanzuclient.AttachedProvisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Attached cluster %s not found, removing from state", clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", cluster.FullName.Name)
+	d.Set("uid", cluster.Meta.UID)
+	d.Set("description", cluster.Meta.Description)
+
+	if cluster.Spec != nil {
+		d.Set("cluster_group_name", cluster.Spec.ClusterGroupName)
+	}
+
+	// Clusters being registered may not report a status yet
+	if cluster.Status != nil {
+		d.Set("installer_link", cluster.Status.InstallerLink)
+		d.Set("phase", cluster.Status.Phase)
+		d.Set("health", cluster.Status.Health)
+	}
+
+	if err := d.Set("labels", cluster.Meta.Labels); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read attached cluster",
+			Detail:   fmt.Sprintf("Error getting labels for resource %s: %s", d.Get("name"), err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+func resourceTmcClusterAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	clusterName := d.Get("name").(string)
+	description := d.Get("description").(string)
+	labels := d.Get("labels").(map[string]interface{})
+
+	spec := &tanzuclient.ClusterSpec{
+		ClusterGroupName: d.Get("cluster_group_name").(string),
+	}
+
+	cluster, err := client.CreateCluster(ctx, clusterName, description, tanzuclient.AttachedManagementClusterName, tanzuclient.AttachedProvisionerName, spec, labels)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(clusterName)
+
+	kubeconfig, cleanup, err := attachmentKubeconfig(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer cleanup()
+
+	// Without access to the cluster the agent has to be installed by hand,
+	// using the installer link.
+	if kubeconfig == "" {
+		return resourceTmcClusterAttachmentRead(ctx, d, meta)
+	}
+
+	if cluster.Status == nil || cluster.Status.InstallerLink == "" {
+		return diag.Errorf("TMC did not return an installer link for cluster %s", clusterName)
+	}
+
+	manifest, err := client.GetClusterInstallerManifest(ctx, cluster.Status.InstallerLink)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := kubectlApply(ctx, kubeconfig, manifest); err != nil {
+		return diag.Errorf("Cannot install the TMC agent on cluster %s: %s", clusterName, err)
+	}
+
+	if _, err := waitForClusterHealthy(ctx, client, tanzuclient.AttachedManagementClusterName, tanzuclient.AttachedProvisionerName, clusterName, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcClusterAttachmentRead(ctx, d, meta)
+}
+
+func resourceTmcClusterAttachmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	clusterName := d.Get("name").(string)
+
+	// The kubeconfig is only used to install the agent, so changing it has no
+	// effect on TMC.
+	if d.HasChanges("description", "labels", "cluster_group_name") {
+		description := d.Get("description").(string)
+		labels := d.Get("labels").(map[string]interface{})
+
+		spec := &tanzuclient.ClusterSpec{
+			ClusterGroupName: d.Get("cluster_group_name").(string),
+		}
+
+		_, err := client.UpdateCluster(ctx, clusterName, description, tanzuclient.AttachedManagementClusterName, tanzuclient.AttachedProvisionerName, spec, labels)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to update attached cluster",
+				Detail:   fmt.Sprintf("Cannot update the cluster %s with the new values: %s", clusterName, err),
+			})
+			return diags
+		}
+	}
+
+	return resourceTmcClusterAttachmentRead(ctx, d, meta)
+}
+
+func resourceTmcClusterAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	clusterName := d.Get("name").(string)
+
+	err := client.DeleteCluster(ctx, clusterName, tanzuclient.AttachedManagementClusterName, tanzuclient.AttachedProvisionerName)
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to detach cluster",
+			Detail:   fmt.Sprintf("Cannot detach given cluster %s: %s", clusterName, err),
+		})
+		return diags
+	}
+
+	if err := waitForClusterDeleted(ctx, client, tanzuclient.AttachedManagementClusterName, tanzuclient.AttachedProvisionerName, clusterName, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// attachmentKubeconfig returns the path of the kubeconfig giving access to
+// the attached cluster, or an empty path if none is set. An inline kubeconfig
+// is written to a temporary file, which cleanup removes.
+func attachmentKubeconfig(d *schema.ResourceData) (path string, cleanup func(), err error) {
+	cleanup = func() {}
+
+	if path := d.Get("kubeconfig_file").(string); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", cleanup, fmt.Errorf("cannot read kubeconfig_file: %w", err)
+		}
+		return path, cleanup, nil
+	}
+
+	raw := d.Get("kubeconfig_raw").(string)
+	if raw == "" {
+		return "", cleanup, nil
+	}
+
+	file, err := ioutil.TempFile("", "tmc-kubeconfig-")
+	if err != nil {
+		return "", cleanup, err
+	}
+	defer file.Close()
+
+	cleanup = func() { os.Remove(file.Name()) }
+
+	if _, err := file.WriteString(raw); err != nil {
+		cleanup()
+		return "", func() {}, err
+	}
+
+	return file.Name(), cleanup, nil
+}
+
+// kubectlApply applies the manifest to the cluster the kubeconfig gives
+// access to, using the kubectl binary found in the PATH.
+func kubectlApply(ctx context.Context, kubeconfig string, manifest []byte) error {
+	kubectl, err := exec.LookPath("kubectl")
+	if err != nil {
+		return fmt.Errorf("kubectl is needed to install the agent: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, kubectl, "apply", "--kubeconfig", kubeconfig, "-f", "-")
+	cmd.Stdin = bytes.NewReader(manifest)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(output))
+	}
+
+	log.Printf("[DEBUG] kubectl apply: %s", output)
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package tmc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+// testAccFakeKubectl puts a kubectl in the PATH which records the manifest
+// it is asked to apply and its arguments in the returned directory.
+func testAccFakeKubectl(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "tmc-kubectl-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := `#!/bin/sh
+cat > "$(dirname "$0")/manifest.yaml"
+echo "$@" > "$(dirname "$0")/args"
+`
+	if err := ioutil.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+
+	t.Cleanup(func() {
+		os.Setenv("PATH", path)
+		os.RemoveAll(dir)
+	})
+
+	return dir
+}
+
+func TestAccResourceTmcClusterAttachment(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	kubectlDir := testAccFakeKubectl(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "attached", "attached", "tf-acc-attached"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterAttachmentConfig(server, "first description"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcClusterExists(server, "attached", "attached", "tf-acc-attached"),
+					testAccCheckKubectlApplied(kubectlDir),
+					resource.TestCheckResourceAttr("tmc_cluster_attachment.test", "id", "tf-acc-attached"),
+					resource.TestCheckResourceAttr("tmc_cluster_attachment.test", "description", "first description"),
+					resource.TestCheckResourceAttr("tmc_cluster_attachment.test", "phase", "READY"),
+					resource.TestCheckResourceAttr("tmc_cluster_attachment.test", "health", "HEALTHY"),
+					resource.TestCheckResourceAttrSet("tmc_cluster_attachment.test", "uid"),
+					resource.TestCheckResourceAttrSet("tmc_cluster_attachment.test", "installer_link"),
+				),
+			},
+			{
+				Config: testAccResourceTmcClusterAttachmentConfig(server, "second description"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_attachment.test", "description", "second description"),
+				),
+			},
+			{
+				ResourceName:            "tmc_cluster_attachment.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"kubeconfig_raw"},
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterAttachmentWithoutKubeconfig(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "attached", "attached", "tf-acc-attached"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster_attachment" "test" {
+  name = "tf-acc-attached"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_attachment.test", "phase", "PENDING"),
+					resource.TestCheckResourceAttr("tmc_cluster_attachment.test", "cluster_group_name", "default"),
+					resource.TestCheckResourceAttrSet("tmc_cluster_attachment.test", "installer_link"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterAttachmentWithoutStatus(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	config := testAccProviderConfig(server) + `
+resource "tmc_cluster_attachment" "test" {
+  name = "tf-acc-attached"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "attached", "attached", "tf-acc-attached"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// The cluster is still being registered and reports no status
+			{
+				PreConfig: func() {
+					server.SetClusterPhase("attached", "attached", "tf-acc-attached", "")
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_attachment.test", "cluster_group_name", "default"),
+					resource.TestCheckResourceAttrSet("tmc_cluster_attachment.test", "uid"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterAttachmentKubeconfigConflict(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster_attachment" "test" {
+  name            = "tf-acc-attached"
+  kubeconfig_file = "/dev/null"
+  kubeconfig_raw  = "apiVersion: v1"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"kubeconfig_file": conflicts with kubeconfig_raw`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcClusterAttachmentConfig(server *tmcfake.Server, description string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster_attachment" "test" {
+  name        = "tf-acc-attached"
+  description = %q
+
+  kubeconfig_raw = <<-EOT
+    apiVersion: v1
+    kind: Config
+  EOT
+
+  labels = {
+    env = "test"
+  }
+}
+`, description)
+}
+
+func testAccCheckKubectlApplied(dir string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		manifest, err := ioutil.ReadFile(filepath.Join(dir, "manifest.yaml"))
+		if err != nil {
+			return fmt.Errorf("kubectl was not run: %s", err)
+		}
+		if !strings.Contains(string(manifest), "vmware-system-tmc") {
+			return fmt.Errorf("kubectl did not apply the installer manifest, got %q", manifest)
+		}
+
+		args, err := ioutil.ReadFile(filepath.Join(dir, "args"))
+		if err != nil {
+			return err
+		}
+		fields := strings.Fields(string(args))
+		if len(fields) < 3 || fields[0] != "apply" || fields[1] != "--kubeconfig" {
+			return fmt.Errorf("unexpected kubectl arguments %q", args)
+		}
+
+		// The inline kubeconfig is only written out while kubectl runs
+		if _, err := os.Stat(fields[2]); !os.IsNotExist(err) {
+			return fmt.Errorf("kubeconfig %s was not removed", fields[2])
+		}
+
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package tmc
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,8 +17,24 @@ func resourceClusterGroup() *schema.Resource {
 		ReadContext:   resourceClusterGroupRead,
 		UpdateContext: resourceClusterGroupUpdate,
 		DeleteContext: resourceClusterGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceClusterGroupV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceClusterGroupStateUpgradeV0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Tanzu Cluster Group, which is its name",
+			},
+			"uid": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Unique ID of the Tanzu Cluster Group",
@@ -48,7 +65,7 @@ func resourceClusterGroupCreate(ctx context.Context, d *schema.ResourceData, m i
 	desc := d.Get("description").(string)
 	labels := d.Get("labels").(map[string]interface{})
 
-	clusterGroup, err := client.CreateClusterGroup(name, desc, labels)
+	clusterGroup, err := client.CreateClusterGroup(ctx, name, desc, labels)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -58,7 +75,7 @@ func resourceClusterGroupCreate(ctx context.Context, d *schema.ResourceData, m i
 		return diags
 	}
 
-	d.SetId(clusterGroup.Meta.UID)
+	d.SetId(clusterGroup.FullName.Name)
 
 	resourceClusterGroupRead(ctx, d, m)
 
@@ -71,10 +88,15 @@ func resourceClusterGroupRead(ctx context.Context, d *schema.ResourceData, m int
 
 	client := m.(*tanzuclient.Client)
 
-	cgName := d.Get("name").(string)
+	cgName := d.Id()
 
-	clusterGroup, err := client.GetClusterGroup(cgName)
+	clusterGroup, err := client.GetClusterGroup(ctx, cgName)
 	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Cluster Group %s not found, removing from state", cgName)
+			d.SetId("")
+			return nil
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Read ClusterGroup Failed",
@@ -83,6 +105,8 @@ func resourceClusterGroupRead(ctx context.Context, d *schema.ResourceData, m int
 		return diags
 	}
 
+	d.Set("name", clusterGroup.FullName.Name)
+	d.Set("uid", clusterGroup.Meta.UID)
 	d.Set("description", clusterGroup.Meta.Description)
 	if err := d.Set("labels", clusterGroup.Meta.Labels); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -92,7 +116,6 @@ func resourceClusterGroupRead(ctx context.Context, d *schema.ResourceData, m int
 		})
 		return diags
 	}
-	d.Set("id", clusterGroup.Meta.UID)
 
 	return nil
 }
@@ -109,7 +132,7 @@ func resourceClusterGroupUpdate(ctx context.Context, d *schema.ResourceData, m i
 		desc := d.Get("description").(string)
 		labels := d.Get("labels").(map[string]interface{})
 
-		_, err := client.UpdateClusterGroup(cgName, desc, labels)
+		_, err := client.UpdateClusterGroup(ctx, cgName, desc, labels)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -133,7 +156,7 @@ func resourceClusterGroupDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	cgName := d.Get("name").(string)
 
-	err := client.DeleteClusterGroup(cgName)
+	err := client.DeleteClusterGroup(ctx, cgName)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -147,3 +170,32 @@ func resourceClusterGroupDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	return nil
 }
+
+// resourceClusterGroupV0 is the schema of cluster groups whose ID was the
+// UID of the cluster group rather than its name.
+func resourceClusterGroupV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"labels": labelsSchema(),
+		},
+	}
+}
+
+func resourceClusterGroupStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	rawState["uid"] = rawState["id"]
+	rawState["id"] = rawState["name"]
+
+	return rawState, nil
+}
@@ -0,0 +1,95 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceClusterGroup(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckClusterGroupDestroy(server, "tf-acc-cluster-group"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceClusterGroupConfig(server, "tf-acc-cluster-group", "first description", "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterGroupExists(server, "tf-acc-cluster-group"),
+					resource.TestCheckResourceAttr("tmc_cluster_group.test", "name", "tf-acc-cluster-group"),
+					resource.TestCheckResourceAttr("tmc_cluster_group.test", "description", "first description"),
+					resource.TestCheckResourceAttr("tmc_cluster_group.test", "labels.env", "test"),
+					resource.TestCheckResourceAttrSet("tmc_cluster_group.test", "id"),
+				),
+			},
+			{
+				Config: testAccResourceClusterGroupConfig(server, "tf-acc-cluster-group", "second description", "staging"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_group.test", "description", "second description"),
+					resource.TestCheckResourceAttr("tmc_cluster_group.test", "labels.env", "staging"),
+				),
+			},
+			{
+				ResourceName:      "tmc_cluster_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceClusterGroupConfig(server *tmcfake.Server, name, description, env string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster_group" "test" {
+  name        = %q
+  description = %q
+
+  labels = {
+    env = %q
+  }
+}
+`, name, description, env)
+}
+
+func testAccCheckClusterGroupExists(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.ClusterGroup(name); !ok {
+			return fmt.Errorf("cluster group %s was not created", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckClusterGroupDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.ClusterGroup(name); ok {
+			return fmt.Errorf("cluster group %s still exists", name)
+		}
+		return nil
+	}
+}
+
+func TestResourceClusterGroupStateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		"id":   "cg:01F6ZW5QKMJY5Q0ZPD3JXK0V3T",
+		"name": "tf-acc-cluster-group",
+	}
+
+	v1, err := resourceClusterGroupStateUpgradeV0(context.Background(), v0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v1["id"] != "tf-acc-cluster-group" {
+		t.Errorf("expected id to be the cluster group name, got %v", v1["id"])
+	}
+	if v1["uid"] != "cg:01F6ZW5QKMJY5Q0ZPD3JXK0V3T" {
+		t.Errorf("expected uid to be the previous id, got %v", v1["uid"])
+	}
+}
@@ -0,0 +1,318 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// clusterInspectionIDFormat is the format of the IDs of inspections, which
+// are also used to import them.
+const clusterInspectionIDFormat = "management_cluster/provisioner_name/cluster_name/name"
+
+// inspectionTypes are the types of inspections TMC can run.
+var inspectionTypes = []string{
+	tanzuclient.InspectionTypeCIS,
+	tanzuclient.InspectionTypeConformance,
+	tanzuclient.InspectionTypeLite,
+}
+
+func resourceTmcClusterInspection() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcClusterInspectionRead,
+		CreateContext: resourceTmcClusterInspectionCreate,
+		UpdateContext: resourceTmcClusterInspectionUpdate,
+		DeleteContext: resourceTmcClusterInspectionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcClusterInspectionImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the inspection in the management_cluster/provisioner_name/cluster_name/name format",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the inspection",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the inspection",
+			},
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Cluster to inspect",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(inspectionTypes, false),
+				Description:  "Type of the inspection: cis checks the Cluster against the CIS Kubernetes benchmark, conformance runs the Kubernetes conformance tests and lite runs a quick subset of them",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether creating the inspection waits until it completes. Otherwise the results are read once it completed, on the next refresh",
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Phase of the inspection, COMPLETE once its results are available",
+			},
+			"passed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of checks the Cluster passed",
+			},
+			"failed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of checks the Cluster failed",
+			},
+			"warned": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of checks which need a manual review",
+			},
+			"report_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL to download the detailed results of the inspection from",
+			},
+		},
+	}
+}
+
+func resourceTmcClusterInspectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	parts, err := parseID(d.Id(), clusterInspectionIDFormat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managementClusterName, provisionerName, clusterName, inspectionName := parts[0], parts[1], parts[2], parts[3]
+
+	inspection, err := client.GetInspection(ctx, inspectionName, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Inspection %s of cluster %s not found, removing from state", inspectionName, clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", inspection.FullName.Name)
+	d.Set("management_cluster", inspection.FullName.ManagementClusterName)
+	d.Set("provisioner_name", inspection.FullName.ProvisionerName)
+	d.Set("cluster_name", inspection.FullName.ClusterName)
+	d.Set("uid", inspection.Meta.UID)
+	d.Set("type", inspection.Spec.Type())
+
+	for attribute, value := range flattenInspectionStatus(inspection.Status) {
+		if err := d.Set(attribute, value); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read inspection",
+				Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", attribute, d.Id(), err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTmcClusterInspectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	inspectionName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	_, err := client.CreateInspection(ctx, inspectionName, clusterName, managementCluster, provisionerName, tanzuclient.NewInspectionSpec(d.Get("type").(string)))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create inspection",
+			Detail:   fmt.Sprintf("Cannot launch the inspection %s of cluster %s: %s", inspectionName, clusterName, err),
+		})
+		return diags
+	}
+
+	d.SetId(buildID(managementCluster, provisionerName, clusterName, inspectionName))
+
+	if d.Get("wait_for_completion").(bool) {
+		if err := waitForInspectionComplete(ctx, client, managementCluster, provisionerName, clusterName, inspectionName, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTmcClusterInspectionRead(ctx, d, meta)
+}
+
+// resourceTmcClusterInspectionUpdate only records wait_for_completion, which
+// has no effect once the inspection is launched.
+func resourceTmcClusterInspectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceTmcClusterInspectionRead(ctx, d, meta)
+}
+
+func resourceTmcClusterInspectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	inspectionName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	err := client.DeleteInspection(ctx, inspectionName, clusterName, managementCluster, provisionerName)
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete inspection",
+			Detail:   fmt.Sprintf("Cannot delete the inspection %s of cluster %s: %s", inspectionName, clusterName, err),
+		})
+		return diags
+	}
+
+	if err := waitForInspectionDeleted(ctx, client, managementCluster, provisionerName, clusterName, inspectionName, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTmcClusterInspectionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseID(d.Id(), clusterInspectionIDFormat); err != nil {
+		return nil, err
+	}
+
+	d.Set("wait_for_completion", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForInspectionComplete polls the inspection until it is done, and fails
+// with the reason reported by TMC if it could not complete.
+func waitForInspectionComplete(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			"",
+			tanzuclient.InspectionPhasePending,
+			tanzuclient.InspectionPhaseRunning,
+		},
+		Target: []string{
+			tanzuclient.InspectionPhaseComplete,
+			tanzuclient.InspectionPhaseError,
+		},
+		Refresh:      inspectionPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, name),
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for inspection %s of cluster %s to complete: %w", name, clusterName, err)
+	}
+
+	if inspection := result.(*tanzuclient.Inspection); inspection.Status.Phase == tanzuclient.InspectionPhaseError {
+		return fmt.Errorf("inspection %s of cluster %s failed: %s", name, clusterName, inspection.Status.PhaseInfo)
+	}
+
+	return nil
+}
+
+// waitForInspectionDeleted polls the inspection until TMC no longer knows
+// about it.
+func waitForInspectionDeleted(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, timeout time.Duration) error {
+	if err := waitForDeletion(ctx, inspectionPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, name), timeout); err != nil {
+		return fmt.Errorf("error waiting for inspection %s of cluster %s to be deleted: %w", name, clusterName, err)
+	}
+
+	return nil
+}
+
+func inspectionPhaseRefreshFunc(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		inspection, err := client.GetInspection(ctx, name, clusterName, managementCluster, provisionerName)
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if inspection.Status == nil {
+			return inspection, "", nil
+		}
+
+		return inspection, inspection.Status.Phase, nil
+	}
+}
+
+// flattenInspectionStatus returns the values of the attributes describing the
+// results of the inspection, by attribute name.
+func flattenInspectionStatus(status *tanzuclient.InspectionStatus) map[string]interface{} {
+	data := map[string]interface{}{
+		"phase":      "",
+		"passed":     0,
+		"failed":     0,
+		"warned":     0,
+		"report_url": "",
+	}
+	if status == nil {
+		return data
+	}
+
+	data["phase"] = status.Phase
+	data["report_url"] = status.TarballDownloadURL
+	if status.Report != nil {
+		data["passed"] = status.Report.Passed
+		data["failed"] = status.Report.Failed
+		data["warned"] = status.Report.Warned
+	}
+
+	return data
+}
@@ -0,0 +1,179 @@
+package tmc
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcClusterInspection(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.SetInspectionResult("tf-acc-cis", "", 98, 3, 12)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterInspectionDestroy(server, "tf-acc-cis"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterInspectionConfig(server, "tf-acc-cis", "cis", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "id", "aws-hosted/tf-acc/tf-acc-cluster/tf-acc-cis"),
+					resource.TestCheckResourceAttrSet("tmc_cluster_inspection.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "type", "cis"),
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "phase", "COMPLETE"),
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "passed", "98"),
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "failed", "3"),
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "warned", "12"),
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "report_url", server.URL+"/inspection-results/tf-acc-cis.tar.gz"),
+					testAccCheckTmcClusterInspectionType(server, "tf-acc-cis", "cisSpec"),
+				),
+			},
+			{
+				// Not waiting for the completion of an inspection which already
+				// completed does not launch it again
+				Config: testAccResourceTmcClusterInspectionConfig(server, "tf-acc-cis", "cis", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "wait_for_completion", "false"),
+					resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "passed", "98"),
+				),
+			},
+			{
+				ResourceName:            "tmc_cluster_inspection.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_completion"},
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterInspectionFailed(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.SetInspectionResult("tf-acc-conformance", "the sonobuoy plugin timed out", 0, 0, 0)
+
+	t.Run("wait", func(t *testing.T) {
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccResourceTmcClusterInspectionConfig(server, "tf-acc-conformance", "conformance", true),
+					ExpectError: regexp.MustCompile(`inspection tf-acc-conformance of cluster tf-acc-cluster failed: the sonobuoy plugin timed out`),
+				},
+			},
+		})
+	})
+
+	t.Run("no wait", func(t *testing.T) {
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccResourceTmcClusterInspectionConfig(server, "tf-acc-conformance", "conformance", false),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "phase", "ERROR"),
+						resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "passed", "0"),
+						resource.TestCheckResourceAttr("tmc_cluster_inspection.test", "report_url", ""),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestAccResourceTmcClusterInspectionDestroyUnsettled(t *testing.T) {
+	for _, phase := range []string{"ERROR", ""} {
+		t.Run(fmt.Sprintf("phase %q", phase), func(t *testing.T) {
+			server := tmcfake.NewServer()
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				CheckDestroy:      testAccCheckTmcClusterInspectionDestroy(server, "tf-acc-cis"),
+				Steps: testAccDestroyInPhaseSteps(testAccResourceTmcClusterInspectionConfig(server, "tf-acc-cis", "cis", true), func() {
+					server.SetInspectionPhase("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-cis", phase)
+				}),
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcClusterInspectionInvalidType(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTmcClusterInspectionConfig(server, "tf-acc-e2e", "e2e", true),
+				ExpectError: regexp.MustCompile(`expected type to be one of`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterInspectionImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcClusterInspectionConfig(server, "tf-acc-cis", "cis", true),
+				ResourceName:  "tmc_cluster_inspection.test",
+				ImportState:   true,
+				ImportStateId: "tf-acc-cluster/tf-acc-cis",
+				ExpectError:   regexp.MustCompile(`expected management_cluster/provisioner_name/cluster_name/name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcClusterInspectionConfig(server *tmcfake.Server, name, inspectionType string, wait bool) string {
+	return testAccResourceTmcClusterConfig(server, "first description", "default") + fmt.Sprintf(`
+resource "tmc_cluster_inspection" "test" {
+  name                = %q
+  management_cluster  = tmc_cluster.test.management_cluster
+  provisioner_name    = tmc_cluster.test.provisioner_name
+  cluster_name        = tmc_cluster.test.name
+  type                = %q
+  wait_for_completion = %t
+}
+`, name, inspectionType, wait)
+}
+
+// testAccCheckTmcClusterInspectionType checks the spec of the inspection
+// received by TMC selects the expected type.
+func testAccCheckTmcClusterInspectionType(server *tmcfake.Server, name, specField string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		inspection, ok := server.Inspection("aws-hosted", "tf-acc", "tf-acc-cluster", name)
+		if !ok {
+			return fmt.Errorf("inspection %s was not launched", name)
+		}
+
+		spec, _ := inspection["spec"].(tmcfake.Object)
+		if _, ok := spec[specField]; !ok || len(spec) != 1 {
+			return fmt.Errorf("expected spec of inspection %s to only have %s, got %v", name, specField, spec)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTmcClusterInspectionDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Inspection("aws-hosted", "tf-acc", "tf-acc-cluster", name); ok {
+			return fmt.Errorf("inspection %s still exists", name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,459 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// clusterIntegrationIDFormat is the format of the IDs of cluster
+// integrations, which are also used to import them.
+const clusterIntegrationIDFormat = "management_cluster/provisioner_name/cluster_name/name"
+
+// integrationBlocks are the blocks holding the settings of each integration,
+// by integration name.
+var integrationBlocks = map[string]string{
+	tanzuclient.IntegrationTanzuObservability: "tanzu_observability",
+	tanzuclient.IntegrationTanzuServiceMesh:   "tanzu_service_mesh",
+}
+
+// Types of the namespace exclusions of Tanzu Service Mesh.
+const (
+	namespaceExclusionExact     = "EXACT"
+	namespaceExclusionStartWith = "START_WITH"
+)
+
+func resourceTmcClusterIntegration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcClusterIntegrationRead,
+		CreateContext: resourceTmcClusterIntegrationCreate,
+		UpdateContext: resourceTmcClusterIntegrationUpdate,
+		DeleteContext: resourceTmcClusterIntegrationDelete,
+		CustomizeDiff: resourceTmcClusterIntegrationCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcClusterIntegrationImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the integration in the management_cluster/provisioner_name/cluster_name/name format",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the integration",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					tanzuclient.IntegrationTanzuObservability,
+					tanzuclient.IntegrationTanzuServiceMesh,
+				}, false),
+				Description: "Name of the integration, tanzu-observability-saas or tanzu-service-mesh",
+			},
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Cluster to enable the integration on",
+			},
+			"tanzu_observability": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Settings of Tanzu Observability, required to enable tanzu-observability-saas",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPS,
+							Description:  "URL of the Tanzu Observability instance, like https://example.wavefront.com",
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "API token of Tanzu Observability, which TMC never returns",
+						},
+					},
+				},
+			},
+			"tanzu_service_mesh": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Settings of Tanzu Service Mesh. Namespaces are only excluded from the mesh when it is set",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"namespace_exclusion": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Namespaces left out of the mesh",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"match": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Name, or start of the names, of the namespaces",
+									},
+									"type": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      namespaceExclusionExact,
+										ValidateFunc: validation.StringInSlice([]string{namespaceExclusionExact, namespaceExclusionStartWith}, false),
+										Description:  "How match is compared with the names of the namespaces, EXACT or START_WITH",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Phase of the integration",
+			},
+			"health": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Health of the integration as reported by its agent",
+			},
+		},
+	}
+}
+
+func resourceTmcClusterIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	parts, err := parseID(d.Id(), clusterIntegrationIDFormat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managementClusterName, provisionerName, clusterName, integrationName := parts[0], parts[1], parts[2], parts[3]
+
+	integration, err := client.GetIntegration(ctx, integrationName, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Integration %s of cluster %s not found, removing from state", integrationName, clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", integration.FullName.Name)
+	d.Set("management_cluster", integration.FullName.ManagementClusterName)
+	d.Set("provisioner_name", integration.FullName.ProvisionerName)
+	d.Set("cluster_name", integration.FullName.ClusterName)
+	d.Set("uid", integration.Meta.UID)
+
+	if integration.Status != nil {
+		d.Set("phase", integration.Status.Phase)
+		d.Set("health", integration.Status.Health)
+	}
+
+	for attribute, value := range flattenIntegrationSpec(integration.FullName.Name, integration.Spec, d) {
+		if err := d.Set(attribute, value); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read integration",
+				Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", attribute, d.Id(), err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTmcClusterIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integrationName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	_, err := client.EnableIntegration(ctx, integrationName, clusterName, managementCluster, provisionerName, expandIntegrationSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to enable integration",
+			Detail:   fmt.Sprintf("Cannot enable the integration %s on cluster %s: %s", integrationName, clusterName, err),
+		})
+		return diags
+	}
+
+	d.SetId(buildID(managementCluster, provisionerName, clusterName, integrationName))
+
+	if err := waitForIntegrationHealthy(ctx, client, managementCluster, provisionerName, clusterName, integrationName, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcClusterIntegrationRead(ctx, d, meta)
+}
+
+func resourceTmcClusterIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integrationName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	_, err := client.UpdateIntegration(ctx, integrationName, clusterName, managementCluster, provisionerName, expandIntegrationSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update integration",
+			Detail:   fmt.Sprintf("Cannot update the integration %s of cluster %s with the new values: %s", integrationName, clusterName, err),
+		})
+		return diags
+	}
+
+	if err := waitForIntegrationHealthy(ctx, client, managementCluster, provisionerName, clusterName, integrationName, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcClusterIntegrationRead(ctx, d, meta)
+}
+
+func resourceTmcClusterIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integrationName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	err := client.DisableIntegration(ctx, integrationName, clusterName, managementCluster, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to disable integration",
+			Detail:   fmt.Sprintf("Cannot disable the integration %s of cluster %s: %s", integrationName, clusterName, err),
+		})
+		return diags
+	}
+
+	if err := waitForIntegrationDisabled(ctx, client, managementCluster, provisionerName, clusterName, integrationName, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceTmcClusterIntegrationCustomizeDiff checks the settings given are
+// the ones of the integration.
+func resourceTmcClusterIntegrationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	integrationName := d.Get("name").(string)
+
+	for name, block := range integrationBlocks {
+		if len(d.Get(block).([]interface{})) > 0 && name != integrationName {
+			return fmt.Errorf("%s can only be set to enable the %s integration", block, name)
+		}
+	}
+
+	if integrationName == tanzuclient.IntegrationTanzuObservability && len(d.Get("tanzu_observability").([]interface{})) == 0 {
+		return fmt.Errorf("tanzu_observability is required to enable the %s integration", integrationName)
+	}
+
+	return nil
+}
+
+func resourceTmcClusterIntegrationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseID(d.Id(), clusterIntegrationIDFormat); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForIntegrationHealthy polls an integration until its agent is installed
+// and reports it healthy.
+func waitForIntegrationHealthy(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			"",
+			tanzuclient.IntegrationPhaseCreating,
+			tanzuclient.IntegrationPhaseUpdating,
+			tanzuclient.IntegrationHealthUnhealthy,
+		},
+		Target:       []string{tanzuclient.IntegrationHealthHealthy},
+		Refresh:      integrationStateRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, name, false),
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for integration %s of cluster %s to become healthy: %w", name, clusterName, err)
+	}
+
+	return nil
+}
+
+// waitForIntegrationDisabled polls an integration until its agent is removed.
+func waitForIntegrationDisabled(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, timeout time.Duration) error {
+	if err := waitForDeletion(ctx, integrationStateRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, name, true), timeout); err != nil {
+		return fmt.Errorf("error waiting for integration %s of cluster %s to be disabled: %w", name, clusterName, err)
+	}
+
+	return nil
+}
+
+// integrationStateRefreshFunc reports the phase of an integration, or its
+// health once it is ready. The ERROR phase fails the wait, unless the
+// integration is being disabled.
+func integrationStateRefreshFunc(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName, name string, deleting bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		integration, err := client.GetIntegration(ctx, name, clusterName, managementCluster, provisionerName)
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if integration.Status == nil {
+			return integration, "", nil
+		}
+
+		switch integration.Status.Phase {
+		case tanzuclient.IntegrationPhaseError:
+			if deleting {
+				return integration, integration.Status.Phase, nil
+			}
+			return integration, integration.Status.Phase, statusError("integration", integration.Status.Phase, integration.Status.Conditions)
+		case tanzuclient.IntegrationPhaseReady:
+			return integration, integration.Status.Health, nil
+		}
+
+		return integration, integration.Status.Phase, nil
+	}
+}
+
+func expandIntegrationSpec(d *schema.ResourceData) *tanzuclient.IntegrationSpec {
+	spec := &tanzuclient.IntegrationSpec{}
+
+	if observability := d.Get("tanzu_observability").([]interface{}); len(observability) > 0 && observability[0] != nil {
+		settings := observability[0].(map[string]interface{})
+
+		spec.Configurations = map[string]interface{}{
+			"url": settings["url"].(string),
+		}
+		spec.Secrets = map[string]string{
+			"token": settings["token"].(string),
+		}
+	}
+
+	if mesh := d.Get("tanzu_service_mesh").([]interface{}); len(mesh) > 0 {
+		exclusions := []interface{}{}
+		if mesh[0] != nil {
+			for _, e := range mesh[0].(map[string]interface{})["namespace_exclusion"].([]interface{}) {
+				exclusion := e.(map[string]interface{})
+				exclusions = append(exclusions, map[string]interface{}{
+					"match": exclusion["match"].(string),
+					"type":  exclusion["type"].(string),
+				})
+			}
+		}
+
+		spec.Configurations = map[string]interface{}{
+			"enableNamespaceExclusions": true,
+			"namespaceExclusions":       exclusions,
+		}
+	}
+
+	return spec
+}
+
+// flattenIntegrationSpec returns the values of the blocks holding the settings
+// of the integration, by attribute name. As TMC never returns secrets, they
+// are kept from the state, and are left empty when the integration is
+// imported.
+func flattenIntegrationSpec(name string, spec *tanzuclient.IntegrationSpec, d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"tanzu_observability": []interface{}{},
+		"tanzu_service_mesh":  []interface{}{},
+	}
+	if spec == nil {
+		return data
+	}
+
+	switch name {
+	case tanzuclient.IntegrationTanzuObservability:
+		url, _ := spec.Configurations["url"].(string)
+		data["tanzu_observability"] = []interface{}{map[string]interface{}{
+			"url":   url,
+			"token": d.Get("tanzu_observability.0.token").(string),
+		}}
+	case tanzuclient.IntegrationTanzuServiceMesh:
+		if enabled, _ := spec.Configurations["enableNamespaceExclusions"].(bool); !enabled {
+			break
+		}
+
+		exclusions := []interface{}{}
+		namespaceExclusions, _ := spec.Configurations["namespaceExclusions"].([]interface{})
+		for _, e := range namespaceExclusions {
+			exclusion, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			exclusions = append(exclusions, map[string]interface{}{
+				"match": exclusion["match"],
+				"type":  exclusion["type"],
+			})
+		}
+		data["tanzu_service_mesh"] = []interface{}{map[string]interface{}{
+			"namespace_exclusion": exclusions,
+		}}
+	}
+
+	return data
+}
@@ -0,0 +1,253 @@
+package tmc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcClusterIntegrationTanzuObservability(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterIntegrationDestroy(server, "tanzu-observability-saas"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterIntegrationConfig(server, "tanzu-observability-saas", `
+  tanzu_observability {
+    url   = "https://tf-acc.wavefront.com"
+    token = "tf-acc-token"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "id", "aws-hosted/tf-acc/tf-acc-cluster/tanzu-observability-saas"),
+					resource.TestCheckResourceAttrSet("tmc_cluster_integration.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "tanzu_observability.0.url", "https://tf-acc.wavefront.com"),
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "tanzu_observability.0.token", "tf-acc-token"),
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "phase", "READY"),
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "health", "HEALTHY"),
+					testAccCheckTmcClusterIntegrationSpec(server, "tanzu-observability-saas", tmcfake.Object{
+						"configurations": tmcfake.Object{"url": "https://tf-acc.wavefront.com"},
+						"secrets":        tmcfake.Object{"token": "tf-acc-token"},
+					}),
+				),
+			},
+			{
+				// Rotating the token updates the integration in place
+				Config: testAccResourceTmcClusterIntegrationConfig(server, "tanzu-observability-saas", `
+  tanzu_observability {
+    url   = "https://tf-acc.wavefront.com"
+    token = "tf-acc-rotated"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "tanzu_observability.0.token", "tf-acc-rotated"),
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "health", "HEALTHY"),
+					testAccCheckTmcClusterIntegrationSpec(server, "tanzu-observability-saas", tmcfake.Object{
+						"configurations": tmcfake.Object{"url": "https://tf-acc.wavefront.com"},
+						"secrets":        tmcfake.Object{"token": "tf-acc-rotated"},
+					}),
+				),
+			},
+			{
+				ResourceName:            "tmc_cluster_integration.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"tanzu_observability.0.token"},
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterIntegrationTanzuServiceMesh(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterIntegrationDestroy(server, "tanzu-service-mesh"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterIntegrationConfig(server, "tanzu-service-mesh", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "tanzu_service_mesh.#", "0"),
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "health", "HEALTHY"),
+					testAccCheckTmcClusterIntegrationSpec(server, "tanzu-service-mesh", tmcfake.Object{}),
+				),
+			},
+			{
+				Config: testAccResourceTmcClusterIntegrationConfig(server, "tanzu-service-mesh", `
+  tanzu_service_mesh {
+    namespace_exclusion {
+      match = "kube-system"
+    }
+
+    namespace_exclusion {
+      match = "vmware-system-"
+      type  = "START_WITH"
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "tanzu_service_mesh.0.namespace_exclusion.#", "2"),
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "tanzu_service_mesh.0.namespace_exclusion.0.type", "EXACT"),
+					resource.TestCheckResourceAttr("tmc_cluster_integration.test", "tanzu_service_mesh.0.namespace_exclusion.1.match", "vmware-system-"),
+					testAccCheckTmcClusterIntegrationSpec(server, "tanzu-service-mesh", tmcfake.Object{
+						"configurations": tmcfake.Object{
+							"enableNamespaceExclusions": true,
+							"namespaceExclusions": []interface{}{
+								tmcfake.Object{"match": "kube-system", "type": "EXACT"},
+								tmcfake.Object{"match": "vmware-system-", "type": "START_WITH"},
+							},
+						},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_cluster_integration.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterIntegrationFailed(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.FailIntegration("aws-hosted", "tf-acc", "tf-acc-cluster", "tanzu-service-mesh", "the cluster is not supported by Tanzu Service Mesh")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTmcClusterIntegrationConfig(server, "tanzu-service-mesh", ""),
+				ExpectError: regexp.MustCompile(`Ready: the cluster is not supported by Tanzu Service Mesh`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterIntegrationDestroyUnsettled(t *testing.T) {
+	for _, phase := range []string{"ERROR", ""} {
+		t.Run(fmt.Sprintf("phase %q", phase), func(t *testing.T) {
+			server := tmcfake.NewServer()
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				CheckDestroy:      testAccCheckTmcClusterIntegrationDestroy(server, "tanzu-service-mesh"),
+				Steps: testAccDestroyInPhaseSteps(testAccResourceTmcClusterIntegrationConfig(server, "tanzu-service-mesh", ""), func() {
+					server.SetIntegrationPhase("aws-hosted", "tf-acc", "tf-acc-cluster", "tanzu-service-mesh", phase)
+				}),
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcClusterIntegrationValidation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		settings string
+		expected string
+	}{
+		{
+			name:     "tanzu-observability-saas",
+			expected: `tanzu_observability is required to enable the tanzu-observability-saas integration`,
+		},
+		{
+			name: "tanzu-service-mesh",
+			settings: `
+  tanzu_observability {
+    url   = "https://tf-acc.wavefront.com"
+    token = "tf-acc-token"
+  }
+`,
+			expected: `tanzu_observability can only be set to enable the tanzu-observability-saas integration`,
+		},
+		{
+			name:     "tanzu-application-platform",
+			expected: `expected name to be one of`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccResourceTmcClusterIntegrationConfig(server, tc.name, tc.settings),
+						ExpectError: regexp.MustCompile(tc.expected),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcClusterIntegrationImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcClusterIntegrationConfig(server, "tanzu-service-mesh", ""),
+				ResourceName:  "tmc_cluster_integration.test",
+				ImportState:   true,
+				ImportStateId: "tf-acc-cluster/tanzu-service-mesh",
+				ExpectError:   regexp.MustCompile(`expected management_cluster/provisioner_name/cluster_name/name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcClusterIntegrationConfig(server *tmcfake.Server, name, settings string) string {
+	return testAccResourceTmcClusterConfig(server, "first description", "default") + fmt.Sprintf(`
+resource "tmc_cluster_integration" "test" {
+  name               = %q
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+%s}
+`, name, settings)
+}
+
+// testAccCheckTmcClusterIntegrationSpec checks the spec of the integration
+// received by TMC, including the secrets it never returns.
+func testAccCheckTmcClusterIntegrationSpec(server *tmcfake.Server, name string, expected tmcfake.Object) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		integration, ok := server.Integration("aws-hosted", "tf-acc", "tf-acc-cluster", name)
+		if !ok {
+			return fmt.Errorf("integration %s was not enabled", name)
+		}
+
+		if spec, _ := integration["spec"].(tmcfake.Object); !reflect.DeepEqual(spec, expected) {
+			return fmt.Errorf("expected spec of integration %s to be %v, got %v", name, expected, spec)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTmcClusterIntegrationDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Integration("aws-hosted", "tf-acc", "tf-acc-cluster", name); ok {
+			return fmt.Errorf("integration %s is still enabled", name)
+		}
+		return nil
+	}
+}
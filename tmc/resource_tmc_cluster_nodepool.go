@@ -0,0 +1,477 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// nodePoolIDFormat is the format of the IDs of node pools, which are also
+// used to import them.
+const nodePoolIDFormat = "management_cluster/provisioner_name/cluster_name/name"
+
+// nodePoolInfrastructures are the blocks describing the nodes of a pool, one
+// per infrastructure a cluster can be provisioned on.
+var nodePoolInfrastructures = []string{"tkg_aws", "tkg_vsphere", "tkg_service_vsphere"}
+
+func resourceTmcClusterNodePool() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcClusterNodePoolRead,
+		CreateContext: resourceTmcClusterNodePoolCreate,
+		UpdateContext: resourceTmcClusterNodePoolUpdate,
+		DeleteContext: resourceTmcClusterNodePoolDelete,
+		CustomizeDiff: resourceTmcClusterNodePoolCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcClusterNodePoolImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the node pool in the management_cluster/provisioner_name/cluster_name/name format",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the node pool",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the node pool",
+			},
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Cluster the node pool belongs to",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the node pool",
+			},
+			"worker_node_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of worker nodes (replicas) in the pool. When autoscaling is enabled, the autoscaler changes it within its bounds, so add it to ignore_changes",
+			},
+			"node_labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Kubernetes labels of the worker nodes",
+			},
+			"cloud_labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Cloud provider tags of the worker node instances",
+			},
+			"taint": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Kubernetes taints of the worker nodes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Key of the taint",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Value of the taint",
+						},
+						"effect": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}, false),
+							Description:  "Effect of the taint on pods which do not tolerate it: NoSchedule, PreferNoSchedule or NoExecute",
+						},
+					},
+				},
+			},
+			"autoscaling": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Bounds of the number of worker nodes the cluster autoscaler scales the pool to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_count": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Minimum number of worker nodes",
+						},
+						"max_count": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Maximum number of worker nodes",
+						},
+					},
+				},
+			},
+			"tkg_aws": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: nodePoolInfrastructures,
+				Description:  "Worker nodes of a node pool of a TKG cluster on AWS",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Instance type used to deploy the worker nodes",
+						},
+						"availability_zone": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Availability zone of the worker nodes, one of the Cluster's",
+						},
+					},
+				},
+			},
+			"tkg_vsphere": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: nodePoolInfrastructures,
+				Description:  "Worker nodes of a node pool of a TKG cluster on vSphere",
+				Elem: &schema.Resource{
+					Schema: vsphereVMConfigSchema(false),
+				},
+			},
+			"tkg_service_vsphere": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: nodePoolInfrastructures,
+				Description:  "Worker nodes of a node pool of a vSphere with Tanzu cluster",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"class": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Virtual machine class of the worker nodes",
+						},
+						"storage_class": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Storage class of the disks of the worker nodes",
+						},
+						"volume": tkgsVolumeSchema(false),
+					},
+				},
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Lifecycle phase of the node pool",
+			},
+		},
+	}
+}
+
+func resourceTmcClusterNodePoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	parts, err := parseID(d.Id(), nodePoolIDFormat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managementClusterName, provisionerName, clusterName, nodePoolName := parts[0], parts[1], parts[2], parts[3]
+
+	nodePool, err := client.GetNodePool(ctx, nodePoolName, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Node pool %s of cluster %s not found, removing from state", nodePoolName, clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", nodePool.FullName.Name)
+	d.Set("management_cluster", nodePool.FullName.ManagementClusterName)
+	d.Set("provisioner_name", nodePool.FullName.ProvisionerName)
+	d.Set("cluster_name", nodePool.FullName.ClusterName)
+	d.Set("uid", nodePool.Meta.UID)
+	d.Set("description", nodePool.Meta.Description)
+
+	if nodePool.Status != nil {
+		d.Set("phase", nodePool.Status.Phase)
+	}
+
+	for attribute, value := range flattenNodePoolSpec(nodePool.Spec) {
+		if err := d.Set(attribute, value); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read node pool",
+				Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", attribute, d.Get("name"), err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTmcClusterNodePoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	nodePoolName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+	description := d.Get("description").(string)
+
+	_, err := client.CreateNodePool(ctx, nodePoolName, description, clusterName, managementCluster, provisionerName, expandNodePoolSpec(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildID(managementCluster, provisionerName, clusterName, nodePoolName))
+
+	if _, err := waitForNodePoolReady(ctx, client, managementCluster, provisionerName, clusterName, nodePoolName, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcClusterNodePoolRead(ctx, d, meta)
+}
+
+func resourceTmcClusterNodePoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	nodePoolName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+	description := d.Get("description").(string)
+
+	_, err := client.UpdateNodePool(ctx, nodePoolName, description, clusterName, managementCluster, provisionerName, expandNodePoolSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update node pool",
+			Detail:   fmt.Sprintf("Cannot update the node pool %s of cluster %s with the new values: %s", nodePoolName, clusterName, err),
+		})
+		return diags
+	}
+
+	if _, err := waitForNodePoolReady(ctx, client, managementCluster, provisionerName, clusterName, nodePoolName, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcClusterNodePoolRead(ctx, d, meta)
+}
+
+func resourceTmcClusterNodePoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	nodePoolName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	err := client.DeleteNodePool(ctx, nodePoolName, clusterName, managementCluster, provisionerName)
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete node pool",
+			Detail:   fmt.Sprintf("Cannot delete the node pool %s of cluster %s: %s", nodePoolName, clusterName, err),
+		})
+		return diags
+	}
+
+	if err := waitForNodePoolDeleted(ctx, client, managementCluster, provisionerName, clusterName, nodePoolName, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTmcClusterNodePoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	autoscaling := d.Get("autoscaling").([]interface{})
+	if len(autoscaling) == 0 || autoscaling[0] == nil {
+		return nil
+	}
+	bounds := autoscaling[0].(map[string]interface{})
+	minCount, maxCount := bounds["min_count"].(int), bounds["max_count"].(int)
+
+	if minCount > maxCount {
+		return fmt.Errorf("autoscaling min_count %d is greater than max_count %d", minCount, maxCount)
+	}
+
+	if count := d.Get("worker_node_count").(int); count < minCount || count > maxCount {
+		return fmt.Errorf("worker_node_count %d is not between the autoscaling min_count %d and max_count %d", count, minCount, maxCount)
+	}
+
+	return nil
+}
+
+func resourceTmcClusterNodePoolImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseID(d.Id(), nodePoolIDFormat); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandNodePoolSpec(d *schema.ResourceData) *tanzuclient.NodePoolSpec {
+	spec := &tanzuclient.NodePoolSpec{
+		WorkerNodeCount: strconv.Itoa(d.Get("worker_node_count").(int)),
+		NodeLabels:      expandStringMap(d.Get("node_labels").(map[string]interface{})),
+		CloudLabels:     expandStringMap(d.Get("cloud_labels").(map[string]interface{})),
+	}
+
+	for _, t := range d.Get("taint").([]interface{}) {
+		taint := t.(map[string]interface{})
+
+		spec.Taints = append(spec.Taints, tanzuclient.Taint{
+			Key:    taint["key"].(string),
+			Value:  taint["value"].(string),
+			Effect: taint["effect"].(string),
+		})
+	}
+
+	if autoscaling := d.Get("autoscaling").([]interface{}); len(autoscaling) > 0 && autoscaling[0] != nil {
+		bounds := autoscaling[0].(map[string]interface{})
+
+		spec.Autoscaling = &tanzuclient.NodePoolAutoscaling{
+			Enabled:  true,
+			MinCount: strconv.Itoa(bounds["min_count"].(int)),
+			MaxCount: strconv.Itoa(bounds["max_count"].(int)),
+		}
+	}
+
+	if tkgAws := d.Get("tkg_aws").([]interface{}); len(tkgAws) > 0 && tkgAws[0] != nil {
+		nodes := tkgAws[0].(map[string]interface{})
+		availabilityZone := nodes["availability_zone"].(string)
+
+		spec.TkgAws = &tanzuclient.AWSNodeSpec{
+			AvailabilityZone: availabilityZone,
+			InstanceType:     nodes["instance_type"].(string),
+			NodePlacement:    []tanzuclient.AWSNodePlacement{{AvailabilityZone: availabilityZone}},
+		}
+	}
+
+	if tkgVsphere := d.Get("tkg_vsphere").([]interface{}); len(tkgVsphere) > 0 && tkgVsphere[0] != nil {
+		spec.TkgVsphere = &tanzuclient.VsphereNodeSpec{
+			VMConfig: expandVsphereVMConfig(tkgVsphere[0].(map[string]interface{})),
+		}
+	}
+
+	if tkgs := d.Get("tkg_service_vsphere").([]interface{}); len(tkgs) > 0 && tkgs[0] != nil {
+		nodeSpec := expandTKGSNodeSpec(tkgs[0].(map[string]interface{}))
+		spec.TkgServiceVsphere = &nodeSpec
+	}
+
+	return spec
+}
+
+// flattenNodePoolSpec returns the attributes describing the spec of a node
+// pool, shared by the node pool resource and the node pools data source.
+func flattenNodePoolSpec(spec *tanzuclient.NodePoolSpec) map[string]interface{} {
+	nodePool := map[string]interface{}{
+		"worker_node_count":   0,
+		"node_labels":         map[string]string{},
+		"cloud_labels":        map[string]string{},
+		"taint":               []interface{}{},
+		"autoscaling":         []interface{}{},
+		"tkg_aws":             []interface{}{},
+		"tkg_vsphere":         []interface{}{},
+		"tkg_service_vsphere": []interface{}{},
+	}
+
+	if spec == nil {
+		return nodePool
+	}
+
+	workerNodeCount, _ := strconv.Atoi(spec.WorkerNodeCount)
+	nodePool["worker_node_count"] = workerNodeCount
+	nodePool["node_labels"] = spec.NodeLabels
+	nodePool["cloud_labels"] = spec.CloudLabels
+
+	taints := make([]interface{}, 0, len(spec.Taints))
+	for _, taint := range spec.Taints {
+		taints = append(taints, map[string]interface{}{
+			"key":    taint.Key,
+			"value":  taint.Value,
+			"effect": taint.Effect,
+		})
+	}
+	nodePool["taint"] = taints
+
+	if spec.Autoscaling != nil && spec.Autoscaling.Enabled {
+		minCount, _ := strconv.Atoi(spec.Autoscaling.MinCount)
+		maxCount, _ := strconv.Atoi(spec.Autoscaling.MaxCount)
+
+		nodePool["autoscaling"] = []interface{}{map[string]interface{}{
+			"min_count": minCount,
+			"max_count": maxCount,
+		}}
+	}
+
+	if spec.TkgAws != nil {
+		nodePool["tkg_aws"] = []interface{}{map[string]interface{}{
+			"instance_type":     spec.TkgAws.InstanceType,
+			"availability_zone": spec.TkgAws.AvailabilityZone,
+		}}
+	}
+
+	if spec.TkgVsphere != nil {
+		nodePool["tkg_vsphere"] = []interface{}{flattenVsphereVMConfig(spec.TkgVsphere.VMConfig)}
+	}
+
+	if spec.TkgServiceVsphere != nil {
+		nodePool["tkg_service_vsphere"] = []interface{}{flattenTKGSNodeSpec(*spec.TkgServiceVsphere)}
+	}
+
+	return nodePool
+}
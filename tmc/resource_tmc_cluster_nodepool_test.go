@@ -0,0 +1,252 @@
+package tmc
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcClusterNodePool(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterNodePoolDestroy(server, "vsphere-tkg", "default", "tf-acc-vsphere", "tf-acc-pool"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterNodePoolConfig(server, 1, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcClusterNodePoolExists(server, "vsphere-tkg", "default", "tf-acc-vsphere", "tf-acc-pool"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "id", "vsphere-tkg/default/tf-acc-vsphere/tf-acc-pool"),
+					resource.TestCheckResourceAttrSet("tmc_cluster_nodepool.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "description", "Workers"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "worker_node_count", "1"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "node_labels.role", "worker"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "tkg_vsphere.0.cpu", "2"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "tkg_vsphere.0.memory_mib", "8192"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "tkg_vsphere.0.disk_gib", "40"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "taint.#", "0"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "autoscaling.#", "0"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "phase", "READY"),
+				),
+			},
+			{
+				Config: testAccResourceTmcClusterNodePoolConfig(server, 3, `
+  taint {
+    key    = "dedicated"
+    value  = "gpu"
+    effect = "NoSchedule"
+  }
+
+  autoscaling {
+    min_count = 2
+    max_count = 5
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "worker_node_count", "3"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "taint.#", "1"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "taint.0.key", "dedicated"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "taint.0.value", "gpu"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "taint.0.effect", "NoSchedule"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "autoscaling.0.min_count", "2"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "autoscaling.0.max_count", "5"),
+					resource.TestCheckResourceAttr("tmc_cluster_nodepool.test", "phase", "READY"),
+					testAccCheckTmcClusterNodePoolSpec(server, "vsphere-tkg", "default", "tf-acc-vsphere", "tf-acc-pool", "3"),
+				),
+			},
+			{
+				ResourceName:      "tmc_cluster_nodepool.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterNodePoolDestroyUnsettled(t *testing.T) {
+	for _, phase := range []string{"ERROR", ""} {
+		t.Run(fmt.Sprintf("phase %q", phase), func(t *testing.T) {
+			server := tmcfake.NewServer()
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				CheckDestroy:      testAccCheckTmcClusterNodePoolDestroy(server, "vsphere-tkg", "default", "tf-acc-vsphere", "tf-acc-pool"),
+				Steps: testAccDestroyInPhaseSteps(testAccResourceTmcClusterNodePoolConfig(server, 1, ""), func() {
+					server.SetNodePoolPhase("vsphere-tkg", "default", "tf-acc-vsphere", "tf-acc-pool", phase)
+				}),
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcClusterNodePoolValidation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		count    int
+		extra    string
+		expected string
+	}{
+		{
+			name:  "autoscaling bounds inverted",
+			count: 3,
+			extra: `
+  autoscaling {
+    min_count = 5
+    max_count = 2
+  }
+`,
+			expected: `autoscaling min_count 5 is greater than max_count 2`,
+		},
+		{
+			name:  "worker node count out of bounds",
+			count: 1,
+			extra: `
+  autoscaling {
+    min_count = 2
+    max_count = 5
+  }
+`,
+			expected: `worker_node_count 1 is not between the autoscaling min_count 2 and max_count 5`,
+		},
+		{
+			name:  "unknown taint effect",
+			count: 1,
+			extra: `
+  taint {
+    key    = "dedicated"
+    effect = "NoWay"
+  }
+`,
+			expected: `expected taint.0.effect to be one of`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccResourceTmcClusterNodePoolConfig(server, tc.count, tc.extra),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(tc.expected)),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcClusterNodePoolInfrastructureRequired(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster_nodepool" "test" {
+  name               = "tf-acc-pool"
+  management_cluster = "vsphere-tkg"
+  provisioner_name   = "default"
+  cluster_name       = "tf-acc-vsphere"
+  worker_node_count  = 1
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`one of\s+` + "`tkg_aws,tkg_service_vsphere,tkg_vsphere`" + `\s+must be specified`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterNodePoolImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcClusterNodePoolConfig(server, 1, ""),
+				ResourceName:  "tmc_cluster_nodepool.test",
+				ImportState:   true,
+				ImportStateId: "vsphere-tkg/default/tf-acc-pool",
+				ExpectError:   regexp.MustCompile(`expected management_cluster/provisioner_name/cluster_name/name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcClusterNodePoolConfig(server *tmcfake.Server, workerNodeCount int, extra string) string {
+	return testAccResourceTmcClusterTkgVsphereConfig(server, "v1.20.5+vmware.2-tkg.1", 1) + fmt.Sprintf(`
+resource "tmc_cluster_nodepool" "test" {
+  name               = "tf-acc-pool"
+  description        = "Workers"
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+  worker_node_count  = %d
+
+  node_labels = {
+    role = "worker"
+  }
+
+  tkg_vsphere {
+    cpu        = 2
+    memory_mib = 8192
+    disk_gib   = 40
+  }
+%s}
+`, workerNodeCount, extra)
+}
+
+func testAccCheckTmcClusterNodePoolExists(server *tmcfake.Server, managementCluster, provisioner, cluster, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.NodePool(managementCluster, provisioner, cluster, name); !ok {
+			return fmt.Errorf("node pool %s was not created", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTmcClusterNodePoolSpec(server *tmcfake.Server, managementCluster, provisioner, cluster, name, workerNodeCount string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		nodePool, ok := server.NodePool(managementCluster, provisioner, cluster, name)
+		if !ok {
+			return fmt.Errorf("node pool %s was not created", name)
+		}
+
+		spec := nodePool["spec"].(tmcfake.Object)
+		if spec["workerNodeCount"] != workerNodeCount {
+			return fmt.Errorf("expected node pool %s to have %s workers, got %v", name, workerNodeCount, spec["workerNodeCount"])
+		}
+
+		autoscaling, _ := spec["autoscaling"].(tmcfake.Object)
+		if autoscaling["enabled"] != true || autoscaling["minCount"] != "2" || autoscaling["maxCount"] != "5" {
+			return fmt.Errorf("unexpected autoscaling of node pool %s: %v", name, autoscaling)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTmcClusterNodePoolDestroy(server *tmcfake.Server, managementCluster, provisioner, cluster, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.NodePool(managementCluster, provisioner, cluster, name); ok {
+			return fmt.Errorf("node pool %s still exists", name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,611 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcCluster(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "aws-hosted", "tf-acc", "tf-acc-cluster"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterConfig(server, "first description", "default"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcClusterExists(server, "aws-hosted", "tf-acc", "tf-acc-cluster"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "name", "tf-acc-cluster"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "description", "first description"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "cluster_group_name", "default"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "labels.env", "test"),
+					resource.TestCheckResourceAttrSet("tmc_cluster.test", "installer_link"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "phase", "READY"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "health", "HEALTHY"),
+					resource.TestCheckResourceAttrSet("tmc_cluster.test", "id"),
+				),
+			},
+			{
+				Config: testAccResourceTmcClusterConfig(server, "second description", "tf-acc-group"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster.test", "description", "second description"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "cluster_group_name", "tf-acc-group"),
+				),
+			},
+			{
+				ResourceName:      "tmc_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceTmcClusterConfig(server *tmcfake.Server, description, clusterGroup string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-cluster"
+  description        = %q
+  management_cluster = "aws-hosted"
+  provisioner_name   = "tf-acc"
+  cluster_group_name = %q
+
+  labels = {
+    env = "test"
+  }
+}
+`, description, clusterGroup)
+}
+
+func testAccCheckTmcClusterExists(server *tmcfake.Server, managementCluster, provisioner, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Cluster(managementCluster, provisioner, name); !ok {
+			return fmt.Errorf("cluster %s was not created", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTmcClusterDestroy(server *tmcfake.Server, managementCluster, provisioner, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Cluster(managementCluster, provisioner, name); ok {
+			return fmt.Errorf("cluster %s still exists", name)
+		}
+		return nil
+	}
+}
+
+func TestResourceTmcClusterStateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		"id":                 "c:01F6ZW5QKMJY5Q0ZPD3JXK0V3T",
+		"name":               "tf-acc-cluster",
+		"management_cluster": "aws-hosted",
+		"provisioner_name":   "tf-acc",
+	}
+
+	v1, err := resourceTmcClusterStateUpgradeV0(context.Background(), v0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v1["id"] != "aws-hosted/tf-acc/tf-acc-cluster" {
+		t.Errorf("expected id to be the full name of the cluster, got %v", v1["id"])
+	}
+	if v1["uid"] != "c:01F6ZW5QKMJY5Q0ZPD3JXK0V3T" {
+		t.Errorf("expected uid to be the previous id, got %v", v1["uid"])
+	}
+}
+
+func TestAccResourceTmcClusterImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcClusterConfig(server, "first description", "default"),
+				ResourceName:  "tmc_cluster.test",
+				ImportState:   true,
+				ImportStateId: "tf-acc-cluster",
+				ExpectError:   regexp.MustCompile(`expected management_cluster/provisioner_name/name`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterCreateFailure(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.FailCluster("tf-acc-cluster", "insufficient capacity in availability zone us-west-2a")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "aws-hosted", "tf-acc", "tf-acc-cluster"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTmcClusterConfig(server, "first description", "default"),
+				ExpectError: regexp.MustCompile(`Ready: insufficient capacity in availability zone us-west-2a`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterDestroyFailed(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.FailCluster("tf-acc-cluster", "insufficient capacity in availability zone us-west-2a")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "aws-hosted", "tf-acc", "tf-acc-cluster"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTmcClusterConfig(server, "first description", "default"),
+				ExpectError: regexp.MustCompile(`Ready: insufficient capacity in availability zone us-west-2a`),
+			},
+			// The cluster stays in the ERROR phase while it is deleted
+			{
+				Config:  testAccResourceTmcClusterConfig(server, "first description", "default"),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterDestroyUnsettled(t *testing.T) {
+	for _, phase := range []string{"UPGRADE_FAILED", ""} {
+		t.Run(fmt.Sprintf("phase %q", phase), func(t *testing.T) {
+			server := tmcfake.NewServer()
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				CheckDestroy:      testAccCheckTmcClusterDestroy(server, "aws-hosted", "tf-acc", "tf-acc-cluster"),
+				Steps: testAccDestroyInPhaseSteps(testAccResourceTmcClusterConfig(server, "first description", "default"), func() {
+					server.SetClusterPhase("aws-hosted", "tf-acc", "tf-acc-cluster", phase)
+				}),
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcClusterTkgAws(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "aws-hosted", "tf-acc", "tf-acc-aws"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterTkgAwsConfig(server, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcClusterTkgAws(server, "aws-hosted", "tf-acc", "tf-acc-aws", "us-west-2", "1"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.#", "1"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.region", "us-west-2"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.availability_zones.0", "us-west-2a"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.high_availability", "false"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.vpc_cidrblock", "10.0.0.0/16"),
+					resource.TestCheckResourceAttrSet("tmc_cluster.test", "tkg_aws.0.vpc_id"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.subnet.#", "2"),
+					resource.TestCheckResourceAttrSet("tmc_cluster.test", "tkg_aws.0.subnet.0.id"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.pods_cidrblocks.0", "192.168.0.0/16"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.services_cidrblocks.0", "10.96.0.0/12"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.node_pool.0.name", "default-node-pool"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.node_pool.0.worker_node_count", "1"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.node_pool.0.node_labels.role", "worker"),
+				),
+			},
+			{
+				Config: testAccResourceTmcClusterTkgAwsConfig(server, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcClusterTkgAws(server, "aws-hosted", "tf-acc", "tf-acc-aws", "us-west-2", "3"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_aws.0.node_pool.0.worker_node_count", "3"),
+				),
+			},
+			{
+				ResourceName:      "tmc_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterTkgAwsValidation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	cases := []struct {
+		tkgAws string
+		err    string
+	}{
+		{
+			tkgAws: `availability_zones = ["us-east-1a"]`,
+			err:    `availability zone us-east-1a is not in region us-west-2`,
+		},
+		{
+			tkgAws: `availability_zones = ["us-west-2a"]
+    high_availability  = true`,
+			err: `a highly available control plane needs 3 availability zones, got 1`,
+		},
+		{
+			tkgAws: `availability_zones = ["us-west-2a", "us-west-2b", "us-west-2c"]`,
+			err:    `a single control plane node needs exactly 1 availability zone, got 3`,
+		},
+		{
+			tkgAws: `availability_zones  = ["us-west-2a"]
+    pods_cidrblocks     = ["10.96.0.0/16"]
+    services_cidrblocks = ["10.96.0.0/12"]`,
+			err: `pods CIDR block 10.96.0.0/16 overlaps with services CIDR block 10.96.0.0/12`,
+		},
+		{
+			tkgAws: `availability_zones = ["us-west-2a"]
+    vpc_cidrblock      = "192.168.0.0/16"
+    pods_cidrblocks    = ["192.168.0.0/16"]`,
+			err: `CIDR block 192.168.0.0/16 overlaps with the VPC CIDR block 192.168.0.0/16`,
+		},
+		{
+			tkgAws: `availability_zones = ["us-west-2a"]
+    vpc_cidrblock      = "10.0.0.0/16"
+
+    subnet {
+      availability_zone = "us-west-2a"
+      cidr_block        = "10.1.0.0/24"
+    }`,
+			err: `subnet CIDR block 10.1.0.0/24 is not within the VPC CIDR block 10.0.0.0/16`,
+		},
+		{
+			tkgAws: `availability_zones = ["us-west-2a"]
+    vpc_cidrblock      = "10.0.0.0/33"`,
+			err: `expected "tkg_aws.0.vpc_cidrblock" to be a valid IPv4 Value`,
+		},
+		{
+			tkgAws: `availability_zones = ["us-west-2a"]
+
+    node_pool {
+      name              = "default-node-pool"
+      worker_node_count = 1
+      instance_type     = "m5.large"
+      availability_zone = "us-west-2b"
+    }`,
+			err: `node pool default-node-pool is in availability zone us-west-2b, which is not one of the availability zones of the cluster`,
+		},
+	}
+
+	steps := make([]resource.TestStep, 0, len(cases))
+	for _, c := range cases {
+		steps = append(steps, resource.TestStep{
+			Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-aws"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "tf-acc"
+
+  tkg_aws {
+    region          = "us-west-2"
+    version         = "1.20.5-1-amazon2"
+    credential_name = "tf-acc-aws-credential"
+    instance_type   = "m5.large"
+    ssh_key         = "tf-acc"
+    %s
+  }
+}
+`, c.tkgAws),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(regexp.QuoteMeta(c.err)),
+		})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps:             steps,
+	})
+}
+
+func testAccResourceTmcClusterTkgAwsConfig(server *tmcfake.Server, workerNodeCount int) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-aws"
+  management_cluster = "aws-hosted"
+  provisioner_name   = "tf-acc"
+
+  tkg_aws {
+    region             = "us-west-2"
+    version            = "1.20.5-1-amazon2"
+    credential_name    = "tf-acc-aws-credential"
+    availability_zones = ["us-west-2a"]
+    instance_type      = "m5.large"
+    ssh_key            = "tf-acc"
+
+    node_pool {
+      name              = "default-node-pool"
+      worker_node_count = %d
+      instance_type     = "m5.large"
+      availability_zone = "us-west-2a"
+
+      node_labels = {
+        role = "worker"
+      }
+    }
+  }
+}
+`, workerNodeCount)
+}
+
+func testAccCheckTmcClusterTkgAws(server *tmcfake.Server, managementCluster, provisioner, name, region, workerNodeCount string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cluster, ok := server.Cluster(managementCluster, provisioner, name)
+		if !ok {
+			return fmt.Errorf("cluster %s was not created", name)
+		}
+
+		tkgAws, _ := cluster["spec"].(map[string]interface{})["tkgAws"].(map[string]interface{})
+		if tkgAws == nil {
+			return fmt.Errorf("cluster %s was created without a tkgAws spec", name)
+		}
+
+		if got := tkgAws["distribution"].(map[string]interface{})["region"]; got != region {
+			return fmt.Errorf("expected cluster %s in region %s, got %v", name, region, got)
+		}
+
+		nodePools := tkgAws["topology"].(map[string]interface{})["nodePools"].([]interface{})
+		spec := nodePools[0].(map[string]interface{})["spec"].(map[string]interface{})
+		if got := spec["workerNodeCount"]; got != workerNodeCount {
+			return fmt.Errorf("expected %s worker nodes in cluster %s, got %v", workerNodeCount, name, got)
+		}
+
+		return nil
+	}
+}
+
+func TestAccResourceTmcClusterTkgVsphere(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "vsphere-tkg", "default", "tf-acc-vsphere"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterTkgVsphereConfig(server, "v1.20.5+vmware.2-tkg.1", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcClusterExists(server, "vsphere-tkg", "default", "tf-acc-vsphere"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.#", "1"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.datacenter", "/dc0"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.workspace_network", "/dc0/network/VM Network"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.control_plane_endpoint", "10.0.0.10"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.pods_cidrblocks.0", "100.96.0.0/11"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.services_cidrblocks.0", "100.64.0.0/13"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.control_plane.0.cpu", "2"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.control_plane.0.memory_mib", "8192"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.node_pool.0.worker_node_count", "1"),
+				),
+			},
+			{
+				Config: testAccResourceTmcClusterTkgVsphereConfig(server, "v1.21.2+vmware.1-tkg.1", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.version", "v1.21.2+vmware.1-tkg.1"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_vsphere.0.node_pool.0.worker_node_count", "2"),
+				),
+			},
+			{
+				ResourceName:      "tmc_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterTkgVsphereValidation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-vsphere"
+  management_cluster = "vsphere-tkg"
+  provisioner_name   = "default"
+
+  tkg_vsphere {
+    version             = "v1.20.5+vmware.2-tkg.1"
+    datacenter          = "/dc0"
+    datastore           = "/dc0/datastore/local-0"
+    folder              = "/dc0/vm"
+    resource_pool       = "/dc0/host/cluster0/Resources"
+    workspace_network   = "/dc0/network/VM Network"
+    ssh_key             = "ssh-rsa AAAA tf-acc"
+    pods_cidrblocks     = ["100.64.0.0/11"]
+    services_cidrblocks = ["100.64.0.0/13"]
+
+    control_plane {
+      cpu        = 2
+      memory_mib = 8192
+      disk_gib   = 40
+    }
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`pods CIDR block 100.64.0.0/11 overlaps with services CIDR block 100.64.0.0/13`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcClusterTkgVsphereConfig(server *tmcfake.Server, version string, workerNodeCount int) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-vsphere"
+  management_cluster = "vsphere-tkg"
+  provisioner_name   = "default"
+
+  tkg_vsphere {
+    version                = %q
+    datacenter             = "/dc0"
+    datastore              = "/dc0/datastore/local-0"
+    folder                 = "/dc0/vm"
+    resource_pool          = "/dc0/host/cluster0/Resources"
+    workspace_network      = "/dc0/network/VM Network"
+    control_plane_endpoint = "10.0.0.10"
+    ssh_key                = "ssh-rsa AAAA tf-acc"
+
+    control_plane {
+      cpu        = 2
+      memory_mib = 8192
+      disk_gib   = 40
+    }
+
+    node_pool {
+      name              = "default-nodepool"
+      worker_node_count = %d
+      cpu               = 2
+      memory_mib        = 8192
+      disk_gib          = 40
+    }
+  }
+}
+`, version, workerNodeCount)
+}
+
+func TestAccResourceTmcClusterTkgServiceVsphere(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcClusterDestroy(server, "supervisor", "tf-acc-namespace", "tf-acc-tkgs"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterTkgServiceVsphereConfig(server, "best-effort-small", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcClusterExists(server, "supervisor", "tf-acc-namespace", "tf-acc-tkgs"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.#", "1"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.version", "v1.20.7+vmware.1-tkg.1.7fb9067"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.storage_classes.0", "vsan-default-storage-policy"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.pods_cidrblocks.0", "192.168.0.0/16"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.control_plane.0.replicas", "3"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.node_pool.0.class", "best-effort-small"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.node_pool.0.volume.0.capacity", "50"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.node_pool.0.volume.0.storage_class", "vsan-default-storage-policy"),
+				),
+			},
+			{
+				Config: testAccResourceTmcClusterTkgServiceVsphereConfig(server, "best-effort-medium", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.node_pool.0.class", "best-effort-medium"),
+					resource.TestCheckResourceAttr("tmc_cluster.test", "tkg_service_vsphere.0.node_pool.0.worker_node_count", "2"),
+				),
+			},
+			{
+				ResourceName:      "tmc_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterTkgServiceVsphereValidation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-tkgs"
+  management_cluster = "supervisor"
+  provisioner_name   = "tf-acc-namespace"
+
+  tkg_service_vsphere {
+    version               = "v1.20.7+vmware.1-tkg.1.7fb9067"
+    storage_classes       = ["vsan-default-storage-policy"]
+    default_storage_class = "gold"
+
+    control_plane {
+      class         = "best-effort-small"
+      storage_class = "vsan-default-storage-policy"
+    }
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`default storage class gold is not one of the storage_classes of the cluster`),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-tkgs"
+  management_cluster = "supervisor"
+  provisioner_name   = "tf-acc-namespace"
+
+  tkg_service_vsphere {
+    version = "v1.20.7+vmware.1-tkg.1.7fb9067"
+
+    control_plane {
+      class         = "best-effort-small"
+      storage_class = "vsan-default-storage-policy"
+      replicas      = 2
+    }
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expected tkg_service_vsphere.0.control_plane.0.replicas to be one of \[1 3\], got 2`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcClusterTkgServiceVsphereConfig(server *tmcfake.Server, class string, workerNodeCount int) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster" "test" {
+  name               = "tf-acc-tkgs"
+  management_cluster = "supervisor"
+  provisioner_name   = "tf-acc-namespace"
+
+  tkg_service_vsphere {
+    version = "v1.20.7+vmware.1-tkg.1.7fb9067"
+
+    control_plane {
+      class         = "best-effort-small"
+      storage_class = "vsan-default-storage-policy"
+      replicas      = 3
+    }
+
+    node_pool {
+      name              = "default-nodepool"
+      worker_node_count = %d
+      class             = %q
+      storage_class     = "vsan-default-storage-policy"
+
+      volume {
+        name          = "containerd"
+        mount_path    = "/var/lib/containerd"
+        capacity      = 50
+        storage_class = "vsan-default-storage-policy"
+      }
+    }
+  }
+}
+`, workerNodeCount, class)
+}
@@ -0,0 +1,183 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func resourceTmcContinuousDelivery() *schema.Resource {
+	attributes := fluxScopeSchema()
+	attributes["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the continuous delivery, made of the kind of object it is enabled on and the names of the object, like cluster_group/my-group",
+	}
+	attributes["uid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Unique ID of the continuous delivery",
+	}
+	attributes["phase"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Phase of the installation of Flux",
+	}
+
+	return &schema.Resource{
+		ReadContext:   resourceTmcContinuousDeliveryRead,
+		CreateContext: resourceTmcContinuousDeliveryCreate,
+		DeleteContext: resourceTmcContinuousDeliveryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := importFluxID(d); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: attributes,
+	}
+}
+
+func resourceTmcContinuousDeliveryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	continuousDelivery, err := client.GetContinuousDelivery(ctx, fluxScope(d))
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Continuous delivery %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("uid", continuousDelivery.Meta.UID)
+
+	if continuousDelivery.Status != nil {
+		d.Set("phase", continuousDelivery.Status.Phase)
+	}
+
+	return diags
+}
+
+func resourceTmcContinuousDeliveryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	_, err := client.EnableContinuousDelivery(ctx, fluxScope(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to enable continuous delivery",
+			Detail:   fmt.Sprintf("Cannot enable continuous delivery on %s: %s", fluxID(d), err),
+		})
+		return diags
+	}
+
+	d.SetId(fluxID(d))
+
+	if err := waitForContinuousDeliveryReady(ctx, client, d, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcContinuousDeliveryRead(ctx, d, meta)
+}
+
+func resourceTmcContinuousDeliveryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	err := client.DisableContinuousDelivery(ctx, fluxScope(d))
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to disable continuous delivery",
+			Detail:   fmt.Sprintf("Cannot disable continuous delivery on %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	if err := waitForContinuousDeliveryDisabled(ctx, client, d, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// waitForContinuousDeliveryReady polls the continuous delivery of a cluster
+// or cluster group until Flux is installed.
+func waitForContinuousDeliveryReady(ctx context.Context, client *tanzuclient.Client, d *schema.ResourceData, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			"",
+			tanzuclient.ContinuousDeliveryPhasePending,
+			tanzuclient.ContinuousDeliveryPhaseCreating,
+		},
+		Target:       []string{tanzuclient.ContinuousDeliveryPhaseReady},
+		Refresh:      continuousDeliveryPhaseRefreshFunc(ctx, client, fluxScope(d), false),
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for continuous delivery %s to become ready: %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+// waitForContinuousDeliveryDisabled polls the continuous delivery of a
+// cluster or cluster group until Flux is removed.
+func waitForContinuousDeliveryDisabled(ctx context.Context, client *tanzuclient.Client, d *schema.ResourceData, timeout time.Duration) error {
+	if err := waitForDeletion(ctx, continuousDeliveryPhaseRefreshFunc(ctx, client, fluxScope(d), true), timeout); err != nil {
+		return fmt.Errorf("error waiting for continuous delivery %s to be disabled: %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+// continuousDeliveryPhaseRefreshFunc reports the phase of the continuous
+// delivery of a cluster or cluster group. The ERROR phase fails the wait,
+// unless Flux is being removed.
+func continuousDeliveryPhaseRefreshFunc(ctx context.Context, client *tanzuclient.Client, scope tanzuclient.FluxScope, deleting bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		continuousDelivery, err := client.GetContinuousDelivery(ctx, scope)
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if continuousDelivery.Status == nil {
+			return continuousDelivery, "", nil
+		}
+
+		if continuousDelivery.Status.Phase == tanzuclient.ContinuousDeliveryPhaseError && !deleting {
+			return continuousDelivery, continuousDelivery.Status.Phase, statusError("continuous delivery", continuousDelivery.Status.Phase, continuousDelivery.Status.Conditions)
+		}
+
+		return continuousDelivery, continuousDelivery.Status.Phase, nil
+	}
+}
@@ -0,0 +1,157 @@
+package tmc
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcContinuousDeliveryCluster(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if _, ok := server.ContinuousDelivery("aws-hosted", "tf-acc", "tf-acc-cluster"); ok {
+				return fmt.Errorf("continuous delivery is still enabled on cluster tf-acc-cluster")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcContinuousDeliveryClusterConfig(server),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_continuous_delivery.test", "id", "cluster/aws-hosted/tf-acc/tf-acc-cluster"),
+					resource.TestCheckResourceAttrSet("tmc_continuous_delivery.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_continuous_delivery.test", "phase", "READY"),
+					func(s *terraform.State) error {
+						if _, ok := server.ContinuousDelivery("aws-hosted", "tf-acc", "tf-acc-cluster"); !ok {
+							return fmt.Errorf("continuous delivery was not enabled on cluster tf-acc-cluster")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "tmc_continuous_delivery.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcContinuousDeliveryClusterGroup(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if _, ok := server.ClusterGroupContinuousDelivery("tf-acc-group"); ok {
+				return fmt.Errorf("continuous delivery is still enabled on cluster group tf-acc-group")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcContinuousDeliveryClusterGroupConfig(server),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_continuous_delivery.test", "id", "cluster_group/tf-acc-group"),
+					resource.TestCheckResourceAttr("tmc_continuous_delivery.test", "phase", "READY"),
+				),
+			},
+			{
+				ResourceName:      "tmc_continuous_delivery.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcContinuousDeliveryDestroyUnsettled(t *testing.T) {
+	for _, phase := range []string{"ERROR", ""} {
+		t.Run(fmt.Sprintf("phase %q", phase), func(t *testing.T) {
+			server := tmcfake.NewServer()
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				CheckDestroy: func(s *terraform.State) error {
+					if _, ok := server.ContinuousDelivery("aws-hosted", "tf-acc", "tf-acc-cluster"); ok {
+						return fmt.Errorf("continuous delivery is still enabled on cluster tf-acc-cluster")
+					}
+					return nil
+				},
+				Steps: testAccDestroyInPhaseSteps(testAccResourceTmcContinuousDeliveryClusterConfig(server), func() {
+					server.SetContinuousDeliveryPhase("aws-hosted", "tf-acc", "tf-acc-cluster", phase)
+				}),
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcContinuousDeliveryMissingClusterGroup(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_continuous_delivery" "test" {
+  cluster_group_name = "tf-acc-missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`Cannot enable continuous delivery on cluster_group/tf-acc-missing`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcContinuousDeliveryImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcContinuousDeliveryClusterGroupConfig(server),
+				ResourceName:  "tmc_continuous_delivery.test",
+				ImportState:   true,
+				ImportStateId: "cluster/tf-acc-cluster",
+				ExpectError:   regexp.MustCompile(`expected cluster/management_cluster/provisioner_name/cluster_name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcContinuousDeliveryClusterConfig(server *tmcfake.Server) string {
+	return testAccResourceTmcClusterConfig(server, "first description", "default") + `
+resource "tmc_continuous_delivery" "test" {
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+}
+`
+}
+
+func testAccResourceTmcContinuousDeliveryClusterGroupConfig(server *tmcfake.Server) string {
+	return testAccProviderConfig(server) + `
+resource "tmc_cluster_group" "test" {
+  name = "tf-acc-group"
+}
+
+resource "tmc_continuous_delivery" "test" {
+  cluster_group_name = tmc_cluster_group.test.name
+}
+`
+}
@@ -0,0 +1,493 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// credentialTypes are the blocks describing the account a credential gives
+// access to, one per kind of credential.
+var credentialTypes = []string{"aws_iam_role", "azure_service_principal", "s3", "azure_blob"}
+
+func resourceTmcCredential() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcCredentialRead,
+		CreateContext: resourceTmcCredentialCreate,
+		UpdateContext: resourceTmcCredentialUpdate,
+		DeleteContext: resourceTmcCredentialDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the credential",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the credential",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the credential",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the credential",
+			},
+			"aws_iam_role": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ForceNew:     true,
+				ExactlyOneOf: credentialTypes,
+				Description:  "IAM role of an AWS account which TMC assumes to provision TKG clusters on AWS",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_arn": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "ARN of the IAM role, created from cloudformation_template. The credential stays in the PENDING phase until it is set",
+						},
+						"external_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "External ID TMC passes when it assumes the IAM role",
+						},
+					},
+				},
+			},
+			"azure_service_principal": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ForceNew:     true,
+				ExactlyOneOf: credentialTypes,
+				Description:  "Service principal of an Azure subscription which TMC uses to provision clusters on Azure",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subscription_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the subscription",
+						},
+						"tenant_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the Azure Active Directory tenant of the service principal",
+						},
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Application ID of the service principal",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Secret of the service principal, which TMC never returns",
+						},
+						"resource_group": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Resource group the service principal is limited to",
+						},
+						"azure_cloud_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "AzurePublicCloud",
+							Description: "Azure cloud of the subscription, like AzurePublicCloud or AzureUSGovernmentCloud",
+						},
+					},
+				},
+			},
+			"s3": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ForceNew:     true,
+				ExactlyOneOf: credentialTypes,
+				Description:  "Access key of Amazon S3, or of an S3 compatible storage, which Velero uses to keep backups in a backup location",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_key_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the access key, which TMC never returns",
+						},
+						"secret_access_key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Secret of the access key, which TMC never returns",
+						},
+					},
+				},
+			},
+			"azure_blob": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ForceNew:     true,
+				ExactlyOneOf: credentialTypes,
+				Description:  "Service principal with access to an Azure storage account, which Velero uses to keep backups in a backup location. TMC never returns its attributes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subscription_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the subscription of the storage account",
+						},
+						"tenant_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the Azure Active Directory tenant of the service principal",
+						},
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Application ID of the service principal",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Secret of the service principal",
+						},
+						"resource_group": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Resource group of the storage account",
+						},
+					},
+				},
+			},
+			"capability": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "What TMC uses the credential for, MANAGED_K8S_PROVIDER or DATA_PROTECTION",
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Provider of the account the credential gives access to, like AWS_EC2 or AZURE_AKS",
+			},
+			"cloudformation_template": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "CloudFormation template creating the IAM role of aws_iam_role, to deploy in the AWS account",
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Phase of the credential, VALID once TMC could access the account with it",
+			},
+		},
+	}
+}
+
+func resourceTmcCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	credential, err := client.GetCredential(ctx, d.Id())
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Credential %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", credential.FullName.Name)
+	d.Set("uid", credential.Meta.UID)
+	d.Set("description", credential.Meta.Description)
+
+	if credential.Status != nil {
+		d.Set("phase", credential.Status.Phase)
+		d.Set("cloudformation_template", credential.Status.CloudFormationTemplate)
+	}
+
+	for attribute, value := range flattenCredentialSpec(credential.Spec, d) {
+		if err := d.Set(attribute, value); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read credential",
+				Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", attribute, d.Id(), err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTmcCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	credentialName := d.Get("name").(string)
+
+	_, err := client.CreateCredential(ctx, credentialName, d.Get("description").(string), expandCredentialSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create credential",
+			Detail:   fmt.Sprintf("Cannot create the credential %s: %s", credentialName, err),
+		})
+		return diags
+	}
+
+	d.SetId(credentialName)
+
+	if err := waitForCredentialChecked(ctx, client, credentialName, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcCredentialRead(ctx, d, meta)
+}
+
+func resourceTmcCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	_, err := client.UpdateCredential(ctx, d.Id(), d.Get("description").(string), expandCredentialSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update credential",
+			Detail:   fmt.Sprintf("Cannot update the credential %s with the new values: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	if err := waitForCredentialChecked(ctx, client, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcCredentialRead(ctx, d, meta)
+}
+
+func resourceTmcCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	err := client.DeleteCredential(ctx, d.Id())
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete credential",
+			Detail:   fmt.Sprintf("Cannot delete the credential %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// waitForCredentialChecked polls a credential until TMC checked the access it
+// gives. AWS credentials without an IAM role are left waiting for it.
+func waitForCredentialChecked(ctx context.Context, client *tanzuclient.Client, name string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			"",
+			tanzuclient.CredentialPhaseCreated,
+		},
+		Target: []string{
+			tanzuclient.CredentialPhaseValid,
+			tanzuclient.CredentialPhasePending,
+		},
+		Refresh:      credentialPhaseRefreshFunc(ctx, client, name),
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for credential %s to be checked: %w", name, err)
+	}
+
+	return nil
+}
+
+func credentialPhaseRefreshFunc(ctx context.Context, client *tanzuclient.Client, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		credential, err := client.GetCredential(ctx, name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if credential.Status == nil {
+			return credential, "", nil
+		}
+
+		switch credential.Status.Phase {
+		case tanzuclient.CredentialPhaseInvalid, tanzuclient.CredentialPhaseError:
+			return credential, credential.Status.Phase, statusError("credential", credential.Status.Phase, credential.Status.Conditions)
+		}
+
+		return credential, credential.Status.Phase, nil
+	}
+}
+
+func expandCredentialSpec(d *schema.ResourceData) *tanzuclient.CredentialSpec {
+	spec := &tanzuclient.CredentialSpec{
+		Meta: &tanzuclient.CredentialMeta{},
+		Data: &tanzuclient.CredentialData{},
+	}
+
+	if role := d.Get("aws_iam_role").([]interface{}); len(role) > 0 && role[0] != nil {
+		account := role[0].(map[string]interface{})
+
+		spec.Meta.Provider = tanzuclient.CredentialProviderAWSEC2
+		spec.Capability = tanzuclient.CredentialCapabilityManagedK8sProvider
+		spec.Data.AWSCredential = &tanzuclient.AWSCredential{
+			IAMRole: &tanzuclient.CredentialIAMRole{
+				ARN:   account["role_arn"].(string),
+				ExtID: account["external_id"].(string),
+			},
+		}
+	}
+
+	if principal := d.Get("azure_service_principal").([]interface{}); len(principal) > 0 && principal[0] != nil {
+		account := principal[0].(map[string]interface{})
+
+		spec.Meta.Provider = tanzuclient.CredentialProviderAzureAKS
+		spec.Capability = tanzuclient.CredentialCapabilityManagedK8sProvider
+		spec.Data.AzureCredential = &tanzuclient.AzureCredential{
+			ServicePrincipal: &tanzuclient.AzureServicePrincipal{
+				SubscriptionID: account["subscription_id"].(string),
+				TenantID:       account["tenant_id"].(string),
+				ResourceGroup:  account["resource_group"].(string),
+				ClientID:       account["client_id"].(string),
+				ClientSecret:   account["client_secret"].(string),
+				AzureCloudName: account["azure_cloud_name"].(string),
+			},
+		}
+	}
+
+	if s3 := d.Get("s3").([]interface{}); len(s3) > 0 && s3[0] != nil {
+		account := s3[0].(map[string]interface{})
+
+		spec.Meta.Provider = tanzuclient.CredentialProviderGenericS3
+		spec.Capability = tanzuclient.CredentialCapabilityDataProtection
+		spec.Data.KeyValue = &tanzuclient.KeyValueCredential{
+			Data: map[string]string{
+				tanzuclient.CredentialKeyAWSAccessKeyID:     account["access_key_id"].(string),
+				tanzuclient.CredentialKeyAWSSecretAccessKey: account["secret_access_key"].(string),
+			},
+		}
+	}
+
+	if blob := d.Get("azure_blob").([]interface{}); len(blob) > 0 && blob[0] != nil {
+		account := blob[0].(map[string]interface{})
+
+		spec.Meta.Provider = tanzuclient.CredentialProviderAzureAD
+		spec.Capability = tanzuclient.CredentialCapabilityDataProtection
+		spec.Data.KeyValue = &tanzuclient.KeyValueCredential{
+			Data: map[string]string{
+				tanzuclient.CredentialKeyAzureSubscriptionID: account["subscription_id"].(string),
+				tanzuclient.CredentialKeyAzureTenantID:       account["tenant_id"].(string),
+				tanzuclient.CredentialKeyAzureClientID:       account["client_id"].(string),
+				tanzuclient.CredentialKeyAzureClientSecret:   account["client_secret"].(string),
+				tanzuclient.CredentialKeyAzureResourceGroup:  account["resource_group"].(string),
+			},
+		}
+	}
+
+	return spec
+}
+
+// flattenCredentialSpec returns the values of the attributes describing the
+// credential, by attribute name. As TMC never returns secrets, they are kept
+// from the state, and are left empty when the credential is imported.
+func flattenCredentialSpec(spec *tanzuclient.CredentialSpec, d *schema.ResourceData) map[string]interface{} {
+	data := map[string]interface{}{
+		"capability":              "",
+		"provider_name":           "",
+		"aws_iam_role":            []interface{}{},
+		"azure_service_principal": []interface{}{},
+		"s3":                      []interface{}{},
+		"azure_blob":              []interface{}{},
+	}
+	if spec == nil || spec.Meta == nil {
+		return data
+	}
+
+	data["capability"] = spec.Capability
+	data["provider_name"] = spec.Meta.Provider
+
+	account := spec.Data
+	if account == nil {
+		account = &tanzuclient.CredentialData{}
+	}
+
+	switch spec.Meta.Provider {
+	case tanzuclient.CredentialProviderAWSEC2:
+		role := map[string]interface{}{
+			"role_arn":    "",
+			"external_id": "",
+		}
+		if account.AWSCredential != nil && account.AWSCredential.IAMRole != nil {
+			role["role_arn"] = account.AWSCredential.IAMRole.ARN
+			role["external_id"] = account.AWSCredential.IAMRole.ExtID
+		}
+		data["aws_iam_role"] = []interface{}{role}
+	case tanzuclient.CredentialProviderAzureAKS:
+		principal := map[string]interface{}{
+			"client_secret": d.Get("azure_service_principal.0.client_secret").(string),
+		}
+		if account.AzureCredential != nil && account.AzureCredential.ServicePrincipal != nil {
+			servicePrincipal := account.AzureCredential.ServicePrincipal
+			principal["subscription_id"] = servicePrincipal.SubscriptionID
+			principal["tenant_id"] = servicePrincipal.TenantID
+			principal["client_id"] = servicePrincipal.ClientID
+			principal["resource_group"] = servicePrincipal.ResourceGroup
+			principal["azure_cloud_name"] = servicePrincipal.AzureCloudName
+		}
+		data["azure_service_principal"] = []interface{}{principal}
+	case tanzuclient.CredentialProviderGenericS3:
+		data["s3"] = []interface{}{map[string]interface{}{
+			"access_key_id":     d.Get("s3.0.access_key_id").(string),
+			"secret_access_key": d.Get("s3.0.secret_access_key").(string),
+		}}
+	case tanzuclient.CredentialProviderAzureAD:
+		data["azure_blob"] = []interface{}{map[string]interface{}{
+			"subscription_id": d.Get("azure_blob.0.subscription_id").(string),
+			"tenant_id":       d.Get("azure_blob.0.tenant_id").(string),
+			"client_id":       d.Get("azure_blob.0.client_id").(string),
+			"client_secret":   d.Get("azure_blob.0.client_secret").(string),
+			"resource_group":  d.Get("azure_blob.0.resource_group").(string),
+		}}
+	}
+
+	return data
+}
@@ -0,0 +1,280 @@
+package tmc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcCredentialAWS(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	var externalID string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcCredentialDestroy(server, "tf-acc-credential"),
+		Steps: []resource.TestStep{
+			{
+				// The IAM role is created from the template of the credential
+				Config: testAccResourceTmcCredentialConfig(server, `
+  aws_iam_role {}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_credential.test", "id", "tf-acc-credential"),
+					resource.TestCheckResourceAttrSet("tmc_credential.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "capability", "MANAGED_K8S_PROVIDER"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "provider_name", "AWS_EC2"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "aws_iam_role.0.role_arn", ""),
+					resource.TestCheckResourceAttr("tmc_credential.test", "phase", "PENDING"),
+					resource.TestMatchResourceAttr("tmc_credential.test", "cloudformation_template", regexp.MustCompile(`"sts:ExternalId":"tmc-ext-id-\d+"`)),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources["tmc_credential.test"]
+						externalID = rs.Primary.Attributes["aws_iam_role.0.external_id"]
+						if externalID == "" {
+							return fmt.Errorf("no external ID was generated for the credential")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceTmcCredentialConfig(server, `
+  aws_iam_role {
+    role_arn = "arn:aws:iam::123456789012:role/tf-acc-tmc"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_credential.test", "aws_iam_role.0.role_arn", "arn:aws:iam::123456789012:role/tf-acc-tmc"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "phase", "VALID"),
+					func(s *terraform.State) error {
+						return testAccCheckTmcCredentialData(server, "tf-acc-credential", "awsCredential", tmcfake.Object{
+							"iamRole": tmcfake.Object{
+								"arn":   "arn:aws:iam::123456789012:role/tf-acc-tmc",
+								"extId": externalID,
+							},
+						})(s)
+					},
+				),
+			},
+			{
+				ResourceName:      "tmc_credential.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcCredentialAzure(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcCredentialDestroy(server, "tf-acc-credential"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcCredentialConfig(server, `
+  azure_service_principal {
+    subscription_id = "tf-acc-subscription"
+    tenant_id       = "tf-acc-tenant"
+    client_id       = "tf-acc-client"
+    client_secret   = "tf-acc-secret"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_credential.test", "capability", "MANAGED_K8S_PROVIDER"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "provider_name", "AZURE_AKS"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "azure_service_principal.0.client_secret", "tf-acc-secret"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "azure_service_principal.0.azure_cloud_name", "AzurePublicCloud"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "cloudformation_template", ""),
+					resource.TestCheckResourceAttr("tmc_credential.test", "phase", "VALID"),
+					testAccCheckTmcCredentialData(server, "tf-acc-credential", "azureCredential", tmcfake.Object{
+						"servicePrincipal": tmcfake.Object{
+							"subscriptionId": "tf-acc-subscription",
+							"tenantId":       "tf-acc-tenant",
+							"clientId":       "tf-acc-client",
+							"clientSecret":   "tf-acc-secret",
+							"azureCloudName": "AzurePublicCloud",
+						},
+					}),
+				),
+			},
+			{
+				ResourceName:            "tmc_credential.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"azure_service_principal.0.client_secret"},
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcCredentialDataProtection(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcCredentialDestroy(server, "tf-acc-credential"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcCredentialConfig(server, `
+  s3 {
+    access_key_id     = "tf-acc-key"
+    secret_access_key = "tf-acc-secret"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_credential.test", "capability", "DATA_PROTECTION"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "provider_name", "GENERIC_S3"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "s3.0.access_key_id", "tf-acc-key"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "phase", "VALID"),
+					testAccCheckTmcCredentialData(server, "tf-acc-credential", "keyValue", tmcfake.Object{
+						"data": tmcfake.Object{
+							"aws_access_key_id":     "tf-acc-key",
+							"aws_secret_access_key": "tf-acc-secret",
+						},
+					}),
+				),
+			},
+			{
+				// Rotating the access key updates the credential in place
+				Config: testAccResourceTmcCredentialConfig(server, `
+  s3 {
+    access_key_id     = "tf-acc-key"
+    secret_access_key = "tf-acc-rotated"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_credential.test", "s3.0.secret_access_key", "tf-acc-rotated"),
+					testAccCheckTmcCredentialData(server, "tf-acc-credential", "keyValue", tmcfake.Object{
+						"data": tmcfake.Object{
+							"aws_access_key_id":     "tf-acc-key",
+							"aws_secret_access_key": "tf-acc-rotated",
+						},
+					}),
+				),
+			},
+			{
+				ResourceName:            "tmc_credential.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"s3.0.access_key_id", "s3.0.secret_access_key"},
+			},
+			{
+				// Moving to another provider replaces the credential
+				Config: testAccResourceTmcCredentialConfig(server, `
+  azure_blob {
+    subscription_id = "tf-acc-subscription"
+    tenant_id       = "tf-acc-tenant"
+    client_id       = "tf-acc-client"
+    client_secret   = "tf-acc-secret"
+    resource_group  = "tf-acc"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_credential.test", "provider_name", "AZURE_AD"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "s3.#", "0"),
+					resource.TestCheckResourceAttr("tmc_credential.test", "azure_blob.0.resource_group", "tf-acc"),
+					testAccCheckTmcCredentialData(server, "tf-acc-credential", "keyValue", tmcfake.Object{
+						"data": tmcfake.Object{
+							"AZURE_SUBSCRIPTION_ID": "tf-acc-subscription",
+							"AZURE_TENANT_ID":       "tf-acc-tenant",
+							"AZURE_CLIENT_ID":       "tf-acc-client",
+							"AZURE_CLIENT_SECRET":   "tf-acc-secret",
+							"AZURE_RESOURCE_GROUP":  "tf-acc",
+						},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcCredentialInvalid(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.InvalidateCredential("tf-acc-credential", "the access key was rejected by S3")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcCredentialConfig(server, `
+  s3 {
+    access_key_id     = "tf-acc-key"
+    secret_access_key = "tf-acc-secret"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Ready: the access key was rejected by S3`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcCredentialValidation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcCredentialConfig(server, `
+  aws_iam_role {}
+
+  s3 {
+    access_key_id     = "tf-acc-key"
+    secret_access_key = "tf-acc-secret"
+  }
+`),
+				ExpectError: regexp.MustCompile(`only one of`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcCredentialConfig(server *tmcfake.Server, account string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_credential" "test" {
+  name        = "tf-acc-credential"
+  description = "Terraform acceptance testing credential"
+%s}
+`, account)
+}
+
+// testAccCheckTmcCredentialData checks the data of the credential received by
+// TMC, including the secrets it never returns.
+func testAccCheckTmcCredentialData(server *tmcfake.Server, name, field string, expected tmcfake.Object) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		credential, ok := server.Credential(name)
+		if !ok {
+			return fmt.Errorf("credential %s was not created", name)
+		}
+
+		data := credential["spec"].(tmcfake.Object)["data"].(tmcfake.Object)
+		if !reflect.DeepEqual(data[field], expected) {
+			return fmt.Errorf("expected %s of credential %s to be %v, got %v", field, name, expected, data[field])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTmcCredentialDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Credential(name); ok {
+			return fmt.Errorf("credential %s still exists", name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,265 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func resourceTmcCustomPolicy() *schema.Resource {
+	attributes := policyScopeSchema()
+	attributes["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the policy, made of the kind of object it is attached to, the names of the object and the name of the policy, like cluster_group/my-group/my-policy",
+	}
+	attributes["uid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Unique ID of the policy",
+	}
+	attributes["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Name of the policy",
+	}
+	attributes["description"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "",
+		Description: "Description of the policy",
+	}
+	attributes["template_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Name of the policy template the policy is built on, either a template of TMC like tmc-block-nodeport-service or one created with tmc_policy_template",
+	}
+	attributes["audit"] = policyAuditSchema()
+	attributes["parameters"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+		Description:      "Parameters of the policy as a JSON object, matching the parameters schema of the template",
+	}
+	attributes["target_kubernetes_resources"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Description: "Kubernetes objects the policy is enforced on",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"api_groups": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "API groups of the objects, with an empty string for the core group",
+				},
+				"kinds": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Kinds of the objects, like Pod or Service",
+				},
+			},
+		},
+	}
+	attributes["namespace_selector"] = namespaceSelectorSchema()
+
+	return &schema.Resource{
+		ReadContext:   resourceTmcCustomPolicyRead,
+		CreateContext: resourceTmcCustomPolicyCreate,
+		UpdateContext: resourceTmcCustomPolicyUpdate,
+		DeleteContext: resourceTmcPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := importPolicyID(d); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: attributes,
+	}
+}
+
+func resourceTmcCustomPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	policy, err := client.GetPolicy(ctx, policyScope(d), d.Get("name").(string))
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Custom policy %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	if policy.Spec == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read custom policy",
+			Detail:   fmt.Sprintf("TMC returned the custom policy %s without a spec", d.Id()),
+		})
+		return diags
+	}
+
+	if policy.Spec.Type != tanzuclient.PolicyTypeCustom {
+		return diag.Errorf("The policy %s is a %s rather than a custom policy, import it as a tmc_policy", d.Id(), policy.Spec.Type)
+	}
+
+	d.Set("uid", policy.Meta.UID)
+	d.Set("description", policy.Meta.Description)
+	d.Set("template_name", policy.Spec.Recipe)
+
+	audit, _ := policy.Spec.Input["audit"].(bool)
+	d.Set("audit", audit)
+
+	parameters := ""
+	if p, ok := policy.Spec.Input["parameters"].(map[string]interface{}); ok && len(p) > 0 {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		parameters = string(b)
+	}
+	d.Set("parameters", parameters)
+
+	targets, _ := policy.Spec.Input["targetKubernetesResources"].([]interface{})
+	if err := d.Set("target_kubernetes_resources", flattenTargetKubernetesResources(targets)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read custom policy",
+			Detail:   fmt.Sprintf("Error setting target Kubernetes resources for resource %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	if err := d.Set("namespace_selector", flattenNamespaceSelector(policy.Spec.NamespaceSelector)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read custom policy",
+			Detail:   fmt.Sprintf("Error setting namespace selector for resource %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+func resourceTmcCustomPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	spec, err := expandCustomPolicySpec(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.CreatePolicy(ctx, policyScope(d), d.Get("name").(string), d.Get("description").(string), spec)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create custom policy",
+			Detail:   fmt.Sprintf("Cannot create the custom policy %s: %s", policyID(d), err),
+		})
+		return diags
+	}
+
+	d.SetId(policyID(d))
+
+	return resourceTmcCustomPolicyRead(ctx, d, meta)
+}
+
+func resourceTmcCustomPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	spec, err := expandCustomPolicySpec(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.UpdatePolicy(ctx, policyScope(d), d.Get("name").(string), d.Get("description").(string), spec)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update custom policy",
+			Detail:   fmt.Sprintf("Cannot update the custom policy %s with the new values: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	return resourceTmcCustomPolicyRead(ctx, d, meta)
+}
+
+func expandCustomPolicySpec(d *schema.ResourceData) (*tanzuclient.PolicySpec, error) {
+	input := map[string]interface{}{
+		"audit":                     d.Get("audit").(bool),
+		"targetKubernetesResources": expandTargetKubernetesResources(d.Get("target_kubernetes_resources").([]interface{})),
+	}
+
+	if parameters := d.Get("parameters").(string); parameters != "" {
+		p := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(parameters), &p); err != nil {
+			return nil, fmt.Errorf("parameters must be a JSON object: %s", err)
+		}
+		input["parameters"] = p
+	}
+
+	return &tanzuclient.PolicySpec{
+		Type:              tanzuclient.PolicyTypeCustom,
+		Recipe:            d.Get("template_name").(string),
+		RecipeVersion:     "v1",
+		Input:             input,
+		NamespaceSelector: expandNamespaceSelector(d.Get("namespace_selector").([]interface{})),
+	}, nil
+}
+
+func expandTargetKubernetesResources(data []interface{}) []interface{} {
+	targets := make([]interface{}, 0, len(data))
+
+	for _, t := range data {
+		target := t.(map[string]interface{})
+
+		targets = append(targets, map[string]interface{}{
+			"apiGroups": expandStringList(target["api_groups"].([]interface{})),
+			"kinds":     expandStringList(target["kinds"].([]interface{})),
+		})
+	}
+
+	return targets
+}
+
+func flattenTargetKubernetesResources(targets []interface{}) []interface{} {
+	data := make([]interface{}, 0, len(targets))
+
+	for _, t := range targets {
+		target, _ := t.(map[string]interface{})
+
+		data = append(data, map[string]interface{}{
+			"api_groups": target["apiGroups"],
+			"kinds":      target["kinds"],
+		})
+	}
+
+	return data
+}
@@ -0,0 +1,92 @@
+package tmc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcCustomPolicy(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	regoFile := testAccRegoFile(t, testAccRegoRequiredLabels)
+
+	fullName := tmcfake.Object{"workspaceName": "tf-acc-workspace", "name": "tf-acc-labels"}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcPolicyDestroy(server, fullName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcCustomPolicyConfig(server, regoFile, `["team"]`, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_custom_policy.test", "id", "workspace/tf-acc-workspace/tf-acc-labels"),
+					resource.TestCheckResourceAttr("tmc_custom_policy.test", "template_name", "requiredlabels"),
+					resource.TestCheckResourceAttr("tmc_custom_policy.test", "target_kubernetes_resources.0.kinds.0", "Deployment"),
+					testAccCheckTmcPolicySpec(server, fullName, "custom-policy", "requiredlabels", map[string]interface{}{
+						"audit": false,
+						"parameters": map[string]interface{}{
+							"labels": []interface{}{"team"},
+						},
+						"targetKubernetesResources": []interface{}{
+							map[string]interface{}{
+								"apiGroups": []interface{}{"apps"},
+								"kinds":     []interface{}{"Deployment", "StatefulSet"},
+							},
+						},
+					}),
+				),
+			},
+			{
+				Config: testAccResourceTmcCustomPolicyConfig(server, regoFile, `["team", "owner"]`, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_custom_policy.test", "audit", "true"),
+					testAccCheckTmcPolicySpec(server, fullName, "custom-policy", "requiredlabels", map[string]interface{}{
+						"audit": true,
+						"parameters": map[string]interface{}{
+							"labels": []interface{}{"team", "owner"},
+						},
+						"targetKubernetesResources": []interface{}{
+							map[string]interface{}{
+								"apiGroups": []interface{}{"apps"},
+								"kinds":     []interface{}{"Deployment", "StatefulSet"},
+							},
+						},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_custom_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceTmcCustomPolicyConfig(server *tmcfake.Server, regoFile, labels string, audit bool) string {
+	return testAccResourceTmcPolicyTemplateConfig(server, regoFile, "Requires labels") + fmt.Sprintf(`
+resource "tmc_workspace" "test" {
+  name = "tf-acc-workspace"
+}
+
+resource "tmc_custom_policy" "test" {
+  name           = "tf-acc-labels"
+  workspace_name = tmc_workspace.test.name
+  template_name  = tmc_policy_template.test.name
+  audit          = %t
+
+  parameters = jsonencode({
+    labels = %s
+  })
+
+  target_kubernetes_resources {
+    api_groups = ["apps"]
+    kinds      = ["Deployment", "StatefulSet"]
+  }
+}
+`, audit, labels)
+}
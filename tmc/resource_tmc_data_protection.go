@@ -0,0 +1,254 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// dataProtectionIDFormat is the format of the IDs of the data protection of
+// clusters, which are also used to import it.
+const dataProtectionIDFormat = "management_cluster/provisioner_name/cluster_name"
+
+func resourceTmcDataProtection() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcDataProtectionRead,
+		CreateContext: resourceTmcDataProtectionCreate,
+		UpdateContext: resourceTmcDataProtectionUpdate,
+		DeleteContext: resourceTmcDataProtectionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcDataProtectionImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the data protection in the management_cluster/provisioner_name/cluster_name format",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the data protection",
+			},
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Cluster to back up",
+			},
+			"enable_csi_snapshots": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether volumes are backed up with CSI snapshots rather than copied with Restic",
+			},
+			"delete_backups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the backups of the Cluster are deleted from their backup location when data protection is disabled",
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Phase of the installation of Velero on the Cluster",
+			},
+		},
+	}
+}
+
+func resourceTmcDataProtectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	parts, err := parseID(d.Id(), dataProtectionIDFormat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managementClusterName, provisionerName, clusterName := parts[0], parts[1], parts[2]
+
+	dataProtection, err := client.GetDataProtection(ctx, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Data protection of cluster %s not found, removing from state", clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("management_cluster", managementClusterName)
+	d.Set("provisioner_name", provisionerName)
+	d.Set("cluster_name", clusterName)
+	d.Set("uid", dataProtection.Meta.UID)
+
+	enableCsiSnapshots := false
+	if dataProtection.Spec != nil {
+		enableCsiSnapshots = dataProtection.Spec.EnableCsiSnapshots
+	}
+	d.Set("enable_csi_snapshots", enableCsiSnapshots)
+
+	if dataProtection.Status != nil {
+		d.Set("phase", dataProtection.Status.Phase)
+	}
+
+	return diags
+}
+
+func resourceTmcDataProtectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	spec := &tanzuclient.DataProtectionSpec{
+		EnableCsiSnapshots: d.Get("enable_csi_snapshots").(bool),
+	}
+
+	_, err := client.EnableDataProtection(ctx, clusterName, managementCluster, provisionerName, spec)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to enable data protection",
+			Detail:   fmt.Sprintf("Cannot enable data protection on cluster %s: %s", clusterName, err),
+		})
+		return diags
+	}
+
+	d.SetId(buildID(managementCluster, provisionerName, clusterName))
+
+	if err := waitForDataProtectionReady(ctx, client, managementCluster, provisionerName, clusterName, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTmcDataProtectionRead(ctx, d, meta)
+}
+
+// resourceTmcDataProtectionUpdate only records delete_backups, which is not
+// sent to TMC until data protection is disabled.
+func resourceTmcDataProtectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceTmcDataProtectionRead(ctx, d, meta)
+}
+
+func resourceTmcDataProtectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	err := client.DisableDataProtection(ctx, clusterName, managementCluster, provisionerName, d.Get("delete_backups").(bool))
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to disable data protection",
+			Detail:   fmt.Sprintf("Cannot disable data protection on cluster %s: %s", clusterName, err),
+		})
+		return diags
+	}
+
+	if err := waitForDataProtectionDisabled(ctx, client, managementCluster, provisionerName, clusterName, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTmcDataProtectionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseID(d.Id(), dataProtectionIDFormat); err != nil {
+		return nil, err
+	}
+
+	d.Set("delete_backups", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForDataProtectionReady polls the data protection of a cluster until
+// Velero is installed.
+func waitForDataProtectionReady(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			"",
+			tanzuclient.DataProtectionPhasePending,
+			tanzuclient.DataProtectionPhaseCreating,
+		},
+		Target:       []string{tanzuclient.DataProtectionPhaseReady},
+		Refresh:      dataProtectionPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, false),
+		Timeout:      timeout,
+		PollInterval: clusterPollInterval,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for data protection of cluster %s to become ready: %w", clusterName, err)
+	}
+
+	return nil
+}
+
+// waitForDataProtectionDisabled polls the data protection of a cluster until
+// Velero is removed.
+func waitForDataProtectionDisabled(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName string, timeout time.Duration) error {
+	if err := waitForDeletion(ctx, dataProtectionPhaseRefreshFunc(ctx, client, managementCluster, provisionerName, clusterName, true), timeout); err != nil {
+		return fmt.Errorf("error waiting for data protection of cluster %s to be disabled: %w", clusterName, err)
+	}
+
+	return nil
+}
+
+// dataProtectionPhaseRefreshFunc reports the phase of the data protection of
+// a cluster. The ERROR phase fails the wait, unless Velero is being removed.
+func dataProtectionPhaseRefreshFunc(ctx context.Context, client *tanzuclient.Client, managementCluster, provisionerName, clusterName string, deleting bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		dataProtection, err := client.GetDataProtection(ctx, clusterName, managementCluster, provisionerName)
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+
+		if dataProtection.Status == nil {
+			return dataProtection, "", nil
+		}
+
+		if dataProtection.Status.Phase == tanzuclient.DataProtectionPhaseError && !deleting {
+			return dataProtection, dataProtection.Status.Phase, statusError("data protection", dataProtection.Status.Phase, dataProtection.Status.Conditions)
+		}
+
+		return dataProtection, dataProtection.Status.Phase, nil
+	}
+}
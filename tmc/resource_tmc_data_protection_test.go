@@ -0,0 +1,150 @@
+package tmc
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcDataProtection(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckTmcDataProtectionDestroy(server, "aws-hosted", "tf-acc", "tf-acc-cluster"),
+			// The backups go along with data protection when delete_backups is set
+			func(s *terraform.State) error {
+				if _, ok := server.Backup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup"); ok {
+					return fmt.Errorf("backup tf-acc-backup was not deleted")
+				}
+				return nil
+			},
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcDataProtectionConfig(server, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_data_protection.test", "id", "aws-hosted/tf-acc/tf-acc-cluster"),
+					resource.TestCheckResourceAttrSet("tmc_data_protection.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_data_protection.test", "enable_csi_snapshots", "true"),
+					resource.TestCheckResourceAttr("tmc_data_protection.test", "phase", "READY"),
+					func(s *terraform.State) error {
+						dataProtection, ok := server.DataProtection("aws-hosted", "tf-acc", "tf-acc-cluster")
+						if !ok {
+							return fmt.Errorf("data protection was not enabled")
+						}
+						if spec, _ := dataProtection["spec"].(tmcfake.Object); spec["enableCsiSnapshots"] != true {
+							return fmt.Errorf("expected CSI snapshots to be enabled, got %v", spec)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup", "", "Completed")
+				},
+				Config: testAccResourceTmcDataProtectionConfig(server, true),
+				Check:  resource.TestCheckResourceAttr("tmc_data_protection.test", "delete_backups", "true"),
+			},
+			{
+				ResourceName:            "tmc_data_protection.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_backups"},
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcDataProtectionKeepsBackups(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcDataProtectionConfig(server, false),
+			},
+			{
+				PreConfig: func() {
+					server.AddBackup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup", "", "Completed")
+				},
+				// Only disable data protection, keeping the cluster
+				Config: testAccResourceTmcClusterConfig(server, "first description", "default"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcDataProtectionDestroy(server, "aws-hosted", "tf-acc", "tf-acc-cluster"),
+					func(s *terraform.State) error {
+						if _, ok := server.Backup("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-backup"); !ok {
+							return fmt.Errorf("backup tf-acc-backup was deleted")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcDataProtectionDestroyUnsettled(t *testing.T) {
+	for _, phase := range []string{"ERROR", ""} {
+		t.Run(fmt.Sprintf("phase %q", phase), func(t *testing.T) {
+			server := tmcfake.NewServer()
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				CheckDestroy:      testAccCheckTmcDataProtectionDestroy(server, "aws-hosted", "tf-acc", "tf-acc-cluster"),
+				Steps: testAccDestroyInPhaseSteps(testAccResourceTmcDataProtectionConfig(server, false), func() {
+					server.SetDataProtectionPhase("aws-hosted", "tf-acc", "tf-acc-cluster", phase)
+				}),
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcDataProtectionImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcDataProtectionConfig(server, false),
+				ResourceName:  "tmc_data_protection.test",
+				ImportState:   true,
+				ImportStateId: "tf-acc-cluster",
+				ExpectError:   regexp.MustCompile(`expected management_cluster/provisioner_name/cluster_name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcDataProtectionConfig(server *tmcfake.Server, deleteBackups bool) string {
+	return testAccResourceTmcClusterConfig(server, "first description", "default") + fmt.Sprintf(`
+resource "tmc_data_protection" "test" {
+  management_cluster   = tmc_cluster.test.management_cluster
+  provisioner_name     = tmc_cluster.test.provisioner_name
+  cluster_name         = tmc_cluster.test.name
+  enable_csi_snapshots = true
+  delete_backups       = %t
+}
+`, deleteBackups)
+}
+
+func testAccCheckTmcDataProtectionDestroy(server *tmcfake.Server, managementCluster, provisioner, cluster string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.DataProtection(managementCluster, provisioner, cluster); ok {
+			return fmt.Errorf("data protection of cluster %s is still enabled", cluster)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,242 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// gitRepositoryRefs are the arguments selecting the revision of a Git
+// repository, exactly one of which is set.
+var gitRepositoryRefs = []string{"ref.0.branch", "ref.0.tag", "ref.0.semver"}
+
+func resourceTmcGitRepository() *schema.Resource {
+	attributes := fluxScopeSchema()
+	attributes["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the Git repository, made of the kind of object it is applied to, the names of the object, the namespace and the name of the Git repository, like cluster_group/my-group/tanzu-continuousdelivery-resources/my-repository",
+	}
+	attributes["uid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Unique ID of the Git repository",
+	}
+	attributes["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Name of the Git repository",
+	}
+	attributes["namespace_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     fluxNamespace,
+		Description: "Namespace the Git repository is applied to",
+	}
+	attributes["url"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "ssh"}),
+		Description:  "URL of the Git repository, like https://github.com/my-org/my-repository",
+	}
+	attributes["secret_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Name of the source secret holding the credentials to access the Git repository, when it is not public",
+	}
+	attributes["interval"] = fluxIntervalSchema("How often the Git repository is fetched, like 5m")
+	attributes["ref"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: "Revision of the Git repository to apply",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"branch": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: gitRepositoryRefs,
+					Description:  "Branch to apply the latest commit of",
+				},
+				"tag": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: gitRepositoryRefs,
+					Description:  "Tag to apply",
+				},
+				"semver": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: gitRepositoryRefs,
+					Description:  "Range of versions, like >= 1.0.0, to apply the latest tag matching it",
+				},
+			},
+		},
+	}
+	attributes["phase"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Phase of the Git repository, APPLIED once Flux fetches it",
+	}
+
+	return &schema.Resource{
+		ReadContext:   resourceTmcGitRepositoryRead,
+		CreateContext: resourceTmcGitRepositoryCreate,
+		UpdateContext: resourceTmcGitRepositoryUpdate,
+		DeleteContext: resourceTmcGitRepositoryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := importFluxID(d, "namespace_name", "name"); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: attributes,
+	}
+}
+
+func resourceTmcGitRepositoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	gitRepository, err := client.GetGitRepository(ctx, fluxScope(d), d.Get("namespace_name").(string), d.Get("name").(string))
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Git repository %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("uid", gitRepository.Meta.UID)
+
+	if gitRepository.Status != nil {
+		d.Set("phase", gitRepository.Status.Phase)
+	}
+
+	for attribute, value := range flattenGitRepositorySpec(gitRepository.Spec) {
+		if err := d.Set(attribute, value); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read Git repository",
+				Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", attribute, d.Id(), err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceTmcGitRepositoryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	_, err := client.CreateGitRepository(ctx, fluxScope(d), d.Get("namespace_name").(string), d.Get("name").(string), expandGitRepositorySpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create Git repository",
+			Detail:   fmt.Sprintf("Cannot create the Git repository %s: %s", fluxID(d, "namespace_name", "name"), err),
+		})
+		return diags
+	}
+
+	d.SetId(fluxID(d, "namespace_name", "name"))
+
+	return resourceTmcGitRepositoryRead(ctx, d, meta)
+}
+
+func resourceTmcGitRepositoryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	_, err := client.UpdateGitRepository(ctx, fluxScope(d), d.Get("namespace_name").(string), d.Get("name").(string), expandGitRepositorySpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update Git repository",
+			Detail:   fmt.Sprintf("Cannot update the Git repository %s with the new values: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	return resourceTmcGitRepositoryRead(ctx, d, meta)
+}
+
+func resourceTmcGitRepositoryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	err := client.DeleteGitRepository(ctx, fluxScope(d), d.Get("namespace_name").(string), d.Get("name").(string))
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete Git repository",
+			Detail:   fmt.Sprintf("Cannot delete the Git repository %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func expandGitRepositorySpec(d *schema.ResourceData) *tanzuclient.GitRepositorySpec {
+	ref := d.Get("ref.0").(map[string]interface{})
+
+	return &tanzuclient.GitRepositorySpec{
+		URL:       d.Get("url").(string),
+		SecretRef: d.Get("secret_name").(string),
+		Interval:  d.Get("interval").(string),
+		Ref: &tanzuclient.GitRepositoryRef{
+			Branch: ref["branch"].(string),
+			Tag:    ref["tag"].(string),
+			Semver: ref["semver"].(string),
+		},
+	}
+}
+
+// flattenGitRepositorySpec returns the values of the arguments of the Git
+// repository, by attribute name.
+func flattenGitRepositorySpec(spec *tanzuclient.GitRepositorySpec) map[string]interface{} {
+	if spec == nil {
+		return map[string]interface{}{}
+	}
+
+	data := map[string]interface{}{
+		"url":         spec.URL,
+		"secret_name": spec.SecretRef,
+		"interval":    spec.Interval,
+		"ref":         []interface{}{},
+	}
+	if spec.Ref != nil {
+		data["ref"] = []interface{}{
+			map[string]interface{}{
+				"branch": spec.Ref.Branch,
+				"tag":    spec.Ref.Tag,
+				"semver": spec.Ref.Semver,
+			},
+		}
+	}
+
+	return data
+}
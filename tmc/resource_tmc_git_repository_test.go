@@ -0,0 +1,249 @@
+package tmc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcGitRepositoryCluster(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	get := func() (tmcfake.Object, bool) {
+		return server.GitRepository("aws-hosted", "tf-acc", "tf-acc-cluster", "tanzu-continuousdelivery-resources", "tf-acc-repository")
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcFluxObjectDestroy("Git repository tf-acc-repository", get),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcGitRepositoryClusterConfig(server, `
+  ref {
+    branch = "main"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_git_repository.test", "id", "cluster/aws-hosted/tf-acc/tf-acc-cluster/tanzu-continuousdelivery-resources/tf-acc-repository"),
+					resource.TestCheckResourceAttrSet("tmc_git_repository.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_git_repository.test", "interval", "5m"),
+					resource.TestCheckResourceAttr("tmc_git_repository.test", "ref.0.branch", "main"),
+					resource.TestCheckResourceAttr("tmc_git_repository.test", "phase", "APPLIED"),
+					testAccCheckTmcFluxSpec("Git repository tf-acc-repository", get, tmcfake.Object{
+						"url":      "https://github.com/tf-acc/fleet",
+						"interval": "5m",
+						"ref":      tmcfake.Object{"branch": "main"},
+					}),
+				),
+			},
+			{
+				Config: testAccResourceTmcGitRepositoryClusterConfig(server, `
+  secret_name = "tf-acc-deploy-key"
+  interval    = "10m"
+
+  ref {
+    tag = "v1.2.0"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_git_repository.test", "ref.0.branch", ""),
+					resource.TestCheckResourceAttr("tmc_git_repository.test", "ref.0.tag", "v1.2.0"),
+					testAccCheckTmcFluxSpec("Git repository tf-acc-repository", get, tmcfake.Object{
+						"url":       "https://github.com/tf-acc/fleet",
+						"secretRef": "tf-acc-deploy-key",
+						"interval":  "10m",
+						"ref":       tmcfake.Object{"tag": "v1.2.0"},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_git_repository.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcGitRepositoryClusterGroup(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	get := func() (tmcfake.Object, bool) {
+		return server.ClusterGroupGitRepository("tf-acc-group", "tanzu-continuousdelivery-resources", "tf-acc-repository")
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcFluxObjectDestroy("Git repository tf-acc-repository", get),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcGitRepositoryClusterGroupConfig(server),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_git_repository.test", "id", "cluster_group/tf-acc-group/tanzu-continuousdelivery-resources/tf-acc-repository"),
+					resource.TestCheckResourceAttr("tmc_git_repository.test", "ref.0.semver", ">= 1.0.0"),
+					testAccCheckTmcFluxSpec("Git repository tf-acc-repository", get, tmcfake.Object{
+						"url":      "https://github.com/tf-acc/fleet",
+						"interval": "1h",
+						"ref":      tmcfake.Object{"semver": ">= 1.0.0"},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_git_repository.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcGitRepositoryContinuousDeliveryDisabled(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterConfig(server, "first description", "default") + `
+resource "tmc_git_repository" "test" {
+  name               = "tf-acc-repository"
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+  url                = "https://github.com/tf-acc/fleet"
+
+  ref {
+    branch = "main"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`continuous delivery is not\s+enabled on cluster tf-acc-cluster`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcGitRepositoryValidation(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		settings string
+		expected string
+	}{
+		{
+			name: "two refs",
+			settings: `
+  ref {
+    branch = "main"
+    tag    = "v1.2.0"
+  }
+`,
+			expected: `only one of .ref.0.branch,ref.0.semver,ref.0.tag.`,
+		},
+		{
+			name: "invalid interval",
+			settings: `
+  interval = "5 minutes"
+
+  ref {
+    branch = "main"
+  }
+`,
+			expected: `expected interval to be a duration like 5m`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccResourceTmcGitRepositoryClusterConfig(server, tc.settings),
+						ExpectError: regexp.MustCompile(tc.expected),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccResourceTmcGitRepositoryImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcGitRepositoryClusterGroupConfig(server),
+				ResourceName:  "tmc_git_repository.test",
+				ImportState:   true,
+				ImportStateId: "workspace/tf-acc-workspace/tf-acc-repository",
+				ExpectError:   regexp.MustCompile(`expected one of cluster/management_cluster/provisioner_name/cluster_name/namespace_name/name, cluster_group/cluster_group_name/namespace_name/name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcGitRepositoryClusterConfig(server *tmcfake.Server, settings string) string {
+	return testAccResourceTmcContinuousDeliveryClusterConfig(server) + fmt.Sprintf(`
+resource "tmc_git_repository" "test" {
+  name               = "tf-acc-repository"
+  management_cluster = tmc_continuous_delivery.test.management_cluster
+  provisioner_name   = tmc_continuous_delivery.test.provisioner_name
+  cluster_name       = tmc_continuous_delivery.test.cluster_name
+  url                = "https://github.com/tf-acc/fleet"
+%s}
+`, settings)
+}
+
+func testAccResourceTmcGitRepositoryClusterGroupConfig(server *tmcfake.Server) string {
+	return testAccResourceTmcContinuousDeliveryClusterGroupConfig(server) + `
+resource "tmc_git_repository" "test" {
+  name               = "tf-acc-repository"
+  cluster_group_name = tmc_continuous_delivery.test.cluster_group_name
+  url                = "https://github.com/tf-acc/fleet"
+  interval           = "1h"
+
+  ref {
+    semver = ">= 1.0.0"
+  }
+}
+`
+}
+
+// testAccCheckTmcFluxSpec checks the spec of a Flux object received by TMC.
+func testAccCheckTmcFluxSpec(object string, get func() (tmcfake.Object, bool), expected tmcfake.Object) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		actual, ok := get()
+		if !ok {
+			return fmt.Errorf("%s was not created", object)
+		}
+
+		if spec, _ := actual["spec"].(tmcfake.Object); !reflect.DeepEqual(spec, expected) {
+			return fmt.Errorf("expected spec of %s to be %v, got %v", object, expected, spec)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTmcFluxObjectDestroy(object string, get func() (tmcfake.Object, bool)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := get(); ok {
+			return fmt.Errorf("%s still exists", object)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// resourceTmcIAMMember returns the resource granting a role on an object of
+// the scope to a single user or group, leaving the other role bindings of
+// the object alone.
+func resourceTmcIAMMember(s iamScope) *schema.Resource {
+	attributes := s.schema()
+	attributes["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: fmt.Sprintf("ID of the role binding in the %s format, where member is user:<name> or group:<name>", s.idFormat("role", "member")),
+	}
+	attributes["role"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+		Description:  "Name of the TMC role, like workspace.edit",
+	}
+	attributes["user"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		ExactlyOneOf: []string{"user", "group"},
+		Description:  "User granted the role",
+	}
+	attributes["group"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		ExactlyOneOf: []string{"user", "group"},
+		Description:  "Group granted the role",
+	}
+
+	return &schema.Resource{
+		ReadContext:   resourceTmcIAMMemberRead(s),
+		CreateContext: resourceTmcIAMMemberCreate(s),
+		DeleteContext: resourceTmcIAMMemberDelete(s),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcIAMMemberImport(s),
+		},
+		Schema: attributes,
+	}
+}
+
+func resourceTmcIAMMemberRead(s iamScope) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*tanzuclient.Client)
+
+		var diags diag.Diagnostics
+
+		role := d.Get("role").(string)
+		subject := iamMemberSubject(d)
+
+		policy, err := client.GetIAMPolicy(ctx, s.scope(s.values(d)))
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				log.Printf("[WARN] %s %s not found, removing role binding from state", s.object, d.Id())
+				d.SetId("")
+				return diags
+			}
+			return diag.FromErr(err)
+		}
+
+		for _, roleBinding := range policy.RoleBindings {
+			if roleBinding.Role != role {
+				continue
+			}
+			for _, bound := range roleBinding.Subjects {
+				if bound.Name == subject.Name && strings.EqualFold(bound.Kind, subject.Kind) {
+					return diags
+				}
+			}
+		}
+
+		log.Printf("[WARN] Role %s is no longer granted to %s, removing role binding from state", role, subject.Name)
+		d.SetId("")
+
+		return diags
+	}
+}
+
+func resourceTmcIAMMemberCreate(s iamScope) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*tanzuclient.Client)
+
+		// Warning or errors can be collected in a slice type
+		var diags diag.Diagnostics
+
+		role := d.Get("role").(string)
+		subject := iamMemberSubject(d)
+
+		_, err := client.UpdateIAMPolicy(ctx, s.scope(s.values(d)), []tanzuclient.BindingDelta{
+			{Op: tanzuclient.BindingDeltaAdd, Role: role, Subject: subject},
+		})
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to add role binding",
+				Detail:   fmt.Sprintf("Cannot grant the role %s on the %s %s to %s: %s", role, s.object, buildID(s.idParts(d)...), subject.Name, err),
+			})
+			return diags
+		}
+
+		d.SetId(buildID(append(s.idParts(d), role, iamMemberID(subject))...))
+
+		return resourceTmcIAMMemberRead(s)(ctx, d, meta)
+	}
+}
+
+func resourceTmcIAMMemberDelete(s iamScope) schema.DeleteContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*tanzuclient.Client)
+
+		// Warning or errors can be collected in a slice type
+		var diags diag.Diagnostics
+
+		role := d.Get("role").(string)
+		subject := iamMemberSubject(d)
+
+		_, err := client.UpdateIAMPolicy(ctx, s.scope(s.values(d)), []tanzuclient.BindingDelta{
+			{Op: tanzuclient.BindingDeltaDelete, Role: role, Subject: subject},
+		})
+		if err != nil && !tanzuclient.IsNotFound(err) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to remove role binding",
+				Detail:   fmt.Sprintf("Cannot revoke the role %s on the %s %s from %s: %s", role, s.object, d.Id(), subject.Name, err),
+			})
+			return diags
+		}
+
+		d.SetId("")
+
+		return diags
+	}
+}
+
+func resourceTmcIAMMemberImport(s iamScope) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		parts, err := s.importID(d, "role", "member")
+		if err != nil {
+			return nil, err
+		}
+		role, member := parts[0], parts[1]
+
+		kind, name := "", ""
+		if i := strings.Index(member, ":"); i > 0 {
+			kind, name = member[:i], member[i+1:]
+		}
+		if (kind != "user" && kind != "group") || name == "" {
+			return nil, fmt.Errorf("unexpected member %q in ID %q, expected user:<name> or group:<name>", member, d.Id())
+		}
+
+		d.Set("role", role)
+		d.Set(kind, name)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// iamMemberSubject returns the user or group the role is granted to.
+func iamMemberSubject(d *schema.ResourceData) tanzuclient.Subject {
+	if group := d.Get("group").(string); group != "" {
+		return tanzuclient.Subject{Name: group, Kind: tanzuclient.SubjectKindGroup}
+	}
+
+	return tanzuclient.Subject{Name: d.Get("user").(string), Kind: tanzuclient.SubjectKindUser}
+}
+
+// iamMemberID returns the member part of the ID of the resource, like
+// user:jane@example.com.
+func iamMemberID(subject tanzuclient.Subject) string {
+	return strings.ToLower(subject.Kind) + ":" + subject.Name
+}
@@ -0,0 +1,162 @@
+package tmc
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcClusterGroupIAMMember(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	// Members leave the bindings set outside of Terraform alone
+	server.AddRoleBinding("clusterGroup:tf-acc-group", "clustergroup.view", "user:outsider@example.com")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: testAccCheckTmcRoleBindings(server, "clusterGroup:tf-acc-group", map[string][]string{
+			"clustergroup.view": {"user:outsider@example.com"},
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterGroupIAMMemberConfig(server, "clustergroup.edit"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_group_iam_member.user", "id", "tf-acc-group/clustergroup.edit/user:jane@example.com"),
+					resource.TestCheckResourceAttr("tmc_cluster_group_iam_member.group", "id", "tf-acc-group/clustergroup.view/group:developers"),
+					testAccCheckTmcRoleBindings(server, "clusterGroup:tf-acc-group", map[string][]string{
+						"clustergroup.edit": {"user:jane@example.com"},
+						"clustergroup.view": {"group:developers", "user:outsider@example.com"},
+					}),
+				),
+			},
+			{
+				// Changing the role replaces the binding
+				Config: testAccResourceTmcClusterGroupIAMMemberConfig(server, "clustergroup.admin"),
+				Check: testAccCheckTmcRoleBindings(server, "clusterGroup:tf-acc-group", map[string][]string{
+					"clustergroup.admin": {"user:jane@example.com"},
+					"clustergroup.view":  {"group:developers", "user:outsider@example.com"},
+				}),
+			},
+			{
+				ResourceName:      "tmc_cluster_group_iam_member.user",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "tmc_cluster_group_iam_member.group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterIAMMember(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterConfig(server, "first description", "default") + `
+resource "tmc_cluster_iam_member" "test" {
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+  role               = "cluster.admin"
+  group              = "platform"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_iam_member.test", "id", "aws-hosted/tf-acc/tf-acc-cluster/cluster.admin/group:platform"),
+					testAccCheckTmcRoleBindings(server, "cluster:aws-hosted/tf-acc/tf-acc-cluster", map[string][]string{
+						"cluster.admin": {"group:platform"},
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterGroupIAMMemberSlash(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_cluster_group" "test" {
+  name = "tf-acc-group"
+}
+
+resource "tmc_cluster_group_iam_member" "test" {
+  cluster_group_name = tmc_cluster_group.test.name
+  role               = "clustergroup.view"
+  group              = "platform/sre"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_cluster_group_iam_member.test", "id", "tf-acc-group/clustergroup.view/group:platform/sre"),
+					testAccCheckTmcRoleBindings(server, "clusterGroup:tf-acc-group", map[string][]string{
+						"clustergroup.view": {"group:platform/sre"},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_cluster_group_iam_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcOrganizationIAMMemberImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_organization_iam_member" "test" {
+  role = "organization.view"
+  user = "jane@example.com"
+}
+`,
+				ResourceName:  "tmc_organization_iam_member.test",
+				ImportState:   true,
+				ImportStateId: "organization/organization.view/robot:jane@example.com",
+				ExpectError:   regexp.MustCompile(`expected user:<name> or group:<name>`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcClusterGroupIAMMemberConfig(server *tmcfake.Server, role string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster_group" "test" {
+  name = "tf-acc-group"
+}
+
+resource "tmc_cluster_group_iam_member" "user" {
+  cluster_group_name = tmc_cluster_group.test.name
+  role               = %q
+  user               = "jane@example.com"
+}
+
+resource "tmc_cluster_group_iam_member" "group" {
+  cluster_group_name = tmc_cluster_group.test.name
+  role               = "clustergroup.view"
+  group              = "developers"
+}
+`, role)
+}
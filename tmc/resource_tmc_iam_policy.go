@@ -0,0 +1,149 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// resourceTmcIAMPolicy returns the resource managing the whole access policy
+// of an object of the scope. It removes the role bindings it does not know
+// about, so it cannot be used along with the IAM member resource of the same
+// object.
+func resourceTmcIAMPolicy(s iamScope) *schema.Resource {
+	attributes := s.schema()
+	attributes["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: fmt.Sprintf("ID of the access policy in the %s format", s.idFormat()),
+	}
+	attributes["role_binding"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: fmt.Sprintf("Role bindings of the %s. Bindings set outside of Terraform are removed", s.object),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					Description:  "Name of the TMC role, like workspace.edit",
+				},
+				"users": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Users granted the role",
+				},
+				"groups": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Groups granted the role",
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext:   resourceTmcIAMPolicyRead(s),
+		CreateContext: resourceTmcIAMPolicySet(s),
+		UpdateContext: resourceTmcIAMPolicySet(s),
+		DeleteContext: resourceTmcIAMPolicyDelete(s),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if _, err := s.importID(d); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: attributes,
+	}
+}
+
+func resourceTmcIAMPolicyRead(s iamScope) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*tanzuclient.Client)
+
+		var diags diag.Diagnostics
+
+		policy, err := client.GetIAMPolicy(ctx, s.scope(s.values(d)))
+		if err != nil {
+			if tanzuclient.IsNotFound(err) {
+				log.Printf("[WARN] %s %s not found, removing its access policy from state", s.object, d.Id())
+				d.SetId("")
+				return diags
+			}
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("role_binding", flattenRoleBindings(policy.RoleBindings)); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read access policy",
+				Detail:   fmt.Sprintf("Error setting role bindings for resource %s: %s", d.Id(), err),
+			})
+			return diags
+		}
+
+		return diags
+	}
+}
+
+// resourceTmcIAMPolicySet both creates and updates the access policy, which
+// is always replaced as a whole.
+func resourceTmcIAMPolicySet(s iamScope) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*tanzuclient.Client)
+
+		// Warning or errors can be collected in a slice type
+		var diags diag.Diagnostics
+
+		roleBindings := expandRoleBindings(d.Get("role_binding").(*schema.Set).List())
+
+		_, err := client.SetIAMPolicy(ctx, s.scope(s.values(d)), roleBindings)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to set access policy",
+				Detail:   fmt.Sprintf("Cannot set the access policy of the %s %s: %s", s.object, buildID(s.idParts(d)...), err),
+			})
+			return diags
+		}
+
+		d.SetId(buildID(s.idParts(d)...))
+
+		return resourceTmcIAMPolicyRead(s)(ctx, d, meta)
+	}
+}
+
+func resourceTmcIAMPolicyDelete(s iamScope) schema.DeleteContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		client := meta.(*tanzuclient.Client)
+
+		// Warning or errors can be collected in a slice type
+		var diags diag.Diagnostics
+
+		// Removing the policy leaves the object with the bindings it inherits
+		_, err := client.SetIAMPolicy(ctx, s.scope(s.values(d)), nil)
+		if err != nil && !tanzuclient.IsNotFound(err) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to delete access policy",
+				Detail:   fmt.Sprintf("Cannot remove the access policy of the %s %s: %s", s.object, d.Id(), err),
+			})
+			return diags
+		}
+
+		d.SetId("")
+
+		return diags
+	}
+}
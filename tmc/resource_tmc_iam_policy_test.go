@@ -0,0 +1,194 @@
+package tmc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcWorkspaceIAMPolicy(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	// The policy replaces the bindings set outside of Terraform
+	server.AddRoleBinding("workspace:tf-acc-workspace", "workspace.view", "user:outsider@example.com")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcRoleBindings(server, "workspace:tf-acc-workspace", map[string][]string{}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcWorkspaceIAMPolicyConfig(server, `
+  role_binding {
+    role   = "workspace.edit"
+    users  = ["jane@example.com", "john@example.com"]
+    groups = ["developers"]
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_workspace_iam_policy.test", "id", "tf-acc-workspace"),
+					resource.TestCheckResourceAttr("tmc_workspace_iam_policy.test", "role_binding.#", "1"),
+					testAccCheckTmcRoleBindings(server, "workspace:tf-acc-workspace", map[string][]string{
+						"workspace.edit": {"group:developers", "user:jane@example.com", "user:john@example.com"},
+					}),
+				),
+			},
+			{
+				Config: testAccResourceTmcWorkspaceIAMPolicyConfig(server, `
+  role_binding {
+    role  = "workspace.admin"
+    users = ["jane@example.com"]
+  }
+
+  role_binding {
+    role   = "workspace.view"
+    groups = ["auditors"]
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_workspace_iam_policy.test", "role_binding.#", "2"),
+					testAccCheckTmcRoleBindings(server, "workspace:tf-acc-workspace", map[string][]string{
+						"workspace.admin": {"user:jane@example.com"},
+						"workspace.view":  {"group:auditors"},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_workspace_iam_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcOrganizationIAMPolicy(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcRoleBindings(server, "organization", map[string][]string{}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_organization_iam_policy" "test" {
+  role_binding {
+    role   = "organization.admin"
+    groups = ["platform"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_organization_iam_policy.test", "id", "organization"),
+					testAccCheckTmcRoleBindings(server, "organization", map[string][]string{
+						"organization.admin": {"group:platform"},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_organization_iam_policy.test",
+				ImportState:       true,
+				ImportStateId:     "organization",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcNamespaceIAMPolicy(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	const object = "namespace:aws-hosted/tf-acc/tf-acc-cluster/tf-acc-namespace"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcNamespaceConfig(server, "tmc_workspace.first", false) + `
+resource "tmc_namespace_iam_policy" "test" {
+  management_cluster = tmc_namespace.test.management_cluster
+  provisioner_name   = tmc_namespace.test.provisioner_name
+  cluster_name       = tmc_namespace.test.cluster_name
+  namespace_name     = tmc_namespace.test.name
+
+  role_binding {
+    role  = "namespace.edit"
+    users = ["jane@example.com"]
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_namespace_iam_policy.test", "id", "aws-hosted/tf-acc/tf-acc-cluster/tf-acc-namespace"),
+					testAccCheckTmcRoleBindings(server, object, map[string][]string{
+						"namespace.edit": {"user:jane@example.com"},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_namespace_iam_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcClusterIAMPolicyImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcClusterConfig(server, "first description", "default") + `
+resource "tmc_cluster_iam_policy" "test" {
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+}
+`,
+				ResourceName:  "tmc_cluster_iam_policy.test",
+				ImportState:   true,
+				ImportStateId: "tf-acc-cluster",
+				ExpectError:   regexp.MustCompile(`expected management_cluster/provisioner_name/cluster_name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcWorkspaceIAMPolicyConfig(server *tmcfake.Server, roleBindings string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_workspace" "test" {
+  name = "tf-acc-workspace"
+}
+
+resource "tmc_workspace_iam_policy" "test" {
+  workspace_name = tmc_workspace.test.name
+%s}
+`, roleBindings)
+}
+
+// testAccCheckTmcRoleBindings checks the members of the roles bound on an
+// object, named as tmcfake.Server.RoleBindings takes it.
+func testAccCheckTmcRoleBindings(server *tmcfake.Server, object string, expected map[string][]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		members := server.RoleBindings(object)
+		for _, names := range members {
+			sort.Strings(names)
+		}
+
+		if !reflect.DeepEqual(members, expected) {
+			return fmt.Errorf("expected the role bindings of %s to be %v, got %v", object, expected, members)
+		}
+		return nil
+	}
+}
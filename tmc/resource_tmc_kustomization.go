@@ -0,0 +1,190 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func resourceTmcKustomization() *schema.Resource {
+	attributes := fluxScopeSchema()
+	attributes["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the Kustomization, made of the kind of object it is applied to, the names of the object, the namespace and the name of the Kustomization, like cluster_group/my-group/tanzu-continuousdelivery-resources/my-kustomization",
+	}
+	attributes["uid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Unique ID of the Kustomization",
+	}
+	attributes["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Name of the Kustomization",
+	}
+	attributes["namespace_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     fluxNamespace,
+		Description: "Namespace the Kustomization is applied to, which is also the namespace of its Git repository",
+	}
+	attributes["git_repository_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the Git repository the manifests are applied from",
+	}
+	attributes["path"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Path of the directory of the Git repository holding the manifests, like /apps/production",
+	}
+	attributes["prune"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the objects removed from the Git repository are deleted from the clusters",
+	}
+	attributes["interval"] = fluxIntervalSchema("How often the manifests are applied again, like 5m")
+	attributes["target_namespace"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Namespace the objects are created in, overriding the one of their manifest",
+	}
+	attributes["phase"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Phase of the Kustomization, APPLIED once Flux applies the manifests",
+	}
+
+	return &schema.Resource{
+		ReadContext:   resourceTmcKustomizationRead,
+		CreateContext: resourceTmcKustomizationCreate,
+		UpdateContext: resourceTmcKustomizationUpdate,
+		DeleteContext: resourceTmcKustomizationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := importFluxID(d, "namespace_name", "name"); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: attributes,
+	}
+}
+
+func resourceTmcKustomizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	kustomization, err := client.GetKustomization(ctx, fluxScope(d), d.Get("namespace_name").(string), d.Get("name").(string))
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Kustomization %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("uid", kustomization.Meta.UID)
+
+	if kustomization.Status != nil {
+		d.Set("phase", kustomization.Status.Phase)
+	}
+
+	if spec := kustomization.Spec; spec != nil {
+		d.Set("path", spec.Path)
+		d.Set("prune", spec.Prune)
+		d.Set("interval", spec.Interval)
+		d.Set("target_namespace", spec.TargetNamespace)
+		if spec.Source != nil {
+			d.Set("git_repository_name", spec.Source.Name)
+		}
+	}
+
+	return diags
+}
+
+func resourceTmcKustomizationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	_, err := client.CreateKustomization(ctx, fluxScope(d), d.Get("namespace_name").(string), d.Get("name").(string), expandKustomizationSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create Kustomization",
+			Detail:   fmt.Sprintf("Cannot create the Kustomization %s: %s", fluxID(d, "namespace_name", "name"), err),
+		})
+		return diags
+	}
+
+	d.SetId(fluxID(d, "namespace_name", "name"))
+
+	return resourceTmcKustomizationRead(ctx, d, meta)
+}
+
+func resourceTmcKustomizationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	_, err := client.UpdateKustomization(ctx, fluxScope(d), d.Get("namespace_name").(string), d.Get("name").(string), expandKustomizationSpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update Kustomization",
+			Detail:   fmt.Sprintf("Cannot update the Kustomization %s with the new values: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	return resourceTmcKustomizationRead(ctx, d, meta)
+}
+
+func resourceTmcKustomizationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	err := client.DeleteKustomization(ctx, fluxScope(d), d.Get("namespace_name").(string), d.Get("name").(string))
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete Kustomization",
+			Detail:   fmt.Sprintf("Cannot delete the Kustomization %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func expandKustomizationSpec(d *schema.ResourceData) *tanzuclient.KustomizationSpec {
+	return &tanzuclient.KustomizationSpec{
+		Path:            d.Get("path").(string),
+		Prune:           d.Get("prune").(bool),
+		Interval:        d.Get("interval").(string),
+		TargetNamespace: d.Get("target_namespace").(string),
+		Source: &tanzuclient.KustomizationSource{
+			Name:      d.Get("git_repository_name").(string),
+			Namespace: d.Get("namespace_name").(string),
+		},
+	}
+}
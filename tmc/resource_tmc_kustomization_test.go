@@ -0,0 +1,145 @@
+package tmc
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcKustomizationClusterGroup(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	get := func() (tmcfake.Object, bool) {
+		return server.ClusterGroupKustomization("tf-acc-group", "tanzu-continuousdelivery-resources", "tf-acc-apps")
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcFluxObjectDestroy("Kustomization tf-acc-apps", get),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcKustomizationClusterGroupConfig(server, `
+  path = "/apps/staging"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_kustomization.test", "id", "cluster_group/tf-acc-group/tanzu-continuousdelivery-resources/tf-acc-apps"),
+					resource.TestCheckResourceAttrSet("tmc_kustomization.test", "uid"),
+					resource.TestCheckResourceAttr("tmc_kustomization.test", "prune", "false"),
+					resource.TestCheckResourceAttr("tmc_kustomization.test", "interval", "5m"),
+					resource.TestCheckResourceAttr("tmc_kustomization.test", "phase", "APPLIED"),
+					testAccCheckTmcFluxSpec("Kustomization tf-acc-apps", get, tmcfake.Object{
+						"path":     "/apps/staging",
+						"prune":    false,
+						"interval": "5m",
+						"source": tmcfake.Object{
+							"name":      "tf-acc-repository",
+							"namespace": "tanzu-continuousdelivery-resources",
+						},
+					}),
+				),
+			},
+			{
+				Config: testAccResourceTmcKustomizationClusterGroupConfig(server, `
+  path             = "/apps/production"
+  prune            = true
+  interval         = "30m"
+  target_namespace = "tf-acc-apps"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_kustomization.test", "prune", "true"),
+					testAccCheckTmcFluxSpec("Kustomization tf-acc-apps", get, tmcfake.Object{
+						"path":            "/apps/production",
+						"prune":           true,
+						"interval":        "30m",
+						"targetNamespace": "tf-acc-apps",
+						"source": tmcfake.Object{
+							"name":      "tf-acc-repository",
+							"namespace": "tanzu-continuousdelivery-resources",
+						},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_kustomization.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcKustomizationCluster(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	get := func() (tmcfake.Object, bool) {
+		return server.Kustomization("aws-hosted", "tf-acc", "tf-acc-cluster", "tanzu-continuousdelivery-resources", "tf-acc-apps")
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcFluxObjectDestroy("Kustomization tf-acc-apps", get),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcGitRepositoryClusterConfig(server, `
+  ref {
+    branch = "main"
+  }
+`) + `
+resource "tmc_kustomization" "test" {
+  name                = "tf-acc-apps"
+  management_cluster  = tmc_git_repository.test.management_cluster
+  provisioner_name    = tmc_git_repository.test.provisioner_name
+  cluster_name        = tmc_git_repository.test.cluster_name
+  git_repository_name = tmc_git_repository.test.name
+  path                = "/"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_kustomization.test", "id", "cluster/aws-hosted/tf-acc/tf-acc-cluster/tanzu-continuousdelivery-resources/tf-acc-apps"),
+					resource.TestCheckResourceAttr("tmc_kustomization.test", "git_repository_name", "tf-acc-repository"),
+					resource.TestCheckResourceAttr("tmc_kustomization.test", "phase", "APPLIED"),
+				),
+			},
+			{
+				ResourceName:      "tmc_kustomization.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcKustomizationImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcKustomizationClusterGroupConfig(server, `
+  path = "/apps/staging"
+`),
+				ResourceName:  "tmc_kustomization.test",
+				ImportState:   true,
+				ImportStateId: "cluster_group/tf-acc-group/tf-acc-apps",
+				ExpectError:   regexp.MustCompile(`expected cluster_group/cluster_group_name/namespace_name/name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcKustomizationClusterGroupConfig(server *tmcfake.Server, settings string) string {
+	return testAccResourceTmcGitRepositoryClusterGroupConfig(server) + fmt.Sprintf(`
+resource "tmc_kustomization" "test" {
+  name                = "tf-acc-apps"
+  cluster_group_name  = tmc_git_repository.test.cluster_group_name
+  git_repository_name = tmc_git_repository.test.name
+%s}
+`, settings)
+}
@@ -0,0 +1,222 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// namespaceIDFormat is the format of the IDs of namespaces, which are also
+// used to import them.
+const namespaceIDFormat = "management_cluster/provisioner_name/cluster_name/name"
+
+func resourceTmcNamespace() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcNamespaceRead,
+		CreateContext: resourceTmcNamespaceCreate,
+		UpdateContext: resourceTmcNamespaceUpdate,
+		DeleteContext: resourceTmcNamespaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTmcNamespaceImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Namespace in the management_cluster/provisioner_name/cluster_name/name format",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the Namespace",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Namespace",
+			},
+			"management_cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the management cluster of the Cluster",
+			},
+			"provisioner_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the provisioner of the Cluster",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the Cluster the Namespace belongs to",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the Namespace",
+			},
+			"workspace_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the Tanzu Workspace the Namespace belongs to",
+			},
+			"labels": labelsSchema(),
+			"attach": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to adopt the namespace when it already exists in the Cluster without being managed by TMC, rather than failing",
+			},
+		},
+	}
+}
+
+func resourceTmcNamespaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	parts, err := parseID(d.Id(), namespaceIDFormat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managementClusterName, provisionerName, clusterName, namespaceName := parts[0], parts[1], parts[2], parts[3]
+
+	namespace, err := client.GetNamespace(ctx, namespaceName, clusterName, managementClusterName, provisionerName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Namespace %s of cluster %s not found, removing from state", namespaceName, clusterName)
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", namespace.FullName.Name)
+	d.Set("management_cluster", namespace.FullName.ManagementClusterName)
+	d.Set("provisioner_name", namespace.FullName.ProvisionerName)
+	d.Set("cluster_name", namespace.FullName.ClusterName)
+	d.Set("uid", namespace.Meta.UID)
+	d.Set("description", namespace.Meta.Description)
+
+	if namespace.Spec != nil {
+		d.Set("workspace_name", namespace.Spec.WorkspaceName)
+	}
+
+	if err := d.Set("labels", namespace.Meta.Labels); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read namespace",
+			Detail:   fmt.Sprintf("Error getting labels for resource %s: %s", d.Get("name"), err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+func resourceTmcNamespaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	namespaceName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+	description := d.Get("description").(string)
+	labels := d.Get("labels").(map[string]interface{})
+
+	spec := &tanzuclient.NamespaceSpec{
+		WorkspaceName: d.Get("workspace_name").(string),
+		Attach:        d.Get("attach").(bool),
+	}
+
+	_, err := client.CreateNamespace(ctx, namespaceName, clusterName, managementCluster, provisionerName, description, spec, labels)
+	if err != nil {
+		if tanzuclient.IsConflict(err) && !spec.Attach {
+			return diag.Errorf("Namespace %s already exists in cluster %s, set attach to adopt it: %s", namespaceName, clusterName, err)
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildID(managementCluster, provisionerName, clusterName, namespaceName))
+
+	return resourceTmcNamespaceRead(ctx, d, meta)
+}
+
+func resourceTmcNamespaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	namespaceName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+	managementCluster := d.Get("management_cluster").(string)
+	provisionerName := d.Get("provisioner_name").(string)
+
+	// attach only matters when the namespace is created.
+	if d.HasChanges("description", "labels", "workspace_name") {
+		description := d.Get("description").(string)
+		labels := d.Get("labels").(map[string]interface{})
+
+		spec := &tanzuclient.NamespaceSpec{
+			WorkspaceName: d.Get("workspace_name").(string),
+		}
+
+		_, err := client.UpdateNamespace(ctx, namespaceName, clusterName, managementCluster, provisionerName, description, spec, labels)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to update namespace",
+				Detail:   fmt.Sprintf("Cannot update the namespace %s of cluster %s with the new values: %s", namespaceName, clusterName, err),
+			})
+			return diags
+		}
+	}
+
+	return resourceTmcNamespaceRead(ctx, d, meta)
+}
+
+func resourceTmcNamespaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	namespaceName := d.Get("name").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	err := client.DeleteNamespace(ctx, namespaceName, clusterName, d.Get("management_cluster").(string), d.Get("provisioner_name").(string))
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete namespace",
+			Detail:   fmt.Sprintf("Cannot delete the namespace %s of cluster %s: %s", namespaceName, clusterName, err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTmcNamespaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseID(d.Id(), namespaceIDFormat); err != nil {
+		return nil, err
+	}
+
+	// Imported namespaces are already managed, so there is nothing to adopt.
+	d.Set("attach", false)
+
+	return []*schema.ResourceData{d}, nil
+}
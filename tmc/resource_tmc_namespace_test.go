@@ -0,0 +1,157 @@
+package tmc
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcNamespace(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	var uid string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcNamespaceDestroy(server, "tf-acc-namespace"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcNamespaceConfig(server, "tmc_workspace.first", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcNamespaceExists(server, "tf-acc-namespace"),
+					resource.TestCheckResourceAttr("tmc_namespace.test", "id", "aws-hosted/tf-acc/tf-acc-cluster/tf-acc-namespace"),
+					resource.TestCheckResourceAttr("tmc_namespace.test", "description", "Namespace of the team"),
+					resource.TestCheckResourceAttr("tmc_namespace.test", "workspace_name", "tf-acc-first"),
+					resource.TestCheckResourceAttr("tmc_namespace.test", "labels.team", "test"),
+					testAccCheckTmcNamespaceUID("tmc_namespace.test", &uid),
+				),
+			},
+			{
+				Config: testAccResourceTmcNamespaceConfig(server, "tmc_workspace.second", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_namespace.test", "workspace_name", "tf-acc-second"),
+					// Moving the namespace to another workspace does not
+					// recreate it
+					testAccCheckTmcNamespaceUID("tmc_namespace.test", &uid),
+				),
+			},
+			{
+				ResourceName:      "tmc_namespace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcNamespaceAttach(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	server.AddUnmanagedNamespace("aws-hosted", "tf-acc", "tf-acc-cluster", "tf-acc-namespace")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcNamespaceDestroy(server, "tf-acc-namespace"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceTmcNamespaceConfig(server, "tmc_workspace.first", false),
+				ExpectError: regexp.MustCompile(`Namespace tf-acc-namespace already exists in cluster tf-acc-cluster, set attach to adopt it`),
+			},
+			{
+				Config: testAccResourceTmcNamespaceConfig(server, "tmc_workspace.first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcNamespaceExists(server, "tf-acc-namespace"),
+					resource.TestCheckResourceAttr("tmc_namespace.test", "attach", "true"),
+					resource.TestCheckResourceAttr("tmc_namespace.test", "workspace_name", "tf-acc-first"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcNamespaceImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceTmcNamespaceConfig(server, "tmc_workspace.first", false),
+				ResourceName:  "tmc_namespace.test",
+				ImportState:   true,
+				ImportStateId: "tf-acc-cluster/tf-acc-namespace",
+				ExpectError:   regexp.MustCompile(`expected management_cluster/provisioner_name/cluster_name/name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcNamespaceConfig(server *tmcfake.Server, workspace string, attach bool) string {
+	return testAccResourceTmcClusterConfig(server, "first description", "default") + fmt.Sprintf(`
+resource "tmc_workspace" "first" {
+  name = "tf-acc-first"
+}
+
+resource "tmc_workspace" "second" {
+  name = "tf-acc-second"
+}
+
+resource "tmc_namespace" "test" {
+  name               = "tf-acc-namespace"
+  description        = "Namespace of the team"
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+  workspace_name     = %s.name
+  attach             = %t
+
+  labels = {
+    team = "test"
+  }
+}
+`, workspace, attach)
+}
+
+// testAccCheckTmcNamespaceUID records the uid of the namespace the first time
+// it is called, and checks that it did not change afterwards.
+func testAccCheckTmcNamespaceUID(resourceName string, uid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found", resourceName)
+		}
+
+		value := rs.Primary.Attributes["uid"]
+		if *uid == "" {
+			*uid = value
+		} else if value != *uid {
+			return fmt.Errorf("expected the namespace to be updated in place, its uid changed from %s to %s", *uid, value)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTmcNamespaceExists(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Namespace("aws-hosted", "tf-acc", "tf-acc-cluster", name); !ok {
+			return fmt.Errorf("namespace %s was not created", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTmcNamespaceDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Namespace("aws-hosted", "tf-acc", "tf-acc-cluster", name); ok {
+			return fmt.Errorf("namespace %s still exists", name)
+		}
+		return nil
+	}
+}
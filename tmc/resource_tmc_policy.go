@@ -0,0 +1,912 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+// policyRecipe maps a block of a policy type to a recipe of TMC.
+type policyRecipe struct {
+	block  string
+	recipe string
+	schema map[string]*schema.Schema
+	// expand and flatten convert the block to the input of the recipe and
+	// back. They are not set for recipes without input.
+	expand  func(block map[string]interface{}) map[string]interface{}
+	flatten func(input map[string]interface{}) map[string]interface{}
+}
+
+// policyType maps a block of the policy resource to a type of policy of TMC.
+type policyType struct {
+	block       string
+	policyType  string
+	description string
+	recipes     []policyRecipe
+}
+
+var policyTypes = []policyType{
+	{
+		block:       "security",
+		policyType:  tanzuclient.PolicyTypeSecurity,
+		description: "Security policy, restricting what the pods are allowed to do",
+		recipes: []policyRecipe{
+			{
+				block:   "baseline",
+				recipe:  "baseline",
+				schema:  securityPolicyRecipeSchema(false),
+				expand:  fieldsExpander(securityPolicyFields),
+				flatten: fieldsFlattener(securityPolicyFields),
+			},
+			{
+				block:   "strict",
+				recipe:  "strict",
+				schema:  securityPolicyRecipeSchema(false),
+				expand:  fieldsExpander(securityPolicyFields),
+				flatten: fieldsFlattener(securityPolicyFields),
+			},
+			{
+				block:   "custom",
+				recipe:  "custom",
+				schema:  securityPolicyRecipeSchema(true),
+				expand:  fieldsExpander(customSecurityPolicyFields),
+				flatten: fieldsFlattener(customSecurityPolicyFields),
+			},
+		},
+	},
+	{
+		block:       "image_registry",
+		policyType:  tanzuclient.PolicyTypeImage,
+		description: "Image registry policy, restricting the images the pods are allowed to run",
+		recipes: []policyRecipe{
+			{
+				block:  "allowed_name_tag",
+				recipe: "allowed-name-tag",
+				schema: map[string]*schema.Schema{
+					"audit": policyAuditSchema(),
+					"rule":  imagePolicyRuleSchema(false),
+				},
+				expand:  expandImagePolicyInput,
+				flatten: flattenImagePolicyInput,
+			},
+			{
+				block:  "custom",
+				recipe: "custom",
+				schema: map[string]*schema.Schema{
+					"audit": policyAuditSchema(),
+					"rule":  imagePolicyRuleSchema(true),
+				},
+				expand:  expandImagePolicyInput,
+				flatten: flattenImagePolicyInput,
+			},
+			{
+				block:  "block_latest_tag",
+				recipe: "block-latest-tag",
+				schema: map[string]*schema.Schema{
+					"audit": policyAuditSchema(),
+				},
+				expand:  fieldsExpander(auditPolicyFields),
+				flatten: fieldsFlattener(auditPolicyFields),
+			},
+			{
+				block:  "require_digest",
+				recipe: "require-digest",
+				schema: map[string]*schema.Schema{
+					"audit": policyAuditSchema(),
+				},
+				expand:  fieldsExpander(auditPolicyFields),
+				flatten: fieldsFlattener(auditPolicyFields),
+			},
+		},
+	},
+	{
+		block:       "network",
+		policyType:  tanzuclient.PolicyTypeNetwork,
+		description: "Network policy, restricting the traffic of the pods",
+		recipes: []policyRecipe{
+			{
+				block:  "allow_all",
+				recipe: "allow-all",
+				schema: map[string]*schema.Schema{
+					"from_own_namespace": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Whether to only allow the traffic from the namespace of the pods",
+					},
+				},
+				expand:  fieldsExpander(map[string]string{"from_own_namespace": "fromOwnNamespace"}),
+				flatten: fieldsFlattener(map[string]string{"from_own_namespace": "fromOwnNamespace"}),
+			},
+			{
+				block:  "deny_all",
+				recipe: "deny-all",
+				schema: map[string]*schema.Schema{},
+			},
+			{
+				block:   "custom_ingress",
+				recipe:  "custom-ingress",
+				schema:  networkPolicyCustomSchema("from"),
+				expand:  expandNetworkPolicyInput,
+				flatten: flattenNetworkPolicyInput,
+			},
+			{
+				block:   "custom_egress",
+				recipe:  "custom-egress",
+				schema:  networkPolicyCustomSchema("to"),
+				expand:  expandNetworkPolicyInput,
+				flatten: flattenNetworkPolicyInput,
+			},
+		},
+	},
+	{
+		block:       "quota",
+		policyType:  tanzuclient.PolicyTypeQuota,
+		description: "Resource quota policy, limiting the resources used by each namespace",
+		recipes: []policyRecipe{
+			{block: "small", recipe: "small", schema: map[string]*schema.Schema{}},
+			{block: "medium", recipe: "medium", schema: map[string]*schema.Schema{}},
+			{block: "large", recipe: "large", schema: map[string]*schema.Schema{}},
+			{
+				block:   "custom",
+				recipe:  "custom",
+				schema:  quotaPolicyCustomSchema(),
+				expand:  fieldsExpander(quotaPolicyFields),
+				flatten: fieldsFlattener(quotaPolicyFields),
+			},
+		},
+	},
+}
+
+func resourceTmcPolicy() *schema.Resource {
+	attributes := policyScopeSchema()
+	attributes["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the policy, made of the kind of object it is attached to, the names of the object and the name of the policy, like cluster_group/my-group/my-policy",
+	}
+	attributes["uid"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Unique ID of the policy",
+	}
+	attributes["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Name of the policy",
+	}
+	attributes["description"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "",
+		Description: "Description of the policy",
+	}
+	attributes["namespace_selector"] = namespaceSelectorSchema()
+
+	types := make([]string, 0, len(policyTypes))
+	for _, t := range policyTypes {
+		types = append(types, t.block)
+	}
+
+	for _, t := range policyTypes {
+		recipes := make([]string, 0, len(t.recipes))
+		for _, r := range t.recipes {
+			recipes = append(recipes, fmt.Sprintf("%s.0.%s", t.block, r.block))
+		}
+
+		blocks := map[string]*schema.Schema{}
+		for _, r := range t.recipes {
+			blocks[r.block] = &schema.Schema{
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: recipes,
+				Description:  fmt.Sprintf("Settings of the %s recipe", r.recipe),
+				Elem: &schema.Resource{
+					Schema: r.schema,
+				},
+			}
+		}
+
+		// The type of a policy cannot change, but its recipe can.
+		attributes[t.block] = &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			ExactlyOneOf: types,
+			Description:  t.description,
+			Elem: &schema.Resource{
+				Schema: blocks,
+			},
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext:   resourceTmcPolicyRead,
+		CreateContext: resourceTmcPolicyCreate,
+		UpdateContext: resourceTmcPolicyUpdate,
+		DeleteContext: resourceTmcPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := importPolicyID(d); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: attributes,
+	}
+}
+
+func resourceTmcPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	policyName := d.Get("name").(string)
+
+	policy, err := client.GetPolicy(ctx, policyScope(d), policyName)
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Policy %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	if policy.Spec == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read policy",
+			Detail:   fmt.Sprintf("TMC returned the policy %s without a spec", d.Id()),
+		})
+		return diags
+	}
+
+	d.Set("uid", policy.Meta.UID)
+	d.Set("description", policy.Meta.Description)
+
+	if err := d.Set("namespace_selector", flattenNamespaceSelector(policy.Spec.NamespaceSelector)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read policy",
+			Detail:   fmt.Sprintf("Error setting namespace selector for resource %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	found := false
+	for _, t := range policyTypes {
+		var block []interface{}
+		if t.policyType == policy.Spec.Type {
+			block = flattenPolicySpec(t, policy.Spec)
+			found = block != nil
+		}
+
+		if err := d.Set(t.block, block); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to read policy",
+				Detail:   fmt.Sprintf("Error setting %s for resource %s: %s", t.block, d.Id(), err),
+			})
+			return diags
+		}
+	}
+
+	if !found {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unsupported policy recipe",
+			Detail:   fmt.Sprintf("The policy %s uses the %s recipe of the %s type, which is not supported by the provider", d.Id(), policy.Spec.Recipe, policy.Spec.Type),
+		})
+	}
+
+	return diags
+}
+
+func resourceTmcPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	policyName := d.Get("name").(string)
+
+	_, err := client.CreatePolicy(ctx, policyScope(d), policyName, d.Get("description").(string), expandPolicySpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create policy",
+			Detail:   fmt.Sprintf("Cannot create the policy %s: %s", policyID(d), err),
+		})
+		return diags
+	}
+
+	d.SetId(policyID(d))
+
+	return resourceTmcPolicyRead(ctx, d, meta)
+}
+
+func resourceTmcPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	policyName := d.Get("name").(string)
+
+	_, err := client.UpdatePolicy(ctx, policyScope(d), policyName, d.Get("description").(string), expandPolicySpec(d))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update policy",
+			Detail:   fmt.Sprintf("Cannot update the policy %s with the new values: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	return resourceTmcPolicyRead(ctx, d, meta)
+}
+
+func resourceTmcPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	err := client.DeletePolicy(ctx, policyScope(d), d.Get("name").(string))
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete policy",
+			Detail:   fmt.Sprintf("Cannot delete the policy %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func expandPolicySpec(d *schema.ResourceData) *tanzuclient.PolicySpec {
+	spec := &tanzuclient.PolicySpec{
+		NamespaceSelector: expandNamespaceSelector(d.Get("namespace_selector").([]interface{})),
+	}
+
+	for _, t := range policyTypes {
+		data := d.Get(t.block).([]interface{})
+		if len(data) == 0 || data[0] == nil {
+			continue
+		}
+		recipes := data[0].(map[string]interface{})
+
+		spec.Type = t.policyType
+		for _, r := range t.recipes {
+			block := recipes[r.block].([]interface{})
+			if len(block) == 0 {
+				continue
+			}
+
+			spec.Recipe = r.recipe
+			spec.RecipeVersion = "v1"
+			// Blocks without arguments are read as nil.
+			if r.expand != nil && block[0] != nil {
+				spec.Input = r.expand(block[0].(map[string]interface{}))
+			}
+		}
+	}
+
+	return spec
+}
+
+// flattenPolicySpec returns the block of the policy type, or nil when the
+// recipe of the policy is not supported.
+func flattenPolicySpec(t policyType, spec *tanzuclient.PolicySpec) []interface{} {
+	for _, r := range t.recipes {
+		if r.recipe != spec.Recipe {
+			continue
+		}
+
+		block := map[string]interface{}{}
+		if r.flatten != nil {
+			block = r.flatten(spec.Input)
+		}
+
+		return []interface{}{
+			map[string]interface{}{
+				r.block: []interface{}{block},
+			},
+		}
+	}
+
+	return nil
+}
+
+// fieldsExpander returns a function copying the arguments of a block to the
+// input of a recipe, renamed as given. Empty strings and zero numbers are left
+// out, so TMC applies no limit for them.
+func fieldsExpander(fields map[string]string) func(map[string]interface{}) map[string]interface{} {
+	return func(block map[string]interface{}) map[string]interface{} {
+		input := map[string]interface{}{}
+		for attribute, field := range fields {
+			if value := block[attribute]; value != "" && value != 0 {
+				input[field] = value
+			}
+		}
+
+		return input
+	}
+}
+
+// fieldsFlattener is the reverse of fieldsExpander.
+func fieldsFlattener(fields map[string]string) func(map[string]interface{}) map[string]interface{} {
+	return func(input map[string]interface{}) map[string]interface{} {
+		block := map[string]interface{}{}
+		for attribute, field := range fields {
+			if value, ok := input[field]; ok {
+				// Numbers are decoded as floats, the attributes are integers.
+				if number, ok := value.(float64); ok {
+					value = int(number)
+				}
+				block[attribute] = value
+			}
+		}
+
+		return block
+	}
+}
+
+func policyAuditSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to only report the violations of the policy rather than denying the requests",
+	}
+}
+
+var auditPolicyFields = map[string]string{
+	"audit": "audit",
+}
+
+var securityPolicyFields = map[string]string{
+	"audit":              "audit",
+	"disable_native_psp": "disableNativePsp",
+}
+
+var customSecurityPolicyFields = map[string]string{
+	"audit":                        "audit",
+	"disable_native_psp":           "disableNativePsp",
+	"allow_privileged_containers":  "allowPrivilegedContainers",
+	"allow_privilege_escalation":   "allowPrivilegeEscalation",
+	"allow_host_network":           "allowHostNetwork",
+	"allow_host_namespace_sharing": "allowHostNamespaceSharing",
+	"read_only_root_file_system":   "readOnlyRootFileSystem",
+	"allowed_volumes":              "allowedVolumes",
+	"allowed_capabilities":         "allowedCapabilities",
+	"required_drop_capabilities":   "requiredDropCapabilities",
+}
+
+func securityPolicyRecipeSchema(custom bool) map[string]*schema.Schema {
+	attributes := map[string]*schema.Schema{
+		"audit": policyAuditSchema(),
+		"disable_native_psp": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to disable the pod security policies of Kubernetes on the clusters",
+		},
+	}
+	if !custom {
+		return attributes
+	}
+
+	for attribute, description := range map[string]string{
+		"allow_privileged_containers":  "Whether to allow privileged containers",
+		"allow_privilege_escalation":   "Whether to allow the processes of the containers to gain more privileges than their parent",
+		"allow_host_network":           "Whether to allow pods to use the network of the nodes",
+		"allow_host_namespace_sharing": "Whether to allow pods to share the PID and IPC namespaces of the nodes",
+		"read_only_root_file_system":   "Whether to require the root file system of the containers to be read-only",
+	} {
+		attributes[attribute] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: description,
+		}
+	}
+
+	for attribute, description := range map[string]string{
+		"allowed_volumes":            "Types of volumes the pods are allowed to mount, like configMap or persistentVolumeClaim",
+		"allowed_capabilities":       "Linux capabilities the containers are allowed to add",
+		"required_drop_capabilities": "Linux capabilities the containers have to drop",
+	} {
+		attributes[attribute] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: description,
+		}
+	}
+
+	return attributes
+}
+
+func imagePolicyRuleSchema(custom bool) *schema.Schema {
+	rule := map[string]*schema.Schema{
+		"image_name": {
+			Type:        schema.TypeString,
+			Required:    !custom,
+			Optional:    custom,
+			Description: "Name of the allowed images, which may contain * wildcards",
+		},
+		"tag": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Tag of the allowed images, which may contain * wildcards",
+		},
+	}
+
+	if custom {
+		rule["hostname"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Hostname of the registry of the allowed images, which may contain * wildcards",
+		}
+		rule["port"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Port of the registry of the allowed images",
+		}
+		rule["require_digest"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether the images have to be referenced by digest",
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Description: "Allowed images, images matching any of the rules are allowed",
+		Elem: &schema.Resource{
+			Schema: rule,
+		},
+	}
+}
+
+func expandImagePolicyInput(block map[string]interface{}) map[string]interface{} {
+	rules := []interface{}{}
+
+	for _, r := range block["rule"].([]interface{}) {
+		rule := r.(map[string]interface{})
+
+		input := map[string]interface{}{}
+		if name := rule["image_name"].(string); name != "" {
+			input["imageName"] = name
+		}
+		if tag := rule["tag"].(string); tag != "" {
+			input["tag"] = map[string]interface{}{"value": tag}
+		}
+		if hostname, _ := rule["hostname"].(string); hostname != "" {
+			input["hostname"] = hostname
+		}
+		if port, _ := rule["port"].(string); port != "" {
+			input["port"] = port
+		}
+		if requireDigest, _ := rule["require_digest"].(bool); requireDigest {
+			input["requireDigest"] = true
+		}
+
+		rules = append(rules, input)
+	}
+
+	return map[string]interface{}{
+		"audit": block["audit"],
+		"rules": rules,
+	}
+}
+
+func flattenImagePolicyInput(input map[string]interface{}) map[string]interface{} {
+	rules := []interface{}{}
+
+	data, _ := input["rules"].([]interface{})
+	for _, r := range data {
+		rule, _ := r.(map[string]interface{})
+
+		tag, _ := rule["tag"].(map[string]interface{})
+		rules = append(rules, map[string]interface{}{
+			"image_name":     rule["imageName"],
+			"tag":            tag["value"],
+			"hostname":       rule["hostname"],
+			"port":           rule["port"],
+			"require_digest": rule["requireDigest"],
+		})
+	}
+
+	return map[string]interface{}{
+		"audit": input["audit"],
+		"rule":  rules,
+	}
+}
+
+// networkPolicyCustomSchema returns the arguments of the custom network
+// policies. direction is "from" for ingress rules and "to" for egress ones.
+func networkPolicyCustomSchema(direction string) map[string]*schema.Schema {
+	peers := func(description string, attributes map[string]*schema.Schema) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: fmt.Sprintf("%s the traffic is allowed %s", description, direction),
+			Elem: &schema.Resource{
+				Schema: attributes,
+			},
+		}
+	}
+
+	return map[string]*schema.Schema{
+		"to_pod_labels": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Labels of the pods the policy applies to, all the pods of the namespaces when empty",
+		},
+		"rule": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Description: "Allowed traffic, the traffic matching any of the rules is allowed",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"port": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Allowed ports, all the ports when empty",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"port": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "Number or name of the port",
+								},
+								"protocol": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									Default:      "TCP",
+									ValidateFunc: validation.StringInSlice([]string{"TCP", "UDP", "SCTP"}, false),
+									Description:  "Protocol of the port, one of TCP, UDP and SCTP",
+								},
+							},
+						},
+					},
+					"ip_block": peers("IP ranges", map[string]*schema.Schema{
+						"cidr": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsCIDR,
+							Description:  "Allowed IP range",
+						},
+						"except": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "IP ranges excluded from the allowed range",
+						},
+					}),
+					"pod_selector": peers("Pods", map[string]*schema.Schema{
+						"labels": {
+							Type:        schema.TypeMap,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Labels of the pods",
+						},
+					}),
+					"namespace_selector": peers("Namespaces", map[string]*schema.Schema{
+						"labels": {
+							Type:        schema.TypeMap,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Labels of the namespaces",
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+func expandNetworkPolicyInput(block map[string]interface{}) map[string]interface{} {
+	input := map[string]interface{}{
+		"toPodLabels": expandKeyValues(block["to_pod_labels"].(map[string]interface{})),
+	}
+
+	rules := []interface{}{}
+	for _, r := range block["rule"].([]interface{}) {
+		rule := r.(map[string]interface{})
+
+		ports := []interface{}{}
+		for _, p := range rule["port"].([]interface{}) {
+			port := p.(map[string]interface{})
+			ports = append(ports, map[string]interface{}{
+				"port":     port["port"],
+				"protocol": port["protocol"],
+			})
+		}
+
+		ruleSpec := []interface{}{}
+		for _, b := range rule["ip_block"].([]interface{}) {
+			ipBlock := b.(map[string]interface{})
+			ruleSpec = append(ruleSpec, map[string]interface{}{
+				"ipBlock": map[string]interface{}{
+					"cidr":   ipBlock["cidr"],
+					"except": expandStringList(ipBlock["except"].([]interface{})),
+				},
+			})
+		}
+		for _, s := range rule["pod_selector"].([]interface{}) {
+			ruleSpec = append(ruleSpec, map[string]interface{}{
+				"podSelector": map[string]interface{}{
+					"matchLabels": s.(map[string]interface{})["labels"],
+				},
+			})
+		}
+		for _, s := range rule["namespace_selector"].([]interface{}) {
+			ruleSpec = append(ruleSpec, map[string]interface{}{
+				"namespaceSelector": map[string]interface{}{
+					"matchLabels": s.(map[string]interface{})["labels"],
+				},
+			})
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"ports":    ports,
+			"ruleSpec": ruleSpec,
+		})
+	}
+	input["rules"] = rules
+
+	return input
+}
+
+func flattenNetworkPolicyInput(input map[string]interface{}) map[string]interface{} {
+	rules := []interface{}{}
+
+	data, _ := input["rules"].([]interface{})
+	for _, r := range data {
+		rule, _ := r.(map[string]interface{})
+
+		ports := []interface{}{}
+		portsData, _ := rule["ports"].([]interface{})
+		for _, p := range portsData {
+			port, _ := p.(map[string]interface{})
+			ports = append(ports, map[string]interface{}{
+				"port":     port["port"],
+				"protocol": port["protocol"],
+			})
+		}
+
+		ipBlocks, podSelectors, namespaceSelectors := []interface{}{}, []interface{}{}, []interface{}{}
+		ruleSpec, _ := rule["ruleSpec"].([]interface{})
+		for _, s := range ruleSpec {
+			spec, _ := s.(map[string]interface{})
+
+			if ipBlock, ok := spec["ipBlock"].(map[string]interface{}); ok {
+				ipBlocks = append(ipBlocks, map[string]interface{}{
+					"cidr":   ipBlock["cidr"],
+					"except": ipBlock["except"],
+				})
+			}
+			if selector, ok := spec["podSelector"].(map[string]interface{}); ok {
+				podSelectors = append(podSelectors, map[string]interface{}{
+					"labels": selector["matchLabels"],
+				})
+			}
+			if selector, ok := spec["namespaceSelector"].(map[string]interface{}); ok {
+				namespaceSelectors = append(namespaceSelectors, map[string]interface{}{
+					"labels": selector["matchLabels"],
+				})
+			}
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"port":               ports,
+			"ip_block":           ipBlocks,
+			"pod_selector":       podSelectors,
+			"namespace_selector": namespaceSelectors,
+		})
+	}
+
+	toPodLabels, _ := input["toPodLabels"].([]interface{})
+
+	return map[string]interface{}{
+		"to_pod_labels": flattenKeyValues(toPodLabels),
+		"rule":          rules,
+	}
+}
+
+// expandKeyValues converts labels to the list of key and value pairs used by
+// the inputs of recipes, sorted by key.
+func expandKeyValues(labels map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, map[string]interface{}{
+			"key":   key,
+			"value": labels[key],
+		})
+	}
+
+	return pairs
+}
+
+func flattenKeyValues(pairs []interface{}) map[string]interface{} {
+	labels := map[string]interface{}{}
+	for _, p := range pairs {
+		pair, _ := p.(map[string]interface{})
+		labels[fmt.Sprint(pair["key"])] = pair["value"]
+	}
+
+	return labels
+}
+
+var quotaPolicyFields = map[string]string{
+	"requests_cpu":             "requestsCpu",
+	"requests_memory":          "requestsMemory",
+	"limits_cpu":               "limitsCpu",
+	"limits_memory":            "limitsMemory",
+	"requests_storage":         "requestsStorage",
+	"persistent_volume_claims": "persistentvolumeclaims",
+	"services_load_balancers":  "servicesLoadbalancers",
+	"services_node_ports":      "servicesNodeports",
+}
+
+func quotaPolicyCustomSchema() map[string]*schema.Schema {
+	attributes := map[string]*schema.Schema{}
+
+	for attribute, description := range map[string]string{
+		"requests_cpu":     "Total CPU requested by the pods of a namespace, like 2 or 500m",
+		"requests_memory":  "Total memory requested by the pods of a namespace, like 4Gi",
+		"limits_cpu":       "Total CPU limit of the pods of a namespace",
+		"limits_memory":    "Total memory limit of the pods of a namespace",
+		"requests_storage": "Total storage requested by the persistent volume claims of a namespace, like 20Gi",
+	} {
+		attributes[attribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: description,
+		}
+	}
+
+	for attribute, description := range map[string]string{
+		"persistent_volume_claims": "Maximum number of persistent volume claims of a namespace, not limited when unset or 0",
+		"services_load_balancers":  "Maximum number of services of type LoadBalancer of a namespace, not limited when unset or 0",
+		"services_node_ports":      "Maximum number of services of type NodePort of a namespace, not limited when unset or 0",
+	} {
+		attributes[attribute] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  description,
+		}
+	}
+
+	return attributes
+}
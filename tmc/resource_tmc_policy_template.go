@@ -0,0 +1,304 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tanzuformers/terraform-provider-tmc/tanzuclient"
+)
+
+func resourceTmcPolicyTemplate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceTmcPolicyTemplateRead,
+		CreateContext: resourceTmcPolicyTemplateCreate,
+		UpdateContext: resourceTmcPolicyTemplateUpdate,
+		DeleteContext: resourceTmcPolicyTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the policy template",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the policy template",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				// Gatekeeper names the kind of the constraints after the
+				// template.
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z][a-z0-9]*$`), "must only contain lowercase letters and digits"),
+				Description:  "Name of the policy template, which is also the kind of the Gatekeeper constraints built on it",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the policy template",
+			},
+			"rego": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+					if err := checkRego(i.(string)); err != nil {
+						return nil, []error{fmt.Errorf("invalid Rego source in %s: %s", k, err)}
+					}
+					return nil, nil
+				},
+				Description: "Rego source of the template, usually read with the file function. It has to define the violation rule",
+			},
+			"parameters_schema": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description:      "OpenAPI v3 schema of the parameters of the custom policies built on the template, as JSON",
+			},
+			"data_inventory": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Kinds of Kubernetes objects synced to OPA, so the Rego source can look them up in data.inventory",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "API group of the objects, empty for the core group",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "API version of the objects, like v1",
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Kind of the objects, like Namespace",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTmcPolicyTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	var diags diag.Diagnostics
+
+	template, err := client.GetPolicyTemplate(ctx, d.Id())
+	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Policy template %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	if template.Spec == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read policy template",
+			Detail:   fmt.Sprintf("TMC returned the policy template %s without a spec", d.Id()),
+		})
+		return diags
+	}
+
+	constraintTemplate := tanzuclient.ConstraintTemplate{}
+	if err := json.Unmarshal([]byte(template.Spec.Object), &constraintTemplate); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read policy template",
+			Detail:   fmt.Sprintf("Cannot decode the ConstraintTemplate of the policy template %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	d.Set("name", template.FullName.Name)
+	d.Set("uid", template.Meta.UID)
+	d.Set("description", template.Meta.Description)
+
+	rego := ""
+	for _, target := range constraintTemplate.Spec.Targets {
+		if target.Target == tanzuclient.ConstraintTarget {
+			rego = target.Rego
+		}
+	}
+	d.Set("rego", rego)
+
+	parametersSchema := ""
+	if crdValidation := constraintTemplate.Spec.CRD.Spec.Validation; crdValidation != nil && len(crdValidation.OpenAPIV3Schema) > 0 {
+		parametersSchema = string(crdValidation.OpenAPIV3Schema)
+	}
+	d.Set("parameters_schema", parametersSchema)
+
+	if err := d.Set("data_inventory", flattenPolicyTemplateDataInventory(template.Spec.DataInventory)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read policy template",
+			Detail:   fmt.Sprintf("Error setting data inventory for resource %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+func resourceTmcPolicyTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	templateName := d.Get("name").(string)
+
+	spec, err := expandPolicyTemplateSpec(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.CreatePolicyTemplate(ctx, templateName, d.Get("description").(string), spec)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to create policy template",
+			Detail:   fmt.Sprintf("Cannot create the policy template %s: %s", templateName, err),
+		})
+		return diags
+	}
+
+	d.SetId(templateName)
+
+	return resourceTmcPolicyTemplateRead(ctx, d, meta)
+}
+
+func resourceTmcPolicyTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	spec, err := expandPolicyTemplateSpec(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.UpdatePolicyTemplate(ctx, d.Id(), d.Get("description").(string), spec)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update policy template",
+			Detail:   fmt.Sprintf("Cannot update the policy template %s with the new values: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	return resourceTmcPolicyTemplateRead(ctx, d, meta)
+}
+
+func resourceTmcPolicyTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*tanzuclient.Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	err := client.DeletePolicyTemplate(ctx, d.Id())
+	if err != nil && !tanzuclient.IsNotFound(err) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to delete policy template",
+			Detail:   fmt.Sprintf("Cannot delete the policy template %s: %s", d.Id(), err),
+		})
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// expandPolicyTemplateSpec builds the ConstraintTemplate of the policy
+// template.
+func expandPolicyTemplateSpec(d *schema.ResourceData) (*tanzuclient.PolicyTemplateSpec, error) {
+	name := d.Get("name").(string)
+	rego := d.Get("rego").(string)
+
+	constraintTemplate := tanzuclient.ConstraintTemplate{
+		APIVersion: "templates.gatekeeper.sh/v1beta1",
+		Kind:       tanzuclient.PolicyTemplateObjectType,
+		Metadata: tanzuclient.ConstraintTemplateMetadata{
+			Name: name,
+		},
+		Spec: tanzuclient.ConstraintTemplateSpec{
+			CRD: tanzuclient.ConstraintTemplateCRD{
+				Spec: tanzuclient.ConstraintTemplateCRDSpec{
+					Names: tanzuclient.ConstraintTemplateNames{
+						Kind: name,
+					},
+				},
+			},
+			Targets: []tanzuclient.ConstraintTemplateTarget{
+				{Target: tanzuclient.ConstraintTarget, Rego: rego},
+			},
+		},
+	}
+
+	if parametersSchema := d.Get("parameters_schema").(string); parametersSchema != "" {
+		constraintTemplate.Spec.CRD.Spec.Validation = &tanzuclient.ConstraintTemplateValidation{
+			OpenAPIV3Schema: json.RawMessage(parametersSchema),
+		}
+	}
+
+	object, err := json.Marshal(constraintTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &tanzuclient.PolicyTemplateSpec{
+		Object:       string(object),
+		ObjectType:   tanzuclient.PolicyTemplateObjectType,
+		TemplateType: tanzuclient.PolicyTemplateType,
+	}
+
+	for _, i := range d.Get("data_inventory").([]interface{}) {
+		inventory := i.(map[string]interface{})
+
+		spec.DataInventory = append(spec.DataInventory, tanzuclient.PolicyTemplateDataInventory{
+			Group:   inventory["group"].(string),
+			Version: inventory["version"].(string),
+			Kind:    inventory["kind"].(string),
+		})
+	}
+
+	return spec, nil
+}
+
+func flattenPolicyTemplateDataInventory(inventories []tanzuclient.PolicyTemplateDataInventory) []interface{} {
+	data := make([]interface{}, 0, len(inventories))
+
+	for _, inventory := range inventories {
+		data = append(data, map[string]interface{}{
+			"group":   inventory.Group,
+			"version": inventory.Version,
+			"kind":    inventory.Kind,
+		})
+	}
+
+	return data
+}
@@ -0,0 +1,180 @@
+package tmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+const testAccRegoRequiredLabels = `package requiredlabels # labels of the parameters
+
+# Objects have to carry all the labels of the parameters
+violation[{"msg": msg}] {
+  provided := {label | input.review.object.metadata.labels[label]}
+  required := {label | label := input.parameters.labels[_]}
+  missing := required - provided
+  count(missing) > 0
+  msg := sprintf("missing labels: %v", [missing])
+}
+`
+
+func TestAccResourceTmcPolicyTemplate(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	regoFile := testAccRegoFile(t, testAccRegoRequiredLabels)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcPolicyTemplateDestroy(server, "requiredlabels"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcPolicyTemplateConfig(server, regoFile, "Requires labels"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_policy_template.test", "id", "requiredlabels"),
+					resource.TestCheckResourceAttr("tmc_policy_template.test", "rego", testAccRegoRequiredLabels),
+					resource.TestCheckResourceAttr("tmc_policy_template.test", "data_inventory.0.kind", "Namespace"),
+					testAccCheckTmcPolicyTemplateObject(server, "requiredlabels"),
+				),
+			},
+			{
+				Config: testAccResourceTmcPolicyTemplateConfig(server, regoFile, "Requires the labels of the parameters"),
+				Check:  resource.TestCheckResourceAttr("tmc_policy_template.test", "description", "Requires the labels of the parameters"),
+			},
+			{
+				ResourceName:      "tmc_policy_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcPolicyTemplateInvalidRego(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	for _, c := range []struct {
+		rego  string
+		error string
+	}{
+		{"violation[msg] { true }", `template.rego:1: rego_parse_error: package expected`},
+		{"package test\n\nviolation[msg] {\n  msg := \"unterminated\n}", `template.rego:4: rego_parse_error`},
+		{"package test\n\nviolation[msg] {\n  msg := concat(\",\", [\"a\"]\n}", `template.rego:5: rego_parse_error: unexpected } token`},
+		{"package test\n\nviolation[{\"msg\": msg}] { msg := }", `template.rego:3: rego_parse_error: unexpected } token`},
+		{"package test\n\ndeny[msg] { msg := \"denied\" }", `missing violation rule`},
+	} {
+		regoFile := testAccRegoFile(t, c.rego)
+
+		resource.UnitTest(t, resource.TestCase{
+			ProviderFactories: testAccProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      testAccResourceTmcPolicyTemplateConfig(server, regoFile, "Invalid"),
+					ExpectError: regexp.MustCompile(c.error),
+				},
+			},
+		})
+
+		// The invalid templates never reach TMC
+		if _, ok := server.PolicyTemplate("requiredlabels"); ok {
+			t.Fatalf("policy template with invalid Rego %q was created", c.rego)
+		}
+	}
+}
+
+// testAccRegoFile writes the Rego source to a file for the file function.
+func testAccRegoFile(t *testing.T, source string) string {
+	regoFile := filepath.Join(t.TempDir(), "template.rego")
+	if err := ioutil.WriteFile(regoFile, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return regoFile
+}
+
+func testAccResourceTmcPolicyTemplateConfig(server *tmcfake.Server, regoFile, description string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_policy_template" "test" {
+  name        = "requiredlabels"
+  description = %q
+  rego        = file(%q)
+
+  parameters_schema = jsonencode({
+    properties = {
+      labels = {
+        type  = "array"
+        items = { type = "string" }
+      }
+    }
+  })
+
+  data_inventory {
+    version = "v1"
+    kind    = "Namespace"
+  }
+}
+`, description, regoFile)
+}
+
+// testAccCheckTmcPolicyTemplateObject checks the ConstraintTemplate sent to
+// TMC for the template.
+func testAccCheckTmcPolicyTemplateObject(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		template, ok := server.PolicyTemplate(name)
+		if !ok {
+			return fmt.Errorf("policy template %s was not created", name)
+		}
+
+		spec, _ := template["spec"].(map[string]interface{})
+		if spec["templateType"] != "OPAGatekeeper" || spec["objectType"] != "ConstraintTemplate" {
+			return fmt.Errorf("unexpected template type %v/%v", spec["templateType"], spec["objectType"])
+		}
+
+		object := struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				CRD struct {
+					Spec struct {
+						Names struct {
+							Kind string `json:"kind"`
+						} `json:"names"`
+					} `json:"spec"`
+				} `json:"crd"`
+				Targets []struct {
+					Target string `json:"target"`
+					Rego   string `json:"rego"`
+				} `json:"targets"`
+			} `json:"spec"`
+		}{}
+		if err := json.Unmarshal([]byte(fmt.Sprint(spec["object"])), &object); err != nil {
+			return fmt.Errorf("cannot decode the ConstraintTemplate: %s", err)
+		}
+
+		if object.Metadata.Name != name || object.Spec.CRD.Spec.Names.Kind != name {
+			return fmt.Errorf("expected the ConstraintTemplate and its kind to be named %s, got %s and %s", name, object.Metadata.Name, object.Spec.CRD.Spec.Names.Kind)
+		}
+		if len(object.Spec.Targets) != 1 || object.Spec.Targets[0].Target != "admission.k8s.gatekeeper.sh" || object.Spec.Targets[0].Rego != testAccRegoRequiredLabels {
+			return fmt.Errorf("unexpected targets %+v", object.Spec.Targets)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTmcPolicyTemplateDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.PolicyTemplate(name); ok {
+			return fmt.Errorf("policy template %s still exists", name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,407 @@
+package tmc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcPolicySecurity(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	fullName := tmcfake.Object{"clusterGroupName": "tf-acc-group", "name": "tf-acc-security"}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcPolicyDestroy(server, fullName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcPolicyClusterGroupConfig(server, `
+  security {
+    baseline {
+      audit = true
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_policy.test", "id", "cluster_group/tf-acc-group/tf-acc-security"),
+					resource.TestCheckResourceAttr("tmc_policy.test", "security.0.baseline.0.audit", "true"),
+					resource.TestCheckResourceAttr("tmc_policy.test", "security.0.baseline.0.disable_native_psp", "false"),
+					testAccCheckTmcPolicySpec(server, fullName, "security-policy", "baseline", map[string]interface{}{
+						"audit":            true,
+						"disableNativePsp": false,
+					}),
+				),
+			},
+			{
+				Config: testAccResourceTmcPolicyClusterGroupConfig(server, `
+  security {
+    custom {
+      allow_host_network         = true
+      read_only_root_file_system = true
+      allowed_volumes            = ["configMap", "secret"]
+    }
+  }
+
+  namespace_selector {
+    match_expression {
+      key      = "team"
+      operator = "In"
+      values   = ["a", "b"]
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_policy.test", "security.0.baseline.#", "0"),
+					resource.TestCheckResourceAttr("tmc_policy.test", "security.0.custom.0.allowed_volumes.#", "2"),
+					resource.TestCheckResourceAttr("tmc_policy.test", "namespace_selector.0.match_expression.0.values.1", "b"),
+					testAccCheckTmcPolicySpec(server, fullName, "security-policy", "custom", map[string]interface{}{
+						"audit":                     false,
+						"disableNativePsp":          false,
+						"allowPrivilegedContainers": false,
+						"allowPrivilegeEscalation":  false,
+						"allowHostNetwork":          true,
+						"allowHostNamespaceSharing": false,
+						"readOnlyRootFileSystem":    true,
+						"allowedVolumes":            []interface{}{"configMap", "secret"},
+						"allowedCapabilities":       []interface{}{},
+						"requiredDropCapabilities":  []interface{}{},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcPolicyImageRegistry(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	fullName := tmcfake.Object{"workspaceName": "tf-acc-workspace", "name": "tf-acc-images"}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcPolicyDestroy(server, fullName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_workspace" "test" {
+  name = "tf-acc-workspace"
+}
+
+resource "tmc_policy" "test" {
+  name           = "tf-acc-images"
+  workspace_name = tmc_workspace.test.name
+
+  image_registry {
+    custom {
+      rule {
+        hostname = "registry.example.com"
+      }
+
+      rule {
+        hostname       = "*.docker.io"
+        image_name     = "library/*"
+        require_digest = true
+      }
+    }
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_policy.test", "id", "workspace/tf-acc-workspace/tf-acc-images"),
+					resource.TestCheckResourceAttr("tmc_policy.test", "image_registry.0.custom.0.rule.#", "2"),
+					testAccCheckTmcPolicySpec(server, fullName, "image-policy", "custom", map[string]interface{}{
+						"audit": false,
+						"rules": []interface{}{
+							map[string]interface{}{"hostname": "registry.example.com"},
+							map[string]interface{}{"hostname": "*.docker.io", "imageName": "library/*", "requireDigest": true},
+						},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcPolicyNetwork(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	fullName := tmcfake.Object{
+		"managementClusterName": "aws-hosted",
+		"provisionerName":       "tf-acc",
+		"clusterName":           "tf-acc-cluster",
+		"namespaceName":         "tf-acc-namespace",
+		"name":                  "tf-acc-network",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcPolicyNamespaceConfig(server, `
+  network {
+    deny_all {}
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_policy.test", "id", "namespace/aws-hosted/tf-acc/tf-acc-cluster/tf-acc-namespace/tf-acc-network"),
+					resource.TestCheckResourceAttr("tmc_policy.test", "network.0.deny_all.#", "1"),
+					testAccCheckTmcPolicySpec(server, fullName, "network-policy", "deny-all", nil),
+				),
+			},
+			{
+				Config: testAccResourceTmcPolicyNamespaceConfig(server, `
+  network {
+    custom_ingress {
+      to_pod_labels = {
+        app = "web"
+      }
+
+      rule {
+        port {
+          port = "443"
+        }
+
+        ip_block {
+          cidr   = "10.0.0.0/16"
+          except = ["10.0.1.0/24"]
+        }
+
+        pod_selector {
+          labels = {
+            app = "proxy"
+          }
+        }
+      }
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_policy.test", "network.0.deny_all.#", "0"),
+					resource.TestCheckResourceAttr("tmc_policy.test", "network.0.custom_ingress.0.rule.0.port.0.protocol", "TCP"),
+					testAccCheckTmcPolicySpec(server, fullName, "network-policy", "custom-ingress", map[string]interface{}{
+						"toPodLabels": []interface{}{
+							map[string]interface{}{"key": "app", "value": "web"},
+						},
+						"rules": []interface{}{
+							map[string]interface{}{
+								"ports": []interface{}{
+									map[string]interface{}{"port": "443", "protocol": "TCP"},
+								},
+								"ruleSpec": []interface{}{
+									map[string]interface{}{"ipBlock": map[string]interface{}{"cidr": "10.0.0.0/16", "except": []interface{}{"10.0.1.0/24"}}},
+									map[string]interface{}{"podSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "proxy"}}},
+								},
+							},
+						},
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcPolicyQuota(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	fullName := tmcfake.Object{
+		"managementClusterName": "aws-hosted",
+		"provisionerName":       "tf-acc",
+		"clusterName":           "tf-acc-cluster",
+		"name":                  "tf-acc-quota",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcPolicyDestroy(server, fullName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcPolicyClusterConfig(server, `
+  quota {
+    small {}
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_policy.test", "id", "cluster/aws-hosted/tf-acc/tf-acc-cluster/tf-acc-quota"),
+					testAccCheckTmcPolicySpec(server, fullName, "namespace-quota-policy", "small", nil),
+				),
+			},
+			{
+				Config: testAccResourceTmcPolicyClusterConfig(server, `
+  quota {
+    custom {
+      requests_cpu             = "2"
+      requests_memory          = "4Gi"
+      persistent_volume_claims = 5
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_policy.test", "quota.0.custom.0.persistent_volume_claims", "5"),
+					testAccCheckTmcPolicySpec(server, fullName, "namespace-quota-policy", "custom", map[string]interface{}{
+						"requestsCpu":            "2",
+						"requestsMemory":         "4Gi",
+						"persistentvolumeclaims": float64(5),
+					}),
+				),
+			},
+			{
+				ResourceName:      "tmc_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcPolicyInvalidScope(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_policy" "test" {
+  name               = "tf-acc-quota"
+  cluster_group_name = "default"
+  namespace_name     = "tf-acc-namespace"
+
+  quota {
+    small {}
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`"namespace_name": conflicts with cluster_group_name`),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+resource "tmc_policy" "test" {
+  name         = "tf-acc-quota"
+  cluster_name = "tf-acc-cluster"
+
+  quota {
+    small {}
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`all of .cluster_name,management_cluster,provisioner_name.\s+must be\s+specified`),
+			},
+		},
+	})
+}
+
+func TestAccResourceTmcPolicyImportInvalidID(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcPolicyClusterGroupConfig(server, `
+  quota {
+    small {}
+  }
+`),
+				ResourceName:  "tmc_policy.test",
+				ImportState:   true,
+				ImportStateId: "organization/tf-acc-security",
+				ExpectError:   regexp.MustCompile(`expected one of namespace/management_cluster/provisioner_name/cluster_name/namespace_name/name`),
+			},
+		},
+	})
+}
+
+func testAccResourceTmcPolicyClusterGroupConfig(server *tmcfake.Server, spec string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_cluster_group" "test" {
+  name = "tf-acc-group"
+}
+
+resource "tmc_policy" "test" {
+  name               = "tf-acc-security"
+  cluster_group_name = tmc_cluster_group.test.name
+%s}
+`, spec)
+}
+
+func testAccResourceTmcPolicyClusterConfig(server *tmcfake.Server, spec string) string {
+	return testAccResourceTmcClusterConfig(server, "first description", "default") + fmt.Sprintf(`
+resource "tmc_policy" "test" {
+  name               = "tf-acc-quota"
+  management_cluster = tmc_cluster.test.management_cluster
+  provisioner_name   = tmc_cluster.test.provisioner_name
+  cluster_name       = tmc_cluster.test.name
+%s}
+`, spec)
+}
+
+func testAccResourceTmcPolicyNamespaceConfig(server *tmcfake.Server, spec string) string {
+	return testAccResourceTmcNamespaceConfig(server, "tmc_workspace.first", false) + fmt.Sprintf(`
+resource "tmc_policy" "test" {
+  name               = "tf-acc-network"
+  management_cluster = tmc_namespace.test.management_cluster
+  provisioner_name   = tmc_namespace.test.provisioner_name
+  cluster_name       = tmc_namespace.test.cluster_name
+  namespace_name     = tmc_namespace.test.name
+%s}
+`, spec)
+}
+
+func testAccCheckTmcPolicySpec(server *tmcfake.Server, fullName tmcfake.Object, policyType, recipe string, input map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policy, ok := server.Policy(fullName)
+		if !ok {
+			return fmt.Errorf("policy %s was not created", fullName["name"])
+		}
+
+		spec, _ := policy["spec"].(map[string]interface{})
+		if spec["type"] != policyType || spec["recipe"] != recipe {
+			return fmt.Errorf("expected the policy to use the %s recipe of the %s type, got %v/%v", recipe, policyType, spec["recipe"], spec["type"])
+		}
+
+		actual, _ := spec["input"].(map[string]interface{})
+		if len(input) == 0 && len(actual) == 0 {
+			return nil
+		}
+		if !reflect.DeepEqual(actual, input) {
+			return fmt.Errorf("expected the input of the policy to be %v, got %v", input, actual)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTmcPolicyDestroy(server *tmcfake.Server, fullName tmcfake.Object) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Policy(fullName); ok {
+			return fmt.Errorf("policy %s still exists", fullName["name"])
+		}
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package tmc
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,8 +17,24 @@ func resourceTmcWorkspace() *schema.Resource {
 		CreateContext: resourceTmcWorkspaceCreate,
 		UpdateContext: resourceTmcWorkspaceUpdate,
 		DeleteContext: resourceTmcWorkspaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceTmcWorkspaceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceTmcWorkspaceStateUpgradeV0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Tanzu Workspace, which is its name",
+			},
+			"uid": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Unique ID of the Tanzu Workspace",
@@ -49,11 +66,20 @@ func resourceTmcWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	workspace, err := client.GetWorkspace(d.Get("name").(string))
+	workspaceName := d.Id()
+
+	workspace, err := client.GetWorkspace(ctx, workspaceName)
 	if err != nil {
+		if tanzuclient.IsNotFound(err) {
+			log.Printf("[WARN] Workspace %s not found, removing from state", workspaceName)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
+	d.Set("name", workspace.FullName.Name)
+	d.Set("uid", workspace.Meta.UID)
 	d.Set("description", workspace.Meta.Description)
 	if err := d.Set("labels", workspace.Meta.Labels); err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -63,7 +89,6 @@ func resourceTmcWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta
 		})
 		return diags
 	}
-	d.SetId(string(workspace.Meta.UID))
 
 	return diags
 }
@@ -71,12 +96,12 @@ func resourceTmcWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta
 func resourceTmcWorkspaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*tanzuclient.Client)
 
-	workspace, err := client.CreateWorkspace(d.Get("name").(string), d.Get("description").(string), d.Get("labels").(map[string]interface{}))
+	workspace, err := client.CreateWorkspace(ctx, d.Get("name").(string), d.Get("description").(string), d.Get("labels").(map[string]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(string(workspace.Meta.UID))
+	d.SetId(workspace.FullName.Name)
 
 	return resourceTmcWorkspaceRead(ctx, d, meta)
 }
@@ -93,7 +118,7 @@ func resourceTmcWorkspaceUpdate(ctx context.Context, d *schema.ResourceData, met
 		description := d.Get("description").(string)
 		labels := d.Get("labels").(map[string]interface{})
 
-		_, err := client.UpdateWorkspace(workspaceName, description, labels)
+		_, err := client.UpdateWorkspace(ctx, workspaceName, description, labels)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -115,7 +140,7 @@ func resourceTmcWorkspaceDelete(ctx context.Context, d *schema.ResourceData, met
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	err := client.DeleteWorkspace(d.Get("name").(string))
+	err := client.DeleteWorkspace(ctx, d.Get("name").(string))
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -129,3 +154,37 @@ func resourceTmcWorkspaceDelete(ctx context.Context, d *schema.ResourceData, met
 
 	return diags
 }
+
+// resourceTmcWorkspaceV0 is the schema of workspaces whose ID was the UID
+// of the workspace rather than its name.
+func resourceTmcWorkspaceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"labels": labelsSchema(),
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTmcWorkspaceStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	rawState["uid"] = rawState["id"]
+	rawState["id"] = rawState["name"]
+
+	return rawState, nil
+}
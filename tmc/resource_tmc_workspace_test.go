@@ -0,0 +1,96 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tanzuformers/terraform-provider-tmc/internal/tmcfake"
+)
+
+func TestAccResourceTmcWorkspace(t *testing.T) {
+	server := tmcfake.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckTmcWorkspaceDestroy(server, "tf-acc-workspace"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceTmcWorkspaceConfig(server, "tf-acc-workspace", "first description", "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTmcWorkspaceExists(server, "tf-acc-workspace"),
+					resource.TestCheckResourceAttr("tmc_workspace.test", "name", "tf-acc-workspace"),
+					resource.TestCheckResourceAttr("tmc_workspace.test", "description", "first description"),
+					resource.TestCheckResourceAttr("tmc_workspace.test", "labels.env", "test"),
+					resource.TestCheckResourceAttrSet("tmc_workspace.test", "id"),
+				),
+			},
+			{
+				Config: testAccResourceTmcWorkspaceConfig(server, "tf-acc-workspace", "second description", "staging"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tmc_workspace.test", "description", "second description"),
+					resource.TestCheckResourceAttr("tmc_workspace.test", "labels.env", "staging"),
+				),
+			},
+			{
+				ResourceName:            "tmc_workspace.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+		},
+	})
+}
+
+func testAccResourceTmcWorkspaceConfig(server *tmcfake.Server, name, description, env string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "tmc_workspace" "test" {
+  name        = %q
+  description = %q
+
+  labels = {
+    env = %q
+  }
+}
+`, name, description, env)
+}
+
+func testAccCheckTmcWorkspaceExists(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Workspace(name); !ok {
+			return fmt.Errorf("workspace %s was not created", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTmcWorkspaceDestroy(server *tmcfake.Server, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := server.Workspace(name); ok {
+			return fmt.Errorf("workspace %s still exists", name)
+		}
+		return nil
+	}
+}
+
+func TestResourceTmcWorkspaceStateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		"id":   "ws:01F6ZW5QKMJY5Q0ZPD3JXK0V3T",
+		"name": "tf-acc-workspace",
+	}
+
+	v1, err := resourceTmcWorkspaceStateUpgradeV0(context.Background(), v0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v1["id"] != "tf-acc-workspace" {
+		t.Errorf("expected id to be the workspace name, got %v", v1["id"])
+	}
+	if v1["uid"] != "ws:01F6ZW5QKMJY5Q0ZPD3JXK0V3T" {
+		t.Errorf("expected uid to be the previous id, got %v", v1["uid"])
+	}
+}
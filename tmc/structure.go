@@ -0,0 +1,39 @@
+package tmc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func expandStringList(list []interface{}) []string {
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// expandSortedStringSet returns the strings of the set in a stable order, so
+// the requests only change when the set does.
+func expandSortedStringSet(set *schema.Set) []string {
+	list := expandStringList(set.List())
+	sort.Strings(list)
+
+	return list
+}
+
+func expandStringMap(m map[string]interface{}) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = fmt.Sprint(v)
+	}
+	return result
+}